@@ -0,0 +1,258 @@
+package controllers
+
+import (
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type AcademicUnitController struct {
+	Service services.AcademicUnitService
+}
+
+func NewAcademicUnitController(service services.AcademicUnitService) *AcademicUnitController {
+	return &AcademicUnitController{Service: service}
+}
+
+// ListDepartments godoc
+// @Summary      List Departments
+// @Description  Melihat daftar departemen
+// @Tags         Academic Units
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  utils.JSONResponse
+// @Router       /departments [get]
+func (ctrl *AcademicUnitController) ListDepartments(c *fiber.Ctx) error {
+	departments, status, err := ctrl.Service.ListDepartments(c.Context())
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Departments retrieved successfully", departments)
+}
+
+// GetDepartment godoc
+// @Summary      Get Department By ID
+// @Tags         Academic Units
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Department ID (UUID)"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      404  {object}  utils.JSONResponse
+// @Router       /departments/{id} [get]
+func (ctrl *AcademicUnitController) GetDepartment(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid Department ID format")
+	}
+
+	department, status, err := ctrl.Service.GetDepartment(c.Context(), id)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Department retrieved successfully", department)
+}
+
+// CreateDepartment godoc
+// @Summary      Create Department
+// @Description  Admin membuat departemen baru
+// @Tags         Academic Units
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body models.DepartmentRequest true "Data Departemen"
+// @Success      201  {object}  utils.JSONResponse
+// @Failure      409  {object}  utils.JSONResponse
+// @Router       /departments [post]
+func (ctrl *AcademicUnitController) CreateDepartment(c *fiber.Ctx) error {
+	var req models.DepartmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	department, status, err := ctrl.Service.CreateDepartment(c.Context(), &req)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Department created successfully", department)
+}
+
+// UpdateDepartment godoc
+// @Summary      Update Department
+// @Description  Admin mengubah nama/kode departemen (nama di profil dosen ikut diperbarui)
+// @Tags         Academic Units
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Department ID (UUID)"
+// @Param        request body models.DepartmentRequest true "Data Departemen"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      409  {object}  utils.JSONResponse
+// @Router       /departments/{id} [put]
+func (ctrl *AcademicUnitController) UpdateDepartment(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid Department ID format")
+	}
+
+	var req models.DepartmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	department, status, err := ctrl.Service.UpdateDepartment(c.Context(), id, &req)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Department updated successfully", department)
+}
+
+// DeleteDepartment godoc
+// @Summary      Delete Department
+// @Description  Admin menghapus departemen yang tidak lagi memiliki prodi, dosen, atau staf
+// @Tags         Academic Units
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Department ID (UUID)"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      409  {object}  utils.JSONResponse
+// @Router       /departments/{id} [delete]
+func (ctrl *AcademicUnitController) DeleteDepartment(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid Department ID format")
+	}
+
+	status, err := ctrl.Service.DeleteDepartment(c.Context(), id)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Department deleted successfully", nil)
+}
+
+// ListStudyPrograms godoc
+// @Summary      List Study Programs
+// @Description  Melihat daftar program studi, opsional difilter per departemen
+// @Tags         Academic Units
+// @Produce      json
+// @Security     BearerAuth
+// @Param        departmentId query string false "Department ID (UUID)"
+// @Success      200  {object}  utils.JSONResponse
+// @Router       /study-programs [get]
+func (ctrl *AcademicUnitController) ListStudyPrograms(c *fiber.Ctx) error {
+	var departmentID *uuid.UUID
+	if raw := c.Query("departmentId"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid Department ID format")
+		}
+		departmentID = &id
+	}
+
+	programs, status, err := ctrl.Service.ListStudyPrograms(c.Context(), departmentID)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Study programs retrieved successfully", programs)
+}
+
+// GetStudyProgram godoc
+// @Summary      Get Study Program By ID
+// @Tags         Academic Units
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Study Program ID (UUID)"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      404  {object}  utils.JSONResponse
+// @Router       /study-programs/{id} [get]
+func (ctrl *AcademicUnitController) GetStudyProgram(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid Study Program ID format")
+	}
+
+	program, status, err := ctrl.Service.GetStudyProgram(c.Context(), id)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Study program retrieved successfully", program)
+}
+
+// CreateStudyProgram godoc
+// @Summary      Create Study Program
+// @Description  Admin membuat program studi di bawah departemen, opsional sekaligus menunjuk Kaprodi
+// @Tags         Academic Units
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body models.StudyProgramRequest true "Data Program Studi"
+// @Success      201  {object}  utils.JSONResponse
+// @Failure      409  {object}  utils.JSONResponse
+// @Router       /study-programs [post]
+func (ctrl *AcademicUnitController) CreateStudyProgram(c *fiber.Ctx) error {
+	var req models.StudyProgramRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	program, status, err := ctrl.Service.CreateStudyProgram(c.Context(), &req)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Study program created successfully", program)
+}
+
+// UpdateStudyProgram godoc
+// @Summary      Update Study Program
+// @Description  Admin mengubah program studi (nama di profil mahasiswa ikut diperbarui)
+// @Tags         Academic Units
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Study Program ID (UUID)"
+// @Param        request body models.StudyProgramRequest true "Data Program Studi"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      409  {object}  utils.JSONResponse
+// @Router       /study-programs/{id} [put]
+func (ctrl *AcademicUnitController) UpdateStudyProgram(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid Study Program ID format")
+	}
+
+	var req models.StudyProgramRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	program, status, err := ctrl.Service.UpdateStudyProgram(c.Context(), id, &req)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Study program updated successfully", program)
+}
+
+// DeleteStudyProgram godoc
+// @Summary      Delete Study Program
+// @Description  Admin menghapus program studi yang tidak memiliki mahasiswa
+// @Tags         Academic Units
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Study Program ID (UUID)"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      409  {object}  utils.JSONResponse
+// @Router       /study-programs/{id} [delete]
+func (ctrl *AcademicUnitController) DeleteStudyProgram(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid Study Program ID format")
+	}
+
+	status, err := ctrl.Service.DeleteStudyProgram(c.Context(), id)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Study program deleted successfully", nil)
+}
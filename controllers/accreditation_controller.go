@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"prestasi-mahasiswa-api/middleware"
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type AccreditationController struct {
+	Service services.AccreditationService
+}
+
+func NewAccreditationController(service services.AccreditationService) *AccreditationController {
+	return &AccreditationController{Service: service}
+}
+
+func parseAccreditationQuery(c *fiber.Ctx) (models.AccreditationQuery, error) {
+	q := models.AccreditationQuery{ReferenceYear: c.QueryInt("year", 0)}
+	if raw := c.Query("studyProgramId"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return q, err
+		}
+		q.StudyProgramID = &id
+	}
+	return q, nil
+}
+
+// GetReport godoc
+// @Summary      Get Accreditation Report
+// @Description  Tabel prestasi akademik & non-akademik per program studi untuk akreditasi (BAN-PT/LAM) selama tiga tahun terakhir (TS-2 s.d. TS), dikelompokkan per tingkat lokal/wilayah, nasional, dan internasional berdasarkan details.level. Kategori akademik ditentukan dari jenis prestasi (ACCREDITATION_ACADEMIC_TYPES) atau details.category. Prestasi dengan tingkat tidak dikenali dicantumkan di unmapped.
+// @Tags         Reports
+// @Produce      json
+// @Security     BearerAuth
+// @Param        studyProgramId query string false "Program Studi (UUID); kosong = semua prodi dalam scope"
+// @Param        year           query int    false "Tahun acuan TS (default tahun berjalan)"
+// @Success      200  {object}  utils.JSONResponse{data=models.AccreditationReport}
+// @Failure      400  {object}  utils.JSONResponse
+// @Failure      403  {object}  utils.JSONResponse
+// @Failure      404  {object}  utils.JSONResponse
+// @Router       /reports/accreditation [get]
+func (ctrl *AccreditationController) GetReport(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	q, err := parseAccreditationQuery(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid studyProgramId")
+	}
+
+	report, status, err := ctrl.Service.GetReport(c.Context(), claims, q)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Accreditation report generated", report)
+}
+
+// Export godoc
+// @Summary      Export Accreditation Report
+// @Description  Mengunduh paket tabel akreditasi. XLSX (default) berisi satu worksheet per program studi.
+// @Tags         Reports
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce      application/pdf
+// @Produce      text/csv
+// @Security     BearerAuth
+// @Param        studyProgramId query string false "Program Studi (UUID); kosong = semua prodi dalam scope"
+// @Param        year           query int    false "Tahun acuan TS (default tahun berjalan)"
+// @Param        format         query string false "xlsx (default) | pdf | csv"
+// @Success      200  {file}    file
+// @Failure      400  {object}  utils.JSONResponse
+// @Failure      403  {object}  utils.JSONResponse
+// @Failure      404  {object}  utils.JSONResponse
+// @Router       /reports/accreditation/export [get]
+func (ctrl *AccreditationController) Export(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	q, err := parseAccreditationQuery(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid studyProgramId")
+	}
+
+	job, status, err := ctrl.Service.ExportReport(c.Context(), claims, q, c.Query("format"))
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return sendExport(c, job)
+}
@@ -104,6 +104,48 @@ func (ctrl *AchievementController) HardDelete(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, status, "Achievement permanently deleted", nil)
 }
 
+// ListTrash godoc
+// @Summary      List Trashed Achievements
+// @Description  Melihat prestasi yang sudah dihapus (soft delete). User melihat miliknya, pemegang achievement:delete:all melihat semua.
+// @Tags         Achievements
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      403  {object}  utils.JSONResponse
+// @Router       /achievements/trash [get]
+func (ctrl *AchievementController) ListTrash(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	resp, status, err := ctrl.Service.ListTrash(c.Context(), claims)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Trashed achievements retrieved", resp)
+}
+
+// Restore godoc
+// @Summary      Restore Achievement from Trash
+// @Description  Mengembalikan prestasi yang sudah dihapus (soft delete) oleh pemilik atau pemegang achievement:delete:all
+// @Tags         Achievements
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Achievement ID (UUID)"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      404  {object}  utils.JSONResponse
+// @Router       /achievements/{id}/restore [post]
+func (ctrl *AchievementController) Restore(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	resp, status, err := ctrl.Service.RestoreFromTrash(c.Context(), claims, id)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Achievement restored", resp)
+}
+
 // Submit godoc
 // @Summary      Submit Achievement
 // @Tags         Achievements
@@ -135,7 +177,7 @@ func (ctrl *AchievementController) Verify(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid ID format")
 	}
 
-	resp, status, err := ctrl.Service.VerifyAchievement(c.Context(), claims.UserID, id)
+	resp, status, err := ctrl.Service.VerifyAchievement(c.Context(), claims, id)
 	if err != nil {
 		return utils.ErrorResponse(c, status, err.Error())
 	}
@@ -159,13 +201,71 @@ func (ctrl *AchievementController) Reject(c *fiber.Ctx) error {
 	}
 	_ = c.BodyParser(&req) // Ignore error, use default if empty
 	
-	resp, status, err := ctrl.Service.RejectAchievement(c.Context(), claims.UserID, id, req.RejectionNote)
+	resp, status, err := ctrl.Service.RejectAchievement(c.Context(), claims, id, req.RejectionNote)
 	if err != nil {
 		return utils.ErrorResponse(c, status, err.Error())
 	}
 	return utils.SuccessResponse(c, status, "Achievement rejected", resp)
 }
 
+// Revoke godoc
+// @Summary      Revoke Achievement Verification
+// @Description  Mencabut verifikasi prestasi (status kembali menjadi rejected dengan alasan pencabutan). Kode verifikasi publik prestasi ini langsung tidak berlaku.
+// @Tags         Achievements
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Achievement Reference ID (UUID)"
+// @Param        request body models.RevokeAchievementRequest true "Alasan pencabutan"
+// @Success      200  {object}  utils.JSONResponse{data=models.AchievementReference}
+// @Failure      400  {object}  utils.JSONResponse
+// @Failure      403  {object}  utils.JSONResponse
+// @Failure      409  {object}  utils.JSONResponse
+// @Router       /achievements/{id}/revoke [post]
+func (ctrl *AchievementController) Revoke(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	var req models.RevokeAchievementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	resp, status, err := ctrl.Service.RevokeVerification(c.Context(), claims, id, req.Reason)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Achievement verification revoked", resp)
+}
+
+// PublicVerify godoc
+// @Summary      Verify Achievement Certificate (Public)
+// @Description  Endpoint publik (tanpa login, dibatasi rate limit per IP) untuk memeriksa kode verifikasi prestasi. Hanya mengembalikan nama mahasiswa, judul, jenis, tanggal verifikasi, dan role verifikator; status bernilai valid, revoked, atau not_found.
+// @Tags         Public
+// @Produce      json
+// @Param        code path string true "Kode verifikasi prestasi"
+// @Success      200  {object}  utils.JSONResponse{data=models.AchievementVerification}
+// @Failure      429  {object}  utils.JSONResponse
+// @Router       /public/verify/{code} [get]
+func (ctrl *AchievementController) PublicVerify(c *fiber.Ctx) error {
+	result, status, err := ctrl.Service.VerifyPublicCode(c.Context(), c.Params("code"))
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+
+	message := "Achievement is valid"
+	switch result.Status {
+	case models.VerificationRevoked:
+		message = "Achievement verification has been revoked"
+	case models.VerificationNotFound:
+		message = "Verification code not valid"
+	}
+	return utils.SuccessResponse(c, status, message, result)
+}
+
 // List godoc
 // @Summary      List Achievements
 // @Tags         Achievements
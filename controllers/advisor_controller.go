@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"prestasi-mahasiswa-api/middleware"
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type AdvisorController struct {
+	Service services.AdvisorService
+}
+
+func NewAdvisorController(service services.AdvisorService) *AdvisorController {
+	return &AdvisorController{Service: service}
+}
+
+// AssignAdvisor godoc
+// @Summary      Assign Advisor to Student
+// @Description  Menghubungkan mahasiswa dengan dosen walinya. Penugasan lama ditutup di riwayat dan pengajuan yang menunggu verifikasi dialihkan ke dosen baru.
+// @Tags         Users (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "User ID Mahasiswa (UUID)"
+// @Param        request body models.AssignAdvisorRequest true "User ID Dosen (UUID)"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      404  {object}  utils.JSONResponse
+// @Router       /users/{id}/advisor [put]
+func (ctrl *AdvisorController) AssignAdvisor(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	studentUserID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid Student User ID")
+	}
+
+	var req models.AssignAdvisorRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	res, status, err := ctrl.Service.AssignAdvisor(c.Context(), claims.UserID, studentUserID, &req)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Advisor assigned successfully", res)
+}
+
+// BulkAssignAdvisor godoc
+// @Summary      Bulk Assign Advisor
+// @Description  Menetapkan dosen wali untuk daftar mahasiswa (studentUserIds), atau seluruh mahasiswa suatu prodi dan/atau angkatan
+// @Tags         Users (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body models.BulkAssignAdvisorRequest true "Dosen tujuan dan kriteria mahasiswa"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      400  {object}  utils.JSONResponse
+// @Router       /users/advisors/bulk-assign [post]
+func (ctrl *AdvisorController) BulkAssignAdvisor(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+
+	var req models.BulkAssignAdvisorRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	res, status, err := ctrl.Service.BulkAssignAdvisor(c.Context(), claims.UserID, &req)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Advisor assigned successfully", res)
+}
+
+// TransferAdvisees godoc
+// @Summary      Transfer Advisees
+// @Description  Memindahkan seluruh mahasiswa bimbingan dosen A ke dosen B (mis. dosen pindah/pensiun), termasuk pengajuan yang menunggu verifikasi
+// @Tags         Users (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body models.TransferAdviseesRequest true "Dosen asal dan tujuan"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      404  {object}  utils.JSONResponse
+// @Router       /users/advisors/transfer [post]
+func (ctrl *AdvisorController) TransferAdvisees(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+
+	var req models.TransferAdviseesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	res, status, err := ctrl.Service.TransferAdvisees(c.Context(), claims.UserID, &req)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Advisees transferred successfully", res)
+}
+
+// GetAssignmentHistory godoc
+// @Summary      Advisor Assignment History
+// @Description  Riwayat dosen wali seorang mahasiswa beserta tanggal berlakunya
+// @Tags         Users (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "User ID Mahasiswa (UUID)"
+// @Success      200  {object}  utils.JSONResponse
+// @Router       /users/{id}/advisor-history [get]
+func (ctrl *AdvisorController) GetAssignmentHistory(c *fiber.Ctx) error {
+	studentUserID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid Student User ID")
+	}
+
+	history, status, err := ctrl.Service.GetAssignmentHistory(c.Context(), studentUserID)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Advisor history retrieved successfully", history)
+}
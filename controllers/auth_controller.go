@@ -1,6 +1,10 @@
 package controllers
 
 import (
+	"errors"
+	"math"
+	"strconv"
+
 	"prestasi-mahasiswa-api/middleware"
 	"prestasi-mahasiswa-api/models"
 	"prestasi-mahasiswa-api/services"
@@ -19,13 +23,17 @@ func NewAuthController(service services.AuthService) *AuthController {
 
 // Login godoc
 // @Summary      Login User
-// @Description  Masuk menggunakan username dan password untuk mendapatkan token JWT
+// @Description  Masuk menggunakan username dan password untuk mendapatkan token JWT.
+// @Description  Jika 2FA aktif/wajib, respons berisi challengeToken untuk POST /auth/login/2fa.
 // @Tags         Auth
 // @Accept       json
 // @Produce      json
 // @Param        request body models.LoginRequest true "Credentials"
 // @Success      200  {object}  utils.JSONResponse
 // @Failure      401  {object}  utils.JSONResponse
+// @Failure      423  {object}  utils.JSONResponse "Akun dikunci sementara"
+// @Failure      429  {object}  utils.JSONResponse "Terlalu banyak percobaan login"
+// @Failure      503  {object}  utils.JSONResponse "Direktori LDAP tidak bisa dihubungi"
 // @Router       /auth/login [post]
 func (ctrl *AuthController) Login(c *fiber.Ctx) error {
 	var req models.LoginRequest
@@ -33,11 +41,19 @@ func (ctrl *AuthController) Login(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
 	}
 
-	resp, status, err := ctrl.Service.PerformLogin(c.Context(), req.Username, req.Password)
+	client := models.ClientInfo{IPAddress: c.IP(), UserAgent: c.Get(fiber.HeaderUserAgent)}
+	resp, status, err := ctrl.Service.PerformLogin(c.Context(), req.Username, req.Password, client)
 	if err != nil {
+		var throttleErr *services.LoginThrottleError
+		if errors.As(err, &throttleErr) {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(throttleErr.RetryAfter.Seconds()))))
+		}
 		return utils.ErrorResponse(c, status, err.Error())
 	}
 
+	if resp.Data.MFARequired || resp.Data.MFAEnrollmentRequired {
+		return utils.SuccessResponse(c, status, "Two-factor authentication required", resp)
+	}
 	return utils.SuccessResponse(c, status, "Login successful", resp)
 }
 
@@ -55,7 +71,7 @@ func (ctrl *AuthController) Logout(c *fiber.Ctx) error {
 
 // GetProfile godoc
 // @Summary      Get User Profile
-// @Description  Mendapatkan data profil user yang sedang login
+// @Description  Mendapatkan data profil user yang sedang login, termasuk profil mahasiswa (dengan dosen wali) atau dosen jika ada
 // @Tags         Auth
 // @Accept       json
 // @Produce      json
@@ -71,4 +87,105 @@ func (ctrl *AuthController) GetProfile(c *fiber.Ctx) error {
 	}
 
 	return utils.SuccessResponse(c, status, "Profile retrieved successfully", resp)
-}
\ No newline at end of file
+}
+
+// ChangePassword godoc
+// @Summary      Change Password
+// @Description  User mengganti password sendiri. Semua sesi (token) lama akan dicabut sehingga perlu login ulang.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body models.ChangePasswordRequest true "Password lama & baru"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      400  {object}  utils.JSONResponse
+// @Failure      401  {object}  utils.JSONResponse
+// @Router       /auth/password [put]
+func (ctrl *AuthController) ChangePassword(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	var req models.ChangePasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	status, err := ctrl.Service.ChangePassword(c.Context(), claims.UserID, &req)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Password changed successfully, please log in again", nil)
+}
+
+// ResetPassword godoc
+// @Summary      Reset Password with Token
+// @Description  Mengatur password baru menggunakan token reset sekali pakai yang diberikan Admin
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        request body models.ResetPasswordRequest true "Token reset & password baru"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      400  {object}  utils.JSONResponse
+// @Router       /auth/password/reset [post]
+func (ctrl *AuthController) ResetPassword(c *fiber.Ctx) error {
+	var req models.ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	status, err := ctrl.Service.ResetPassword(c.Context(), &req)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Password has been reset successfully", nil)
+}
+
+// VerifyMFALogin godoc
+// @Summary      Complete Two-Factor Login
+// @Description  Tahap kedua login: tukar challenge token dan kode TOTP (atau kode pemulihan) dengan token JWT.
+// @Description  Untuk challenge enrollment, kode sekaligus mengaktifkan 2FA dan kode pemulihan dikembalikan sekali.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        request body models.MFAChallengeRequest true "Challenge token & kode"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      401  {object}  utils.JSONResponse
+// @Router       /auth/login/2fa [post]
+func (ctrl *AuthController) VerifyMFALogin(c *fiber.Ctx) error {
+	var req models.MFAChallengeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	client := models.ClientInfo{IPAddress: c.IP(), UserAgent: c.Get(fiber.HeaderUserAgent)}
+	resp, status, err := ctrl.Service.CompleteMFALogin(c.Context(), &req, client)
+	if err != nil {
+		var throttleErr *services.LoginThrottleError
+		if errors.As(err, &throttleErr) {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(throttleErr.RetryAfter.Seconds()))))
+		}
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Login successful", resp)
+}
+
+// SetupMFAFromChallenge godoc
+// @Summary      Start Mandatory Two-Factor Enrollment
+// @Description  Untuk role yang wajib 2FA tetapi belum enroll: memulai setup TOTP menggunakan challenge token dari login
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        request body models.MFAEnrollmentChallengeRequest true "Challenge token"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      401  {object}  utils.JSONResponse
+// @Router       /auth/login/2fa/setup [post]
+func (ctrl *AuthController) SetupMFAFromChallenge(c *fiber.Ctx) error {
+	var req models.MFAEnrollmentChallengeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	resp, status, err := ctrl.Service.BeginMFAEnrollment(c.Context(), req.ChallengeToken)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Scan the QR code with your authenticator app, then verify the code", resp)
+}
@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"bufio"
+	"context"
+	"log"
+
+	"prestasi-mahasiswa-api/middleware"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ExportController struct {
+	Service services.ExportService
+}
+
+func NewExportController(service services.ExportService) *ExportController {
+	return &ExportController{Service: service}
+}
+
+// ExportAchievements godoc
+// @Summary      Export Achievements
+// @Description  Mengunduh rekap prestasi (scope sama dengan GET /achievements) dalam format CSV, XLSX, atau PDF. Data dialirkan bertahap sehingga aman untuk jumlah besar.
+// @Tags         Achievements
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce      application/pdf
+// @Security     BearerAuth
+// @Param        format query string false "csv (default) | xlsx | pdf"
+// @Success      200  {file}    file
+// @Failure      400  {object}  utils.JSONResponse
+// @Failure      403  {object}  utils.JSONResponse
+// @Router       /achievements/export [get]
+func (ctrl *ExportController) ExportAchievements(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	job, status, err := ctrl.Service.ExportAchievements(c.Context(), claims, c.Query("format"))
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return sendExport(c, job)
+}
+
+// ExportDashboardStats godoc
+// @Summary      Export Dashboard Statistics
+// @Description  Mengunduh ringkasan statistik prestasi (per status, jenis & program studi) dalam format CSV, XLSX, atau PDF
+// @Tags         Reports
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce      application/pdf
+// @Security     BearerAuth
+// @Param        format query string false "csv (default) | xlsx | pdf"
+// @Param        status         query string false "Filter status, pisahkan dengan koma"
+// @Param        from           query string false "Tanggal dibuat mulai (YYYY-MM-DD)"
+// @Param        to             query string false "Tanggal dibuat sampai (YYYY-MM-DD)"
+// @Param        studyProgramId query string false "Filter Program Studi (UUID)"
+// @Success      200  {file}    file
+// @Failure      400  {object}  utils.JSONResponse
+// @Router       /reports/statistics/export [get]
+func (ctrl *ExportController) ExportDashboardStats(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	filter, err := parseStatsFilter(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+	job, status, err := ctrl.Service.ExportDashboardStats(c.Context(), claims, filter, c.Query("format"))
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return sendExport(c, job)
+}
+
+// ExportStudentReport godoc
+// @Summary      Export Student Report
+// @Description  Mengunduh laporan prestasi per mahasiswa (timeline semester, prestasi teratas, rekap status & jenis)
+// @Tags         Reports
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce      application/pdf
+// @Security     BearerAuth
+// @Param        id path string true "User ID Mahasiswa (UUID)"
+// @Param        format query string false "csv (default) | xlsx | pdf"
+// @Success      200  {file}    file
+// @Failure      400  {object}  utils.JSONResponse
+// @Failure      403  {object}  utils.JSONResponse
+// @Failure      404  {object}  utils.JSONResponse
+// @Router       /reports/students/{id}/export [get]
+func (ctrl *ExportController) ExportStudentReport(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	job, status, err := ctrl.Service.ExportStudentReport(c.Context(), claims, id, c.Query("format"))
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return sendExport(c, job)
+}
+
+// sendExport mengalirkan file ke client; error di tengah jalan hanya bisa dicatat karena header sudah terkirim
+func sendExport(c *fiber.Ctx, job *services.ExportJob) error {
+	c.Attachment(job.FileName)
+	c.Set(fiber.HeaderContentType, job.ContentType)
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := job.Write(context.Background(), w); err != nil {
+			log.Printf("export %s failed: %v", job.FileName, err)
+		}
+		w.Flush()
+	})
+	return nil
+}
@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"prestasi-mahasiswa-api/middleware"
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type MFAController struct {
+	Service services.MFAService
+}
+
+func NewMFAController(service services.MFAService) *MFAController {
+	return &MFAController{Service: service}
+}
+
+// Status godoc
+// @Summary      Get Two-Factor Status
+// @Description  Melihat apakah 2FA sudah aktif dan apakah wajib untuk role user
+// @Tags         Two-Factor Auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  utils.JSONResponse
+// @Router       /auth/2fa [get]
+func (ctrl *MFAController) Status(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	resp, status, err := ctrl.Service.GetStatus(c.Context(), claims.UserID, claims.Permissions)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Two-factor status retrieved", resp)
+}
+
+// Setup godoc
+// @Summary      Start Two-Factor Enrollment
+// @Description  Membuat secret TOTP baru beserta provisioning URI (otpauth://) dan QR code
+// @Tags         Two-Factor Auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      409  {object}  utils.JSONResponse
+// @Router       /auth/2fa/setup [post]
+func (ctrl *MFAController) Setup(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	resp, status, err := ctrl.Service.BeginSetup(c.Context(), claims.UserID)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Scan the QR code with your authenticator app, then verify the code", resp)
+}
+
+// Enable godoc
+// @Summary      Confirm Two-Factor Enrollment
+// @Description  Mengaktifkan 2FA dengan kode TOTP pertama. Kode pemulihan hanya ditampilkan sekali.
+// @Tags         Two-Factor Auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body models.MFACodeRequest true "Kode TOTP"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      401  {object}  utils.JSONResponse
+// @Router       /auth/2fa/enable [post]
+func (ctrl *MFAController) Enable(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	var req models.MFACodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	resp, status, err := ctrl.Service.ConfirmSetup(c.Context(), claims.UserID, req.Code)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Two-factor authentication enabled", resp)
+}
+
+// Disable godoc
+// @Summary      Disable Two-Factor Authentication
+// @Description  Menonaktifkan 2FA milik sendiri (wajib kode TOTP/pemulihan). Tidak berlaku untuk role dengan permission mfa:required.
+// @Tags         Two-Factor Auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body models.MFADisableRequest true "Kode TOTP/pemulihan"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      403  {object}  utils.JSONResponse
+// @Router       /auth/2fa/disable [post]
+func (ctrl *MFAController) Disable(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	var req models.MFADisableRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	status, err := ctrl.Service.Disable(c.Context(), claims.UserID, claims.Permissions, &req)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Two-factor authentication disabled", nil)
+}
+
+// Reset godoc
+// @Summary      Reset User Two-Factor (Admin)
+// @Description  Admin menghapus konfigurasi 2FA user (misal perangkat hilang). User harus enroll ulang.
+// @Tags         Users (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "User ID (UUID)"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      404  {object}  utils.JSONResponse
+// @Router       /users/{id}/2fa [delete]
+func (ctrl *MFAController) Reset(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid User ID format")
+	}
+
+	status, err := ctrl.Service.Reset(c.Context(), id)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Two-factor authentication has been reset", nil)
+}
@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"strings"
+	"time"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	oidcStateCookie    = "oidc_auth"
+	oidcStateCookieTTL = 10 * time.Minute
+)
+
+type OIDCController struct {
+	Service services.OIDCService
+}
+
+func NewOIDCController(service services.OIDCService) *OIDCController {
+	return &OIDCController{Service: service}
+}
+
+// Login godoc
+// @Summary      Start SSO Login (OIDC)
+// @Description  Redirect ke identity provider kampus. State, nonce, dan PKCE verifier disimpan di cookie HttpOnly.
+// @Tags         Auth
+// @Success      302
+// @Failure      404  {object}  utils.JSONResponse
+// @Failure      502  {object}  utils.JSONResponse
+// @Router       /auth/oidc/login [get]
+func (ctrl *OIDCController) Login(c *fiber.Ctx) error {
+	authReq, status, err := ctrl.Service.BeginLogin(c.Context())
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcStateCookie,
+		Value:    strings.Join([]string{authReq.State, authReq.Nonce, authReq.CodeVerifier}, "."),
+		Path:     "/",
+		Expires:  time.Now().Add(oidcStateCookieTTL),
+		HTTPOnly: true,
+		Secure:   c.Secure(),
+		SameSite: fiber.CookieSameSiteLaxMode, // cookie harus ikut saat IdP redirect kembali
+	})
+	return c.Redirect(authReq.AuthURL, fiber.StatusFound)
+}
+
+// Callback godoc
+// @Summary      SSO Login Callback (OIDC)
+// @Description  Dipanggil identity provider setelah login. Menerbitkan token JWT yang sama dengan POST /auth/login.
+// @Description  Akun dicocokkan berdasarkan NIM, NIP, lalu email terverifikasi.
+// @Tags         Auth
+// @Produce      json
+// @Param        code   query string true "Authorization code"
+// @Param        state  query string true "State"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      400  {object}  utils.JSONResponse
+// @Failure      401  {object}  utils.JSONResponse
+// @Failure      403  {object}  utils.JSONResponse
+// @Router       /auth/oidc/callback [get]
+func (ctrl *OIDCController) Callback(c *fiber.Ctx) error {
+	authReq := parseOIDCStateCookie(c.Cookies(oidcStateCookie))
+	// State hanya berlaku sekali
+	c.ClearCookie(oidcStateCookie)
+
+	if idpErr := c.Query("error"); idpErr != "" {
+		message := c.Query("error_description", idpErr)
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "SSO login failed: "+message)
+	}
+
+	client := models.ClientInfo{IPAddress: c.IP(), UserAgent: c.Get(fiber.HeaderUserAgent)}
+	resp, status, err := ctrl.Service.CompleteLogin(c.Context(), authReq, c.Query("code"), c.Query("state"), client)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Login successful", resp)
+}
+
+func parseOIDCStateCookie(value string) *models.OIDCAuthRequest {
+	parts := strings.Split(value, ".")
+	if len(parts) != 3 {
+		return nil
+	}
+	return &models.OIDCAuthRequest{State: parts[0], Nonce: parts[1], CodeVerifier: parts[2]}
+}
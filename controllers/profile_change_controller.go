@@ -0,0 +1,159 @@
+package controllers
+
+import (
+	"prestasi-mahasiswa-api/middleware"
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ProfileChangeController struct {
+	Service services.ProfileChangeService
+}
+
+func NewProfileChangeController(service services.ProfileChangeService) *ProfileChangeController {
+	return &ProfileChangeController{Service: service}
+}
+
+// UpdateOwnProfile godoc
+// @Summary      Update Own Profile
+// @Description  Mengubah profil sendiri. fullName & email langsung diterapkan; perubahan NIM/NIP, prodi, angkatan, atau departemen diajukan sebagai change request yang harus disetujui Admin (maksimal satu pengajuan pending).
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body models.SelfProfileUpdateRequest true "Field yang diubah"
+// @Success      200  {object}  utils.JSONResponse{data=models.SelfProfileUpdateResult}
+// @Failure      400  {object}  utils.JSONResponse
+// @Failure      409  {object}  utils.JSONResponse
+// @Failure      422  {object}  utils.JSONResponse
+// @Router       /auth/profile [put]
+func (ctrl *ProfileChangeController) UpdateOwnProfile(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	var req models.SelfProfileUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	result, status, err := ctrl.Service.UpdateOwnProfile(c.Context(), claims.UserID, &req)
+	if err != nil {
+		return utils.ErrorResponseFromError(c, status, err)
+	}
+
+	message := "Profile updated successfully"
+	if result.ChangeRequest != nil {
+		message = "Profile updated; sensitive changes submitted for approval"
+	}
+	return utils.SuccessResponse(c, status, message, result)
+}
+
+// ListOwnRequests godoc
+// @Summary      List Own Profile Change Requests
+// @Description  Riwayat pengajuan perubahan profil milik user yang sedang login
+// @Tags         Auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  utils.JSONResponse{data=[]models.ProfileChangeRequest}
+// @Router       /auth/profile/change-requests [get]
+func (ctrl *ProfileChangeController) ListOwnRequests(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	requests, status, err := ctrl.Service.ListOwnRequests(c.Context(), claims.UserID)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Profile change requests retrieved successfully", requests)
+}
+
+// ListRequests godoc
+// @Summary      List Profile Change Requests
+// @Description  Antrean dan riwayat pengajuan perubahan profil (Admin)
+// @Tags         Users (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status query string false "pending | approved | rejected"
+// @Param        userId query string false "Filter per User ID (UUID)"
+// @Success      200  {object}  utils.JSONResponse{data=[]models.ProfileChangeRequest}
+// @Failure      400  {object}  utils.JSONResponse
+// @Router       /profile-change-requests [get]
+func (ctrl *ProfileChangeController) ListRequests(c *fiber.Ctx) error {
+	filter := models.ProfileChangeFilter{Status: c.Query("status")}
+	if raw := c.Query("userId"); raw != "" {
+		userID, err := uuid.Parse(raw)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid userId")
+		}
+		filter.UserID = &userID
+	}
+
+	requests, status, err := ctrl.Service.ListRequests(c.Context(), filter)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Profile change requests retrieved successfully", requests)
+}
+
+// Approve godoc
+// @Summary      Approve Profile Change Request
+// @Description  Menyetujui pengajuan dan menerapkan perubahan ke profil mahasiswa/dosen
+// @Tags         Users (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Change Request ID (UUID)"
+// @Param        request body models.ReviewProfileChangeRequest false "Catatan reviewer"
+// @Success      200  {object}  utils.JSONResponse{data=models.ProfileChangeRequest}
+// @Failure      404  {object}  utils.JSONResponse
+// @Failure      409  {object}  utils.JSONResponse
+// @Router       /profile-change-requests/{id}/approve [post]
+func (ctrl *ProfileChangeController) Approve(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	var req models.ReviewProfileChangeRequest
+	_ = c.BodyParser(&req) // Catatan opsional
+
+	change, status, err := ctrl.Service.Approve(c.Context(), claims.UserID, id, &req)
+	if err != nil {
+		return utils.ErrorResponseFromError(c, status, err)
+	}
+	return utils.SuccessResponse(c, status, "Profile change request approved", change)
+}
+
+// Reject godoc
+// @Summary      Reject Profile Change Request
+// @Description  Menolak pengajuan perubahan profil (catatan wajib diisi)
+// @Tags         Users (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Change Request ID (UUID)"
+// @Param        request body models.ReviewProfileChangeRequest true "Alasan penolakan"
+// @Success      200  {object}  utils.JSONResponse{data=models.ProfileChangeRequest}
+// @Failure      400  {object}  utils.JSONResponse
+// @Failure      404  {object}  utils.JSONResponse
+// @Failure      409  {object}  utils.JSONResponse
+// @Router       /profile-change-requests/{id}/reject [post]
+func (ctrl *ProfileChangeController) Reject(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	var req models.ReviewProfileChangeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	change, status, err := ctrl.Service.Reject(c.Context(), claims.UserID, id, &req)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Profile change request rejected", change)
+}
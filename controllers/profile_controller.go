@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"prestasi-mahasiswa-api/middleware"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ProfileController struct {
+	Service services.ProfileService
+}
+
+func NewProfileController(service services.ProfileService) *ProfileController {
+	return &ProfileController{Service: service}
+}
+
+// GetStudentProfile godoc
+// @Summary      Get Student Profile
+// @Description  Melihat profil akademik mahasiswa (NIM, prodi, angkatan) beserta dosen walinya
+// @Tags         Users (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "User ID Mahasiswa (UUID)"
+// @Success      200  {object}  utils.JSONResponse{data=models.StudentProfileDetail}
+// @Failure      404  {object}  utils.JSONResponse
+// @Router       /users/{id}/student-profile [get]
+func (ctrl *ProfileController) GetStudentProfile(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid User ID format")
+	}
+
+	profile, status, err := ctrl.Service.GetStudentProfile(c.Context(), id)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Student profile retrieved successfully", profile)
+}
+
+// GetLecturerProfile godoc
+// @Summary      Get Lecturer Profile
+// @Description  Melihat profil dosen (NIP, departemen) beserta jumlah mahasiswa bimbingan
+// @Tags         Users (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "User ID Dosen (UUID)"
+// @Success      200  {object}  utils.JSONResponse{data=models.LecturerProfileDetail}
+// @Failure      404  {object}  utils.JSONResponse
+// @Router       /users/{id}/lecturer-profile [get]
+func (ctrl *ProfileController) GetLecturerProfile(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid User ID format")
+	}
+
+	profile, status, err := ctrl.Service.GetLecturerProfile(c.Context(), id)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Lecturer profile retrieved successfully", profile)
+}
+
+// ListAdvisees godoc
+// @Summary      List Advisees
+// @Description  Daftar mahasiswa bimbingan seorang dosen wali. Dosen hanya dapat melihat bimbingannya sendiri; Admin dapat melihat semua.
+// @Tags         Lecturers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "User ID Dosen (UUID)"
+// @Success      200  {object}  utils.JSONResponse{data=[]models.AdviseeSummary}
+// @Failure      403  {object}  utils.JSONResponse
+// @Failure      404  {object}  utils.JSONResponse
+// @Router       /lecturers/{id}/advisees [get]
+func (ctrl *ProfileController) ListAdvisees(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid Lecturer User ID")
+	}
+
+	advisees, status, err := ctrl.Service.ListAdvisees(c.Context(), claims, id)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Advisees retrieved successfully", advisees)
+}
@@ -1,11 +1,17 @@
 package controllers
 
 import (
+	"errors"
+	"strings"
+	"time"
+
 	"prestasi-mahasiswa-api/middleware"
+	"prestasi-mahasiswa-api/models"
 	"prestasi-mahasiswa-api/services"
 	"prestasi-mahasiswa-api/utils"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 type ReportController struct {
@@ -18,21 +24,202 @@ func NewReportController(service services.ReportService) *ReportController {
 
 // GetDashboardStats godoc
 // @Summary      Get Dashboard Statistics
-// @Description  Melihat ringkasan data statistik prestasi (Total, by Status, by Type)
+// @Description  Melihat ringkasan data statistik prestasi (Total, by Status, by Type, by Program Studi) sesuai scope permission achievement:read:*. Semua rekap dihitung dari prestasi aktif yang sama; asOf menunjukkan kapan angka dihitung.
 // @Tags         Reports
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200  {object}  utils.JSONResponse
+// @Param        status         query string false "Filter status, pisahkan dengan koma (draft,submitted,verified,rejected)"
+// @Param        from           query string false "Tanggal dibuat mulai (YYYY-MM-DD)"
+// @Param        to             query string false "Tanggal dibuat sampai (YYYY-MM-DD)"
+// @Param        studyProgramId query string false "Filter Program Studi (UUID)"
+// @Success      200  {object}  utils.JSONResponse{data=models.DashboardStats}
+// @Failure      400  {object}  utils.JSONResponse
 // @Failure      500  {object}  utils.JSONResponse
 // @Router       /reports/statistics [get]
 func (ctrl *ReportController) GetDashboardStats(c *fiber.Ctx) error {
 	claims := middleware.GetUserClaims(c)
+	filter, err := parseStatsFilter(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
 
-	stats, status, err := ctrl.Service.GetDashboardStats(c.Context(), claims.Role, claims.UserID)
+	stats, status, err := ctrl.Service.GetDashboardStats(c.Context(), claims, filter)
 	if err != nil {
 		return utils.ErrorResponse(c, status, err.Error())
 	}
 
 	return utils.SuccessResponse(c, status, "Dashboard statistics retrieved", stats)
-}
\ No newline at end of file
+}
+
+// parseStatsFilter membaca query status, from, to, dan studyProgramId (dipakai juga oleh ekspor statistik)
+func parseStatsFilter(c *fiber.Ctx) (models.StatsFilter, error) {
+	filter := models.StatsFilter{}
+	for _, status := range strings.Split(c.Query("status"), ",") {
+		if status = strings.TrimSpace(status); status != "" {
+			filter.Statuses = append(filter.Statuses, status)
+		}
+	}
+	if raw := c.Query("from"); raw != "" {
+		from, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return filter, errors.New("Invalid from date, expected YYYY-MM-DD")
+		}
+		filter.From = &from
+	}
+	if raw := c.Query("to"); raw != "" {
+		to, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return filter, errors.New("Invalid to date, expected YYYY-MM-DD")
+		}
+		endOfDay := to.Add(24*time.Hour - time.Nanosecond)
+		filter.To = &endOfDay
+	}
+	if raw := c.Query("studyProgramId"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return filter, errors.New("Invalid studyProgramId")
+		}
+		filter.StudyProgramID = &id
+	}
+	return filter, nil
+}
+
+// GetAdviseeStats godoc
+// @Summary      Get Advisee Statistics
+// @Description  Statistik prestasi khusus mahasiswa bimbingan dosen wali: total per status & tipe, poin per mahasiswa, dan jumlah pengajuan yang menunggu verifikasi. Tanpa advisorUserId dipakai user yang sedang login; Admin dapat melihat dosen lain.
+// @Tags         Reports
+// @Produce      json
+// @Security     BearerAuth
+// @Param        advisorUserId query string false "User ID Dosen Wali (UUID)"
+// @Success      200  {object}  utils.JSONResponse{data=models.AdviseeStats}
+// @Failure      403  {object}  utils.JSONResponse
+// @Failure      404  {object}  utils.JSONResponse
+// @Router       /reports/advisees [get]
+func (ctrl *ReportController) GetAdviseeStats(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+
+	advisorUserID := claims.UserID
+	if raw := c.Query("advisorUserId"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid advisorUserId")
+		}
+		advisorUserID = id
+	}
+
+	stats, status, err := ctrl.Service.GetAdviseeStats(c.Context(), claims, advisorUserID)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Advisee statistics retrieved", stats)
+}
+
+// GetStudentReport godoc
+// @Summary      Get Student Achievement Report
+// @Description  Laporan prestasi per mahasiswa: NIM & prodi, total poin terverifikasi, jumlah per status & tipe, timeline per semester, dan prestasi teratas. Dapat diakses mahasiswa ybs., dosen wali, atau Admin.
+// @Tags         Reports
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "User ID Mahasiswa (UUID)"
+// @Success      200  {object}  utils.JSONResponse{data=models.StudentStats}
+// @Failure      403  {object}  utils.JSONResponse
+// @Failure      404  {object}  utils.JSONResponse
+// @Router       /reports/students/{id} [get]
+func (ctrl *ReportController) GetStudentReport(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid Student User ID")
+	}
+
+	report, status, err := ctrl.Service.GetStudentReport(c.Context(), claims, id)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Student report retrieved", report)
+}
+
+// GetTrends godoc
+// @Summary      Get Achievement Trends
+// @Description  Deret waktu jumlah prestasi dan poin (prestasi terverifikasi) per bulan, semester, atau tahun akademik, opsional dipecah per tipe/status/prodi, beserta persentase pertumbuhan terhadap periode sebelumnya. Data dibatasi scope permission achievement:read:*.
+// @Tags         Reports
+// @Produce      json
+// @Security     BearerAuth
+// @Param        interval  query string false "month (default) | semester | year"
+// @Param        groupBy   query string false "type | status | programStudy"
+// @Param        dateField query string false "submittedAt (default) | verifiedAt"
+// @Param        from      query string false "Tanggal awal (YYYY-MM-DD)"
+// @Param        to        query string false "Tanggal akhir (YYYY-MM-DD)"
+// @Success      200  {object}  utils.JSONResponse{data=models.TrendReport}
+// @Failure      400  {object}  utils.JSONResponse
+// @Failure      403  {object}  utils.JSONResponse
+// @Router       /reports/trends [get]
+func (ctrl *ReportController) GetTrends(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	query := models.TrendQuery{
+		Interval:  c.Query("interval"),
+		GroupBy:   c.Query("groupBy"),
+		DateField: c.Query("dateField"),
+	}
+	if raw := c.Query("from"); raw != "" {
+		from, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid from date, expected YYYY-MM-DD")
+		}
+		query.From = &from
+	}
+	if raw := c.Query("to"); raw != "" {
+		to, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid to date, expected YYYY-MM-DD")
+		}
+		endOfDay := to.Add(24*time.Hour - time.Nanosecond)
+		query.To = &endOfDay
+	}
+
+	report, status, err := ctrl.Service.GetTrends(c.Context(), claims, query)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Trend report retrieved", report)
+}
+
+// GetLeaderboard godoc
+// @Summary      Get Points Leaderboard
+// @Description  Peringkat mahasiswa berdasarkan total poin prestasi terverifikasi (poin sama = peringkat sama). Dapat difilter per prodi, angkatan, dan tipe prestasi. Nama & NIM mahasiswa di luar scope baca pemanggil disamarkan; anonymize=true menyamarkan semua kecuali diri sendiri.
+// @Tags         Reports
+// @Produce      json
+// @Security     BearerAuth
+// @Param        studyProgramId  query string false "Filter Program Studi (UUID)"
+// @Param        academicYear    query string false "Filter angkatan, mis. 2021"
+// @Param        achievementType query string false "Filter tipe prestasi"
+// @Param        anonymize       query bool   false "Samarkan nama (tampilan mahasiswa)"
+// @Param        page            query int    false "Halaman (default 1)"
+// @Param        limit           query int    false "Jumlah per halaman (default 20, maks 100)"
+// @Success      200  {object}  utils.JSONResponse{data=models.LeaderboardResult}
+// @Failure      400  {object}  utils.JSONResponse
+// @Router       /reports/leaderboard [get]
+func (ctrl *ReportController) GetLeaderboard(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	query := models.LeaderboardQuery{
+		AcademicYear:    c.Query("academicYear"),
+		AchievementType: c.Query("achievementType"),
+		Anonymize:       c.QueryBool("anonymize", false),
+		Page:            c.QueryInt("page", 1),
+		Limit:           c.QueryInt("limit", models.DefaultPageLimit),
+	}
+	if raw := c.Query("studyProgramId"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid studyProgramId")
+		}
+		query.StudyProgramID = &id
+	}
+
+	result, status, err := ctrl.Service.GetLeaderboard(c.Context(), claims, query)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Leaderboard retrieved", result)
+}
@@ -0,0 +1,310 @@
+package controllers
+
+import (
+	"errors"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type RoleController struct {
+	Service services.RoleService
+}
+
+func NewRoleController(service services.RoleService) *RoleController {
+	return &RoleController{Service: service}
+}
+
+// ListRoles godoc
+// @Summary      List Roles
+// @Description  Admin melihat semua role beserta permission-nya
+// @Tags         Roles & Permissions (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  utils.JSONResponse
+// @Router       /roles [get]
+func (ctrl *RoleController) ListRoles(c *fiber.Ctx) error {
+	roles, status, err := ctrl.Service.ListRoles(c.Context())
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Roles retrieved successfully", roles)
+}
+
+// GetRole godoc
+// @Summary      Get Role By ID
+// @Tags         Roles & Permissions (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Role ID (UUID)"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      404  {object}  utils.JSONResponse
+// @Router       /roles/{id} [get]
+func (ctrl *RoleController) GetRole(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid Role ID format")
+	}
+
+	role, status, err := ctrl.Service.GetRole(c.Context(), id)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Role retrieved successfully", role)
+}
+
+// CreateRole godoc
+// @Summary      Create Role
+// @Tags         Roles & Permissions (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body models.RoleRequest true "Data Role"
+// @Success      201  {object}  utils.JSONResponse
+// @Failure      409  {object}  utils.JSONResponse
+// @Router       /roles [post]
+func (ctrl *RoleController) CreateRole(c *fiber.Ctx) error {
+	var req models.RoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	role, status, err := ctrl.Service.CreateRole(c.Context(), &req)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Role created successfully", role)
+}
+
+// UpdateRole godoc
+// @Summary      Update Role
+// @Description  Mengubah nama/deskripsi role. Role bawaan (Admin, Dosen Wali, Mahasiswa) tidak bisa diganti nama.
+// @Tags         Roles & Permissions (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Role ID (UUID)"
+// @Param        request body models.RoleRequest true "Data Role"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      403  {object}  utils.JSONResponse
+// @Router       /roles/{id} [put]
+func (ctrl *RoleController) UpdateRole(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid Role ID format")
+	}
+	var req models.RoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	role, status, err := ctrl.Service.UpdateRole(c.Context(), id, &req)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Role updated successfully", role)
+}
+
+// DeleteRole godoc
+// @Summary      Delete Role
+// @Description  Role bawaan dan role yang masih dipakai user tidak bisa dihapus
+// @Tags         Roles & Permissions (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Role ID (UUID)"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      409  {object}  utils.JSONResponse "Role masih dipakai user"
+// @Router       /roles/{id} [delete]
+func (ctrl *RoleController) DeleteRole(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid Role ID format")
+	}
+
+	status, err := ctrl.Service.DeleteRole(c.Context(), id)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Role deleted successfully", nil)
+}
+
+// SetRolePermissions godoc
+// @Summary      Replace Role Permissions
+// @Description  Mengganti seluruh permission milik role. Berlaku untuk request berikutnya tanpa perlu login ulang.
+// @Tags         Roles & Permissions (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Role ID (UUID)"
+// @Param        request body models.RolePermissionsRequest true "Daftar nama permission"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      400  {object}  utils.JSONResponse
+// @Router       /roles/{id}/permissions [put]
+func (ctrl *RoleController) SetRolePermissions(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid Role ID format")
+	}
+	var req models.RolePermissionsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	role, status, err := ctrl.Service.SetRolePermissions(c.Context(), id, &req)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Role permissions updated successfully", role)
+}
+
+// AddRolePermission godoc
+// @Summary      Assign Permission To Role
+// @Tags         Roles & Permissions (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Role ID (UUID)"
+// @Param        permissionId path string true "Permission ID (UUID)"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      404  {object}  utils.JSONResponse
+// @Router       /roles/{id}/permissions/{permissionId} [post]
+func (ctrl *RoleController) AddRolePermission(c *fiber.Ctx) error {
+	roleID, permissionID, err := parseRolePermissionParams(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	role, status, err := ctrl.Service.AddRolePermission(c.Context(), roleID, permissionID)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Permission assigned successfully", role)
+}
+
+// RemoveRolePermission godoc
+// @Summary      Revoke Permission From Role
+// @Tags         Roles & Permissions (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Role ID (UUID)"
+// @Param        permissionId path string true "Permission ID (UUID)"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      403  {object}  utils.JSONResponse
+// @Router       /roles/{id}/permissions/{permissionId} [delete]
+func (ctrl *RoleController) RemoveRolePermission(c *fiber.Ctx) error {
+	roleID, permissionID, err := parseRolePermissionParams(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	role, status, err := ctrl.Service.RemoveRolePermission(c.Context(), roleID, permissionID)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Permission revoked successfully", role)
+}
+
+func parseRolePermissionParams(c *fiber.Ctx) (uuid.UUID, uuid.UUID, error) {
+	roleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.New("Invalid Role ID format")
+	}
+	permissionID, err := uuid.Parse(c.Params("permissionId"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.New("Invalid Permission ID format")
+	}
+	return roleID, permissionID, nil
+}
+
+// ListPermissions godoc
+// @Summary      List Permissions
+// @Tags         Roles & Permissions (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  utils.JSONResponse
+// @Router       /permissions [get]
+func (ctrl *RoleController) ListPermissions(c *fiber.Ctx) error {
+	permissions, status, err := ctrl.Service.ListPermissions(c.Context())
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Permissions retrieved successfully", permissions)
+}
+
+// CreatePermission godoc
+// @Summary      Create Permission
+// @Description  Nama permission berformat resource:action, contoh: achievement:verify
+// @Tags         Roles & Permissions (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body models.PermissionRequest true "Data Permission"
+// @Success      201  {object}  utils.JSONResponse
+// @Failure      409  {object}  utils.JSONResponse
+// @Router       /permissions [post]
+func (ctrl *RoleController) CreatePermission(c *fiber.Ctx) error {
+	var req models.PermissionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	permission, status, err := ctrl.Service.CreatePermission(c.Context(), &req)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Permission created successfully", permission)
+}
+
+// UpdatePermission godoc
+// @Summary      Update Permission
+// @Tags         Roles & Permissions (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Permission ID (UUID)"
+// @Param        request body models.PermissionRequest true "Data Permission"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      404  {object}  utils.JSONResponse
+// @Router       /permissions/{id} [put]
+func (ctrl *RoleController) UpdatePermission(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid Permission ID format")
+	}
+	var req models.PermissionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	permission, status, err := ctrl.Service.UpdatePermission(c.Context(), id, &req)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Permission updated successfully", permission)
+}
+
+// DeletePermission godoc
+// @Summary      Delete Permission
+// @Description  Menghapus permission sekaligus mencabutnya dari semua role
+// @Tags         Roles & Permissions (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Permission ID (UUID)"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      403  {object}  utils.JSONResponse
+// @Router       /permissions/{id} [delete]
+func (ctrl *RoleController) DeletePermission(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid Permission ID format")
+	}
+
+	status, err := ctrl.Service.DeletePermission(c.Context(), id)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "Permission deleted successfully", nil)
+}
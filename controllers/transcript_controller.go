@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"prestasi-mahasiswa-api/middleware"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type TranscriptController struct {
+	Service services.TranscriptService
+}
+
+func NewTranscriptController(service services.TranscriptService) *TranscriptController {
+	return &TranscriptController{Service: service}
+}
+
+// Download godoc
+// @Summary      Download Student Transcript (SKPI)
+// @Description  Menerbitkan Surat Keterangan Pendamping Ijazah berisi prestasi terverifikasi per jenis (poin, tanggal, verifikator) beserta QR code menuju endpoint verifikasi publik. Setiap unduhan mendapat nomor dokumen baru.
+// @Tags         Reports
+// @Produce      application/pdf
+// @Security     BearerAuth
+// @Param        id path string true "User ID Mahasiswa (UUID)"
+// @Success      200  {file}    file
+// @Failure      400  {object}  utils.JSONResponse
+// @Failure      403  {object}  utils.JSONResponse
+// @Failure      404  {object}  utils.JSONResponse
+// @Router       /reports/students/{id}/transcript.pdf [get]
+func (ctrl *TranscriptController) Download(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	job, status, err := ctrl.Service.GenerateTranscript(c.Context(), claims, id)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return sendExport(c, job)
+}
+
+// Verify godoc
+// @Summary      Verify Student Transcript (SKPI)
+// @Description  Endpoint publik (tanpa login) untuk memastikan keaslian SKPI dari kode pada QR code. Kode tidak terdaftar menghasilkan valid=false.
+// @Tags         Public
+// @Produce      json
+// @Param        code path string true "Kode verifikasi dokumen"
+// @Success      200  {object}  utils.JSONResponse{data=models.TranscriptVerification}
+// @Failure      400  {object}  utils.JSONResponse
+// @Router       /public/transcripts/{code} [get]
+func (ctrl *TranscriptController) Verify(c *fiber.Ctx) error {
+	result, status, err := ctrl.Service.VerifyTranscript(c.Context(), c.Params("code"))
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+
+	message := "Transcript is valid"
+	if !result.Valid {
+		message = "Transcript not valid"
+	}
+	return utils.SuccessResponse(c, status, message, result)
+}
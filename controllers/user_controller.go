@@ -2,6 +2,8 @@ package controllers
 
 import (
 	"fmt"
+	"strconv"
+	"prestasi-mahasiswa-api/middleware"
 	"prestasi-mahasiswa-api/models"
 	"prestasi-mahasiswa-api/services"
 	"prestasi-mahasiswa-api/utils"
@@ -18,22 +20,72 @@ func NewUserController(service services.UserService) *UserController {
 	return &UserController{Service: service}
 }
 
-// ListAllUsers godoc
-// @Summary      List All Users
-// @Description  Admin melihat semua daftar pengguna (Mahasiswa, Dosen, Admin)
+// ListUsers godoc
+// @Summary      List Users
+// @Description  Admin melihat daftar pengguna dengan pencarian, filter, sort, dan pagination. Profil mahasiswa/dosen disertakan.
 // @Tags         Users (Admin)
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200  {object}  utils.JSONResponse
+// @Param        page           query int    false "Halaman (mulai 1)"
+// @Param        limit          query int    false "Jumlah per halaman (maks 100)"
+// @Param        search         query string false "Cari nama, username, email, NIM, atau NIP"
+// @Param        role           query string false "Nama role"
+// @Param        isActive       query bool   false "Status aktif"
+// @Param        studyProgramId query string false "ID program studi"
+// @Param        programStudy   query string false "Nama program studi"
+// @Param        advisorUserId  query string false "User ID dosen wali"
+// @Param        sort           query string false "username | name | email | role | createdAt | studentId | lecturerId"
+// @Param        order          query string false "asc | desc"
+// @Success      200  {object}  utils.JSONResponse{data=models.UserListResult}
+// @Failure      400  {object}  utils.JSONResponse
 // @Failure      500  {object}  utils.JSONResponse
 // @Router       /users [get]
-func (ctrl *UserController) ListAllUsers(c *fiber.Ctx) error {
-	users, status, err := ctrl.Service.ListAllUsers(c.Context())
+func (ctrl *UserController) ListUsers(c *fiber.Ctx) error {
+	query := models.UserListQuery{
+		Page:         c.QueryInt("page", 1),
+		Limit:        c.QueryInt("limit", models.DefaultPageLimit),
+		Search:       c.Query("search"),
+		Role:         c.Query("role"),
+		ProgramStudy: c.Query("programStudy"),
+		SortBy:       c.Query("sort"),
+	}
+
+	switch c.Query("order", "asc") {
+	case "asc":
+	case "desc":
+		query.SortDesc = true
+	default:
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "order must be asc or desc")
+	}
+
+	if raw := c.Query("isActive"); raw != "" {
+		active, err := strconv.ParseBool(raw)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "isActive must be true or false")
+		}
+		query.IsActive = &active
+	}
+	if raw := c.Query("studyProgramId"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid studyProgramId")
+		}
+		query.StudyProgramID = &id
+	}
+	if raw := c.Query("advisorUserId"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid advisorUserId")
+		}
+		query.AdvisorUserID = &id
+	}
+
+	res, status, err := ctrl.Service.ListUsers(c.Context(), query)
 	if err != nil {
 		return utils.ErrorResponse(c, status, err.Error())
 	}
-	return utils.SuccessResponse(c, status, "Users retrieved successfully", users)
+	return utils.SuccessResponse(c, status, "Users retrieved successfully", res)
 }
 
 // GetUserByID godoc
@@ -70,6 +122,8 @@ func (ctrl *UserController) GetUserByID(c *fiber.Ctx) error {
 // @Param        request body models.CreateUserRequest true "User Data"
 // @Success      201  {object}  utils.JSONResponse
 // @Failure      400  {object}  utils.JSONResponse
+// @Failure      409  {object}  utils.JSONResponse "Username/email sudah dipakai (detail di errors)"
+// @Failure      422  {object}  utils.JSONResponse "Validasi gagal (detail di errors)"
 // @Router       /users [post]
 func (ctrl *UserController) CreateUser(c *fiber.Ctx) error {
 	var req models.CreateUserRequest
@@ -79,7 +133,7 @@ func (ctrl *UserController) CreateUser(c *fiber.Ctx) error {
 	
 	user, status, err := ctrl.Service.CreateUser(c.Context(), &req)
 	if err != nil {
-		return utils.ErrorResponse(c, status, err.Error())
+		return utils.ErrorResponseFromError(c, status, err)
 	}
 	return utils.SuccessResponse(c, status, "User created successfully", user)
 }
@@ -95,6 +149,8 @@ func (ctrl *UserController) CreateUser(c *fiber.Ctx) error {
 // @Param        request body models.UpdateUserRequest true "Update Data"
 // @Success      200  {object}  utils.JSONResponse
 // @Failure      400  {object}  utils.JSONResponse
+// @Failure      409  {object}  utils.JSONResponse
+// @Failure      422  {object}  utils.JSONResponse
 // @Router       /users/{id} [put]
 func (ctrl *UserController) UpdateUser(c *fiber.Ctx) error {
 	id, err := uuid.Parse(c.Params("id"))
@@ -109,7 +165,7 @@ func (ctrl *UserController) UpdateUser(c *fiber.Ctx) error {
 
 	user, status, err := ctrl.Service.UpdateUser(c.Context(), id, &req)
 	if err != nil {
-		return utils.ErrorResponse(c, status, err.Error())
+		return utils.ErrorResponseFromError(c, status, err)
 	}
 	return utils.SuccessResponse(c, status, "User updated successfully", user)
 }
@@ -140,7 +196,7 @@ func (ctrl *UserController) DeleteUser(c *fiber.Ctx) error {
 
 // SetStudentProfile godoc
 // @Summary      Set Student Profile
-// @Description  Melengkapi data akademik mahasiswa (NIM, Prodi, Tahun)
+// @Description  Melengkapi data akademik mahasiswa (NIM, Prodi, Tahun). Prodi dipilih lewat studyProgramId
 // @Tags         Users (Admin)
 // @Accept       json
 // @Produce      json
@@ -162,14 +218,14 @@ func (ctrl *UserController) SetStudentProfile(c *fiber.Ctx) error {
 
 	res, status, err := ctrl.Service.SetStudentProfile(c.Context(), id, &req)
 	if err != nil {
-		return utils.ErrorResponse(c, status, err.Error())
+		return utils.ErrorResponseFromError(c, status, err)
 	}
 	return utils.SuccessResponse(c, status, "Student profile updated", res)
 }
 
 // SetLecturerProfile godoc
 // @Summary      Set Lecturer Profile
-// @Description  Melengkapi data kepegawaian dosen (NIP, Departemen)
+// @Description  Melengkapi data kepegawaian dosen (NIP, Departemen). Departemen dipilih lewat departmentId
 // @Tags         Users (Admin)
 // @Accept       json
 // @Produce      json
@@ -191,41 +247,83 @@ func (ctrl *UserController) SetLecturerProfile(c *fiber.Ctx) error {
 
 	res, status, err := ctrl.Service.SetLecturerProfile(c.Context(), id, &req)
 	if err != nil {
-		return utils.ErrorResponse(c, status, err.Error())
+		return utils.ErrorResponseFromError(c, status, err)
 	}
 	return utils.SuccessResponse(c, status, "Lecturer profile updated", res)
 }
 
-// AssignAdvisor godoc
-// @Summary      Assign Advisor to Student
-// @Description  Menghubungkan mahasiswa dengan dosen walinya
+// SetFacultyStaffProfile godoc
+// @Summary      Set Faculty Staff Profile
+// @Description  Melengkapi data staf fakultas (NIP/NIK, Departemen) untuk scope laporan per departemen
 // @Tags         Users (Admin)
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        id path string true "User ID Mahasiswa (UUID)"
-// @Param        request body models.AssignAdvisorRequest true "User ID Dosen (UUID)"
+// @Param        id path string true "User ID (UUID)"
+// @Param        request body models.FacultyStaffProfileRequest true "Faculty Staff Profile Data"
 // @Success      200  {object}  utils.JSONResponse
-// @Router       /users/{id}/advisor [put]
-func (ctrl *UserController) AssignAdvisor(c *fiber.Ctx) error {
-	studentUserID, err := uuid.Parse(c.Params("id"))
+// @Router       /users/{id}/staff-profile [post]
+func (ctrl *UserController) SetFacultyStaffProfile(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid Student User ID")
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid User ID format")
 	}
 
-	var req models.AssignAdvisorRequest
+	var req models.FacultyStaffProfileRequest
 	if err := c.BodyParser(&req); err != nil {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
 	}
 
-	advisorUUID, err := uuid.Parse(req.AdvisorUserID)
+	res, status, err := ctrl.Service.SetFacultyStaffProfile(c.Context(), id, &req)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid Advisor User ID")
+		return utils.ErrorResponse(c, status, err.Error())
 	}
+	return utils.SuccessResponse(c, status, "Faculty staff profile updated", res)
+}
 
-	status, err := ctrl.Service.AssignAdvisor(c.Context(), studentUserID, advisorUUID)
+// IssuePasswordReset godoc
+// @Summary      Issue Password Reset Token
+// @Description  Admin membuat token reset password sekali pakai. Token hanya ditampilkan sekali dan dipakai di POST /auth/password/reset.
+// @Tags         Users (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "User ID (UUID)"
+// @Success      201  {object}  utils.JSONResponse
+// @Failure      404  {object}  utils.JSONResponse
+// @Router       /users/{id}/password-reset [post]
+func (ctrl *UserController) IssuePasswordReset(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid User ID format")
+	}
+
+	res, status, err := ctrl.Service.IssuePasswordResetToken(c.Context(), claims.UserID, id)
 	if err != nil {
 		return utils.ErrorResponse(c, status, err.Error())
 	}
-	return utils.SuccessResponse(c, status, "Advisor assigned successfully", nil)
-}
\ No newline at end of file
+	return utils.SuccessResponse(c, status, "Password reset token issued", res)
+}
+
+// UnlockUser godoc
+// @Summary      Unlock User Account
+// @Description  Admin membuka kunci akun yang terkunci sementara karena login gagal berulang
+// @Tags         Users (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "User ID (UUID)"
+// @Success      200  {object}  utils.JSONResponse
+// @Failure      404  {object}  utils.JSONResponse
+// @Router       /users/{id}/unlock [post]
+func (ctrl *UserController) UnlockUser(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid User ID format")
+	}
+
+	status, err := ctrl.Service.UnlockUser(c.Context(), id)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	return utils.SuccessResponse(c, status, "User account unlocked", nil)
+}
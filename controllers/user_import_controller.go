@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"prestasi-mahasiswa-api/middleware"
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type UserImportController struct {
+	Service services.UserImportService
+}
+
+func NewUserImportController(service services.UserImportService) *UserImportController {
+	return &UserImportController{Service: service}
+}
+
+// ImportUsers godoc
+// @Summary      Bulk Import Users (CSV/XLSX)
+// @Description  Admin mengimpor banyak user sekaligus beserta profil mahasiswa/dosen.
+// @Description  Kolom: username, email, full_name, role (wajib), nim_nip, program_study, academic_year, advisor_nip, department.
+// @Description  Mode transactional menyimpan semua baris atau tidak sama sekali; partial tetap menyimpan baris yang valid.
+// @Description  Password awal / link undangan di laporan hanya ditampilkan sekali.
+// @Tags         Users (Admin)
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        file formData file true "File .csv atau .xlsx"
+// @Param        mode formData string false "transactional (default) atau partial"
+// @Param        credential formData string false "invite (default) atau password"
+// @Param        dryRun formData bool false "Hanya validasi tanpa menyimpan"
+// @Success      200  {object}  utils.JSONResponse "Laporan dry-run"
+// @Success      201  {object}  utils.JSONResponse "Laporan import"
+// @Failure      400  {object}  utils.JSONResponse
+// @Failure      422  {object}  utils.JSONResponse "Tidak ada baris yang disimpan, lihat laporan per baris"
+// @Router       /users/import [post]
+func (ctrl *UserImportController) ImportUsers(c *fiber.Ctx) error {
+	claims := middleware.GetUserClaims(c)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "File is required")
+	}
+	if fileHeader.Size > 10*1024*1024 {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "File size too large (max 10MB)")
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to read uploaded file")
+	}
+	defer file.Close()
+
+	opts := models.UserImportOptions{
+		Mode:       c.FormValue("mode"),
+		Credential: c.FormValue("credential"),
+		DryRun:     c.FormValue("dryRun") == "true",
+	}
+
+	report, status, err := ctrl.Service.ImportUsers(c.Context(), claims.UserID, fileHeader.Filename, file, opts)
+	if err != nil {
+		return utils.ErrorResponse(c, status, err.Error())
+	}
+	if status >= fiber.StatusBadRequest {
+		// Laporan per baris tetap dikirim agar Admin bisa memperbaiki file
+		return c.Status(status).JSON(utils.JSONResponse{Status: "error", Message: "Import failed, no rows were saved", Data: report})
+	}
+	if report.DryRun {
+		return utils.SuccessResponse(c, status, "Import validated (dry run)", report)
+	}
+	return utils.SuccessResponse(c, status, "Users imported", report)
+}
@@ -0,0 +1,7 @@
+-- Trash bin prestasi: waktu soft delete dipakai untuk menghitung masa retensi
+ALTER TABLE achievement_references ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP NULL;
+
+UPDATE achievement_references SET deleted_at = updated_at WHERE is_deleted = TRUE AND deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_achievement_references_deleted_at
+	ON achievement_references (deleted_at) WHERE is_deleted = TRUE;
@@ -0,0 +1,15 @@
+-- Token JWT yang diterbitkan sebelum waktu ini dianggap tidak berlaku (ganti/reset password)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS sessions_revoked_at TIMESTAMP NULL;
+
+-- Token reset password sekali pakai (yang disimpan hanya hash SHA-256 dari token)
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+	id UUID PRIMARY KEY,
+	user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	token_hash VARCHAR(64) NOT NULL UNIQUE,
+	expires_at TIMESTAMP NOT NULL,
+	used_at TIMESTAMP NULL,
+	created_by UUID NULL REFERENCES users(id) ON DELETE SET NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_user_id ON password_reset_tokens (user_id);
@@ -0,0 +1,15 @@
+-- Riwayat percobaan login untuk proteksi brute-force (LOGIN_ATTEMPT_STORE=postgres)
+CREATE TABLE IF NOT EXISTS login_attempts (
+	id UUID PRIMARY KEY,
+	username VARCHAR(255) NOT NULL,
+	user_id UUID NULL REFERENCES users(id) ON DELETE SET NULL,
+	ip_address VARCHAR(64) NOT NULL,
+	user_agent TEXT NOT NULL DEFAULT '',
+	success BOOLEAN NOT NULL,
+	failure_reason VARCHAR(100) NULL,
+	cleared_at TIMESTAMP NULL, -- diisi saat login sukses atau di-unlock Admin
+	created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_attempts_username_created_at ON login_attempts (username, created_at);
+CREATE INDEX IF NOT EXISTS idx_login_attempts_ip_created_at ON login_attempts (ip_address, created_at);
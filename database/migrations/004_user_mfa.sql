@@ -0,0 +1,10 @@
+-- TOTP two-factor authentication
+CREATE TABLE IF NOT EXISTS user_mfa (
+	user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+	secret VARCHAR(64) NOT NULL,
+	enabled BOOLEAN NOT NULL DEFAULT FALSE,
+	recovery_code_hashes TEXT[] NOT NULL DEFAULT '{}', -- SHA-256 dari kode pemulihan yang belum dipakai
+	last_used_step BIGINT NOT NULL DEFAULT 0,           -- mencegah kode TOTP yang sama dipakai ulang
+	created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	enabled_at TIMESTAMP NULL
+);
@@ -0,0 +1,20 @@
+-- Kolom deskriptif untuk manajemen role & permission via API
+ALTER TABLE roles ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
+ALTER TABLE roles ADD COLUMN IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT NOW();
+ALTER TABLE permissions ADD COLUMN IF NOT EXISTS resource VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE permissions ADD COLUMN IF NOT EXISTS action VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE permissions ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_roles_name ON roles (name);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_permissions_name ON permissions (name);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_role_permissions_pair ON role_permissions (role_id, permission_id);
+
+-- Permission baru untuk endpoint /roles dan /permissions, diberikan ke Admin
+INSERT INTO permissions (id, name, resource, action, description)
+SELECT gen_random_uuid(), 'role:manage', 'role', 'manage', 'Mengelola role dan permission'
+WHERE NOT EXISTS (SELECT 1 FROM permissions WHERE name = 'role:manage');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name = 'Admin' AND p.name = 'role:manage'
+ON CONFLICT DO NOTHING;
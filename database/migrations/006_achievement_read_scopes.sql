@@ -0,0 +1,20 @@
+-- Permission data-scope untuk membaca prestasi, menggantikan pengecekan nama role di service
+INSERT INTO permissions (id, name, resource, action, description)
+SELECT gen_random_uuid(), v.name, 'achievement', v.action, v.description
+FROM (VALUES
+    ('achievement:read:own', 'read:own', 'Membaca prestasi milik sendiri'),
+    ('achievement:read:advisees', 'read:advisees', 'Membaca prestasi mahasiswa bimbingan'),
+    ('achievement:read:department', 'read:department', 'Membaca prestasi mahasiswa satu departemen'),
+    ('achievement:read:all', 'read:all', 'Membaca seluruh prestasi')
+) AS v(name, action, description)
+WHERE NOT EXISTS (SELECT 1 FROM permissions p WHERE p.name = v.name);
+
+-- Pemetaan awal sesuai perilaku lama
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r
+JOIN permissions p ON (r.name, p.name) IN (
+    ('Mahasiswa', 'achievement:read:own'),
+    ('Dosen Wali', 'achievement:read:advisees'),
+    ('Admin', 'achievement:read:all')
+)
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,81 @@
+-- Departemen dan program studi sebagai entitas (sebelumnya teks bebas di lecturers.department / students.program_study)
+CREATE TABLE IF NOT EXISTS departments (
+    id UUID PRIMARY KEY,
+    code VARCHAR(20) UNIQUE,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS study_programs (
+    id UUID PRIMARY KEY,
+    department_id UUID REFERENCES departments(id), -- NULL hanya untuk data migrasi yang belum dipetakan
+    code VARCHAR(20) UNIQUE,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    head_lecturer_id UUID REFERENCES lecturers(id) ON DELETE SET NULL, -- Kaprodi
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_study_programs_department ON study_programs (department_id);
+CREATE INDEX IF NOT EXISTS idx_study_programs_head ON study_programs (head_lecturer_id);
+
+ALTER TABLE lecturers ADD COLUMN IF NOT EXISTS department_id UUID REFERENCES departments(id);
+ALTER TABLE students ADD COLUMN IF NOT EXISTS study_program_id UUID REFERENCES study_programs(id);
+CREATE INDEX IF NOT EXISTS idx_students_study_program ON students (study_program_id);
+
+-- Staf fakultas: bukan dosen, tetapi terikat ke satu departemen
+CREATE TABLE IF NOT EXISTS faculty_staff (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    staff_id VARCHAR(20) NOT NULL,
+    department_id UUID NOT NULL REFERENCES departments(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Backfill dari teks bebas yang sudah ada
+INSERT INTO departments (id, name)
+SELECT gen_random_uuid(), d.name
+FROM (SELECT DISTINCT TRIM(department) AS name FROM lecturers WHERE TRIM(COALESCE(department, '')) <> '') d
+ON CONFLICT (name) DO NOTHING;
+
+UPDATE lecturers l SET department_id = d.id
+FROM departments d
+WHERE l.department_id IS NULL AND d.name = TRIM(l.department);
+
+-- Departemen program studi ditebak dari departemen dosen wali yang paling sering muncul
+INSERT INTO study_programs (id, name, department_id)
+SELECT gen_random_uuid(), p.name, p.department_id
+FROM (
+    SELECT TRIM(s.program_study) AS name, MODE() WITHIN GROUP (ORDER BY l.department_id) AS department_id
+    FROM students s
+    LEFT JOIN lecturers l ON l.id = s.advisor_id
+    WHERE TRIM(COALESCE(s.program_study, '')) <> ''
+    GROUP BY TRIM(s.program_study)
+) p
+ON CONFLICT (name) DO NOTHING;
+
+UPDATE students s SET study_program_id = sp.id
+FROM study_programs sp
+WHERE s.study_program_id IS NULL AND sp.name = TRIM(s.program_study);
+
+-- Role baru: Kaprodi (scope program studi) dan Staf Fakultas (scope departemen); keduanya tanpa achievement:verify
+INSERT INTO roles (id, name, description)
+SELECT gen_random_uuid(), v.name, v.description
+FROM (VALUES
+    ('Kaprodi', 'Ketua program studi, melihat dan melaporkan prestasi mahasiswa di prodinya'),
+    ('Staf Fakultas', 'Staf fakultas, melihat dan melaporkan prestasi mahasiswa di departemennya')
+) AS v(name, description)
+WHERE NOT EXISTS (SELECT 1 FROM roles r WHERE r.name = v.name);
+
+INSERT INTO permissions (id, name, resource, action, description)
+SELECT gen_random_uuid(), 'achievement:read:program', 'achievement', 'read:program', 'Membaca prestasi mahasiswa di program studi yang dipimpin'
+WHERE NOT EXISTS (SELECT 1 FROM permissions WHERE name = 'achievement:read:program');
+
+-- Kaprodi tetap dosen: mahasiswa bimbingannya sendiri harus tetap terlihat
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r
+JOIN permissions p ON (r.name, p.name) IN (
+    ('Kaprodi', 'achievement:read:program'),
+    ('Kaprodi', 'achievement:read:advisees'),
+    ('Staf Fakultas', 'achievement:read:department')
+)
+ON CONFLICT DO NOTHING;
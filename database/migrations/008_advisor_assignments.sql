@@ -0,0 +1,48 @@
+-- Riwayat penugasan dosen wali; students.advisor_id tetap menyimpan penugasan aktif
+CREATE TABLE IF NOT EXISTS advisor_assignments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    student_id UUID NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    lecturer_id UUID NOT NULL REFERENCES lecturers(id) ON DELETE CASCADE,
+    effective_from TIMESTAMP NOT NULL DEFAULT NOW(),
+    effective_to TIMESTAMP NULL,
+    assigned_by UUID NULL REFERENCES users(id) ON DELETE SET NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Satu penugasan aktif per mahasiswa
+CREATE UNIQUE INDEX IF NOT EXISTS idx_advisor_assignments_open
+    ON advisor_assignments (student_id) WHERE effective_to IS NULL;
+CREATE INDEX IF NOT EXISTS idx_advisor_assignments_lecturer ON advisor_assignments (lecturer_id);
+
+-- Penugasan yang sudah ada dianggap berlaku sejak profil mahasiswa dibuat
+INSERT INTO advisor_assignments (student_id, lecturer_id, effective_from, reason)
+SELECT s.id, s.advisor_id, s.created_at, 'initial'
+FROM students s
+WHERE s.advisor_id IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM advisor_assignments a WHERE a.student_id = s.id);
+
+-- Dosen yang bertugas memverifikasi pengajuan (diisi saat submit, dipindah saat reassign)
+ALTER TABLE achievement_references
+    ADD COLUMN IF NOT EXISTS assigned_advisor_id UUID NULL REFERENCES lecturers(id) ON DELETE SET NULL;
+
+UPDATE achievement_references ar
+SET assigned_advisor_id = s.advisor_id
+FROM students s
+WHERE s.user_id = ar.student_id
+  AND ar.status = 'submitted'
+  AND ar.assigned_advisor_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_achievement_references_assigned_advisor
+    ON achievement_references (assigned_advisor_id) WHERE status = 'submitted';
+
+-- Admin boleh memverifikasi pengajuan di luar mahasiswa bimbingannya
+INSERT INTO permissions (id, name, resource, action, description)
+SELECT gen_random_uuid(), 'achievement:verify:all', 'achievement', 'verify:all', 'Memverifikasi prestasi semua mahasiswa'
+WHERE NOT EXISTS (SELECT 1 FROM permissions WHERE name = 'achievement:verify:all');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r
+JOIN permissions p ON p.name = 'achievement:verify:all'
+WHERE r.name = 'Admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,4 @@
+-- Email unik tanpa membedakan huruf besar/kecil (melengkapi pengecekan duplikasi di service).
+-- Migrasi ini gagal jika masih ada email yang hanya berbeda kapitalisasi; rapikan data terlebih dahulu:
+--   SELECT LOWER(email), COUNT(*) FROM users GROUP BY LOWER(email) HAVING COUNT(*) > 1;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_lower ON users (LOWER(email));
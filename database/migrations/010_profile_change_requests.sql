@@ -0,0 +1,19 @@
+-- Pengajuan perubahan data akademik sensitif (NIM, prodi, angkatan, NIP, departemen) oleh user sendiri
+CREATE TABLE IF NOT EXISTS profile_change_requests (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    profile_type VARCHAR(20) NOT NULL CHECK (profile_type IN ('student', 'lecturer')),
+    changes JSONB NOT NULL,            -- field -> nilai baru
+    previous JSONB NOT NULL,           -- field -> nilai saat pengajuan dibuat
+    reason TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    review_note TEXT NOT NULL DEFAULT '',
+    reviewed_by UUID NULL REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Hanya satu pengajuan pending per user
+CREATE UNIQUE INDEX IF NOT EXISTS idx_profile_change_requests_pending
+    ON profile_change_requests (user_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_profile_change_requests_status ON profile_change_requests (status, created_at);
@@ -0,0 +1,12 @@
+-- SKPI (transkrip prestasi) yang pernah diterbitkan; kode dicetak sebagai QR untuk verifikasi publik
+CREATE TABLE IF NOT EXISTS student_transcripts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(64) NOT NULL UNIQUE,
+    student_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    issued_by UUID NULL REFERENCES users(id) ON DELETE SET NULL,
+    total_achievements INT NOT NULL DEFAULT 0,  -- snapshot saat diterbitkan
+    total_points INT NOT NULL DEFAULT 0,
+    issued_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_student_transcripts_student ON student_transcripts (student_user_id, issued_at);
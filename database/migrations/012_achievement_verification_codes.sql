@@ -0,0 +1,22 @@
+-- Kode verifikasi publik untuk prestasi terverifikasi (GET /public/verify/:code).
+-- Kode hangus saat verifikasi dicabut (revoked_at) atau ikut terhapus saat hard delete.
+CREATE TABLE IF NOT EXISTS achievement_verification_codes (
+    code VARCHAR(64) PRIMARY KEY,
+    achievement_ref_id UUID NOT NULL REFERENCES achievement_references(id) ON DELETE CASCADE,
+    issued_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP NULL
+);
+
+-- Satu kode aktif per prestasi
+CREATE UNIQUE INDEX IF NOT EXISTS idx_achievement_verification_codes_active
+    ON achievement_verification_codes (achievement_ref_id) WHERE revoked_at IS NULL;
+
+-- Terbitkan kode untuk prestasi yang sudah terverifikasi sebelum migrasi ini
+INSERT INTO achievement_verification_codes (code, achievement_ref_id)
+SELECT replace(gen_random_uuid()::text, '-', ''), ar.id
+FROM achievement_references ar
+WHERE ar.status = 'verified' AND ar.is_deleted = FALSE
+  AND NOT EXISTS (
+      SELECT 1 FROM achievement_verification_codes c
+      WHERE c.achievement_ref_id = ar.id AND c.revoked_at IS NULL
+  );
@@ -0,0 +1,13 @@
+-- Agregat statistik prestasi yang dimaterialisasi (GET /reports/statistics).
+-- Satu baris per (mahasiswa, status, jenis); rekap per program studi/jenis/status dijumlahkan dari tabel ini.
+-- Diperbarui per mahasiswa setiap transisi workflow dan dibangun ulang penuh secara berkala.
+-- Jenis prestasi tersimpan di MongoDB, jadi tabel diisi oleh aplikasi (rebuild pertama saat startup).
+CREATE TABLE IF NOT EXISTS achievement_stats (
+    student_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL,
+    achievement_type VARCHAR(50) NOT NULL DEFAULT '',
+    total INT NOT NULL DEFAULT 0,
+    points INT NOT NULL DEFAULT 0,
+    refreshed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (student_id, status, achievement_type)
+);
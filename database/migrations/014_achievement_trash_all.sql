@@ -0,0 +1,10 @@
+-- Trash hanya bisa dilihat/dipulihkan pemiliknya; Admin memegang permission eksplisit untuk trash semua mahasiswa
+INSERT INTO permissions (id, name, resource, action, description)
+SELECT gen_random_uuid(), 'achievement:delete:all', 'achievement', 'delete:all', 'Melihat dan memulihkan trash prestasi semua mahasiswa'
+WHERE NOT EXISTS (SELECT 1 FROM permissions WHERE name = 'achievement:delete:all');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r
+JOIN permissions p ON p.name = 'achievement:delete:all'
+WHERE r.name = 'Admin'
+ON CONFLICT DO NOTHING;
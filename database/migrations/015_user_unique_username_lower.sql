@@ -0,0 +1,6 @@
+-- Username unik tanpa membedakan huruf besar/kecil; login mencocokkan username yang sudah di-lowercase.
+-- Migrasi ini gagal jika masih ada username yang hanya berbeda kapitalisasi; rapikan data terlebih dahulu:
+--   SELECT LOWER(username), COUNT(*) FROM users GROUP BY LOWER(username) HAVING COUNT(*) > 1;
+UPDATE users SET username = LOWER(username) WHERE username <> LOWER(username);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username_lower ON users (LOWER(username));
@@ -0,0 +1,5 @@
+-- Pencabutan sesi memakai nomor versi (klaim "sv" pada JWT), bukan perbandingan waktu terbit token.
+-- User yang sesinya pernah dicabut mulai dari versi 1 agar token lama (tanpa klaim sv = versi 0) ikut ditolak.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS session_version INTEGER NOT NULL DEFAULT 0;
+UPDATE users SET session_version = 1 WHERE sessions_revoked_at IS NOT NULL AND session_version = 0;
+ALTER TABLE users DROP COLUMN IF EXISTS sessions_revoked_at;
@@ -0,0 +1,8 @@
+-- Menandai akun yang password-nya diverifikasi ke direktori LDAP.
+-- Hanya akun auth_source = 'ldap' yang login lewat LDAP dan role-nya disinkronkan dari memberOf;
+-- akun lain (termasuk Admin lokal dan mahasiswa) selalu memakai password lokal.
+-- Akun staf yang sudah ada dan harus login lewat direktori ditandai manual, contoh:
+--   UPDATE users SET auth_source = 'ldap' WHERE username IN ('dosen1', 'dosen2');
+ALTER TABLE users ADD COLUMN IF NOT EXISTS auth_source VARCHAR(20) NOT NULL DEFAULT 'local';
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_auth_source_check;
+ALTER TABLE users ADD CONSTRAINT users_auth_source_check CHECK (auth_source IN ('local', 'ldap'));
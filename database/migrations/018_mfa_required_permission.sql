@@ -0,0 +1,11 @@
+-- 2FA wajib ditentukan oleh permission mfa:required (menggantikan MFA_REQUIRED_ROLES berbasis nama role),
+-- sehingga tetap berlaku walaupun role diganti nama. Default sama dengan konfigurasi sebelumnya: Admin & Dosen Wali.
+INSERT INTO permissions (id, name, resource, action, description)
+SELECT gen_random_uuid(), 'mfa:required', 'mfa', 'required', 'Wajib memakai two-factor authentication saat login'
+WHERE NOT EXISTS (SELECT 1 FROM permissions WHERE name = 'mfa:required');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r
+JOIN permissions p ON p.name = 'mfa:required'
+WHERE r.name IN ('Admin', 'Dosen Wali')
+ON CONFLICT DO NOTHING;
@@ -63,6 +63,84 @@ const docTemplate = `{
                 }
             }
         },
+        "/achievements/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengunduh rekap prestasi (scope sama dengan GET /achievements) dalam format CSV, XLSX, atau PDF. Data dialirkan bertahap sehingga aman untuk jumlah besar.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Export Achievements",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv (default) | xlsx | pdf",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/achievements/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Melihat prestasi yang sudah dihapus (soft delete). User melihat miliknya, pemegang achievement:delete:all melihat semua.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "List Trashed Achievements",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/achievements/{id}": {
             "get": {
                 "security": [
@@ -117,23 +195,53 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
+                "description": "Unggah file bukti prestasi. Format yang diizinkan: JPG, PNG, dan PDF. Ukuran maksimal 5MB.",
                 "consumes": [
                     "multipart/form-data"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "Achievements"
                 ],
                 "summary": "Upload Attachment",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "file",
-                        "description": "File",
+                        "description": "File lampiran (JPG/PNG/PDF, Max 5MB)",
                         "name": "attachment",
                         "in": "formData",
                         "required": true
                     }
                 ],
-                "responses": {}
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Format file salah atau ukuran terlalu besar",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Gagal menyimpan file di server",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
             }
         },
         "/achievements/{id}/hard": {
@@ -165,37 +273,54 @@ const docTemplate = `{
                 "responses": {}
             }
         },
-        "/achievements/{id}/submit": {
+        "/achievements/{id}/restore": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Mengembalikan prestasi yang sudah dihapus (soft delete) oleh pemilik atau pemegang achievement:delete:all",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Submit Achievement",
-                "responses": {}
+                "summary": "Restore Achievement from Trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
             }
         },
-        "/achievements/{id}/verify": {
+        "/achievements/{id}/revoke": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "tags": [
-                    "Achievements"
-                ],
-                "summary": "Verify Achievement (Dosen Wali)",
-                "responses": {}
-            }
-        },
-        "/auth/login": {
-            "post": {
-                "description": "Masuk menggunakan username dan password untuk mendapatkan token JWT",
+                "description": "Mencabut verifikasi prestasi (status kembali menjadi rejected dengan alasan pencabutan). Kode verifikasi publik prestasi ini langsung tidak berlaku.",
                 "consumes": [
                     "application/json"
                 ],
@@ -203,29 +328,60 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Achievements"
                 ],
-                "summary": "Login User",
+                "summary": "Revoke Achievement Verification",
                 "parameters": [
                     {
-                        "description": "Credentials",
+                        "type": "string",
+                        "description": "Achievement Reference ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alasan pencabutan",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.LoginRequest"
+                            "$ref": "#/definitions/models.RevokeAchievementRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AchievementReference"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/utils.JSONResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/utils.JSONResponse"
                         }
@@ -233,18 +389,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/logout": {
+        "/achievements/{id}/submit": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Logout dari sistem (Client harus menghapus token di sisi mereka)",
                 "tags": [
-                    "Auth"
+                    "Achievements"
                 ],
-                "summary": "Logout User",
+                "summary": "Submit Achievement",
+                "responses": {}
+            }
+        },
+        "/achievements/{id}/verify": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Verify Achievement (Dosen Wali)",
+                "responses": {}
+            }
+        },
+        "/auth/2fa": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Melihat apakah 2FA sudah aktif dan apakah wajib untuk role user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Two-Factor Auth"
+                ],
+                "summary": "Get Two-Factor Status",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -255,14 +442,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/profile": {
-            "get": {
+        "/auth/2fa/disable": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mendapatkan data profil user yang sedang login",
+                "description": "Menonaktifkan 2FA milik sendiri (wajib kode TOTP/pemulihan). Tidak berlaku untuk role dengan permission mfa:required.",
                 "consumes": [
                     "application/json"
                 ],
@@ -270,9 +457,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Two-Factor Auth"
+                ],
+                "summary": "Disable Two-Factor Authentication",
+                "parameters": [
+                    {
+                        "description": "Kode TOTP/pemulihan",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MFADisableRequest"
+                        }
+                    }
                 ],
-                "summary": "Get User Profile",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -280,8 +478,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.JSONResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/utils.JSONResponse"
                         }
@@ -289,14 +487,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/reports/statistics": {
-            "get": {
+        "/auth/2fa/enable": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Melihat ringkasan data statistik prestasi (Total, by Status, by Type)",
+                "description": "Mengaktifkan 2FA dengan kode TOTP pertama. Kode pemulihan hanya ditampilkan sekali.",
                 "consumes": [
                     "application/json"
                 ],
@@ -304,9 +502,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Two-Factor Auth"
+                ],
+                "summary": "Confirm Two-Factor Enrollment",
+                "parameters": [
+                    {
+                        "description": "Kode TOTP",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MFACodeRequest"
+                        }
+                    }
                 ],
-                "summary": "Get Dashboard Statistics",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -314,8 +523,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.JSONResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/utils.JSONResponse"
                         }
@@ -323,24 +532,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
-            "get": {
+        "/auth/2fa/setup": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin melihat semua daftar pengguna (Mahasiswa, Dosen, Admin)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Membuat secret TOTP baru beserta provisioning URI (otpauth://) dan QR code",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users (Admin)"
+                    "Two-Factor Auth"
                 ],
-                "summary": "List All Users",
+                "summary": "Start Two-Factor Enrollment",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -348,21 +554,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.JSONResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/utils.JSONResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/login": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Admin membuat akun pengguna baru",
+                "description": "Masuk menggunakan username dan password untuk mendapatkan token JWT.\nJika 2FA aktif/wajib, respons berisi challengeToken untuk POST /auth/login/2fa.",
                 "consumes": [
                     "application/json"
                 ],
@@ -370,44 +573,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users (Admin)"
+                    "Auth"
                 ],
-                "summary": "Create New User",
+                "summary": "Login User",
                 "parameters": [
                     {
-                        "description": "User Data",
+                        "description": "Credentials",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateUserRequest"
+                            "$ref": "#/definitions/models.LoginRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/utils.JSONResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Akun dikunci sementara",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Terlalu banyak percobaan login",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Direktori LDAP tidak bisa dihubungi",
                         "schema": {
                             "$ref": "#/definitions/utils.JSONResponse"
                         }
                     }
-                }
-            }
-        },
-        "/users/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Admin melihat detail data pengguna berdasarkan UUID",
+                }
+            }
+        },
+        "/auth/login/2fa": {
+            "post": {
+                "description": "Tahap kedua login: tukar challenge token dan kode TOTP (atau kode pemulihan) dengan token JWT.\nUntuk challenge enrollment, kode sekaligus mengaktifkan 2FA dan kode pemulihan dikembalikan sekali.",
                 "consumes": [
                     "application/json"
                 ],
@@ -415,16 +631,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users (Admin)"
+                    "Auth"
                 ],
-                "summary": "Get User By ID",
+                "summary": "Complete Two-Factor Login",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Challenge token \u0026 kode",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MFAChallengeRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -434,21 +652,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.JSONResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/utils.JSONResponse"
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Admin memperbarui data pengguna",
+            }
+        },
+        "/auth/login/2fa/setup": {
+            "post": {
+                "description": "Untuk role yang wajib 2FA tetapi belum enroll: memulai setup TOTP menggunakan challenge token dari login",
                 "consumes": [
                     "application/json"
                 ],
@@ -456,24 +671,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users (Admin)"
+                    "Auth"
                 ],
-                "summary": "Update User",
+                "summary": "Start Mandatory Two-Factor Enrollment",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Data",
+                        "description": "Challenge token",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateUserRequest"
+                            "$ref": "#/definitions/models.MFAEnrollmentChallengeRequest"
                         }
                     }
                 ],
@@ -484,37 +692,60 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.JSONResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/utils.JSONResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/auth/logout": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin menonaktifkan akun pengguna",
-                "consumes": [
-                    "application/json"
+                "description": "Logout dari sistem (Client harus menghapus token di sisi mereka)",
+                "tags": [
+                    "Auth"
                 ],
+                "summary": "Logout User",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/callback": {
+            "get": {
+                "description": "Dipanggil identity provider setelah login. Menerbitkan token JWT yang sama dengan POST /auth/login.\nAkun dicocokkan berdasarkan NIM, NIP, lalu email terverifikasi.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users (Admin)"
+                    "Auth"
                 ],
-                "summary": "Deactivate User (Soft Delete)",
+                "summary": "SSO Login Callback (OIDC)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID (UUID)",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State",
+                        "name": "state",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -530,18 +761,56 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/utils.JSONResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
                     }
                 }
             }
         },
-        "/users/{id}/advisor": {
+        "/auth/oidc/login": {
+            "get": {
+                "description": "Redirect ke identity provider kampus. State, nonce, dan PKCE verifier disimpan di cookie HttpOnly.",
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Start SSO Login (OIDC)",
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghubungkan mahasiswa dengan dosen walinya",
+                "description": "User mengganti password sendiri. Semua sesi (token) lama akan dicabut sehingga perlu login ulang.",
                 "consumes": [
                     "application/json"
                 ],
@@ -549,24 +818,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users (Admin)"
+                    "Auth"
                 ],
-                "summary": "Assign Advisor to Student",
+                "summary": "Change Password",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID Mahasiswa (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "User ID Dosen (UUID)",
+                        "description": "Password lama \u0026 baru",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.AssignAdvisorRequest"
+                            "$ref": "#/definitions/models.ChangePasswordRequest"
                         }
                     }
                 ],
@@ -576,18 +838,25 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/utils.JSONResponse"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
                     }
                 }
             }
         },
-        "/users/{id}/lecturer-profile": {
+        "/auth/password/reset": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Melengkapi data kepegawaian dosen (NIP, Departemen)",
+                "description": "Mengatur password baru menggunakan token reset sekali pakai yang diberikan Admin",
                 "consumes": [
                     "application/json"
                 ],
@@ -595,24 +864,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users (Admin)"
+                    "Auth"
                 ],
-                "summary": "Set Lecturer Profile",
+                "summary": "Reset Password with Token",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Lecturer Profile Data",
+                        "description": "Token reset \u0026 password baru",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.LecturerProfileRequest"
+                            "$ref": "#/definitions/models.ResetPasswordRequest"
                         }
                     }
                 ],
@@ -622,18 +884,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/utils.JSONResponse"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
                     }
                 }
             }
         },
-        "/users/{id}/student-profile": {
-            "post": {
+        "/auth/profile": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Melengkapi data akademik mahasiswa (NIM, Prodi, Tahun)",
+                "description": "Mendapatkan data profil user yang sedang login, termasuk profil mahasiswa (dengan dosen wali) atau dosen jika ada",
                 "consumes": [
                     "application/json"
                 ],
@@ -641,130 +909,4096 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users (Admin)"
+                    "Auth"
                 ],
-                "summary": "Set Student Profile",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "User ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                "summary": "Get User Profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
                     {
-                        "description": "Student Profile Data",
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah profil sendiri. fullName \u0026 email langsung diterapkan; perubahan NIM/NIP, prodi, angkatan, atau departemen diajukan sebagai change request yang harus disetujui Admin (maksimal satu pengajuan pending).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Update Own Profile",
+                "parameters": [
+                    {
+                        "description": "Field yang diubah",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.StudentProfileRequest"
+                            "$ref": "#/definitions/models.SelfProfileUpdateRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SelfProfileUpdateResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/utils.JSONResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.AssignAdvisorRequest": {
-            "type": "object",
-            "properties": {
-                "advisorUserId": {
-                    "description": "Kita input User ID Dosen, nanti sistem cari Lecturer ID-nya",
-                    "type": "string"
-                }
-            }
         },
-        "models.CreateAchievementRequest": {
-            "type": "object",
-            "properties": {
-                "achievementType": {
-                    "type": "string"
+        "/auth/profile/change-requests": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Riwayat pengajuan perubahan profil milik user yang sedang login",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "List Own Profile Change Requests",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ProfileChangeRequest"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/departments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Melihat daftar departemen",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Academic Units"
+                ],
+                "summary": "List Departments",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin membuat departemen baru",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Academic Units"
+                ],
+                "summary": "Create Department",
+                "parameters": [
+                    {
+                        "description": "Data Departemen",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.DepartmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/departments/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Academic Units"
+                ],
+                "summary": "Get Department By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Department ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin mengubah nama/kode departemen (nama di profil dosen ikut diperbarui)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Academic Units"
+                ],
+                "summary": "Update Department",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Department ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Departemen",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.DepartmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin menghapus departemen yang tidak lagi memiliki prodi, dosen, atau staf",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Academic Units"
+                ],
+                "summary": "Delete Department",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Department ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lecturers/{id}/advisees": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Daftar mahasiswa bimbingan seorang dosen wali. Dosen hanya dapat melihat bimbingannya sendiri; Admin dapat melihat semua.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lecturers"
+                ],
+                "summary": "List Advisees",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID Dosen (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.AdviseeSummary"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles \u0026 Permissions (Admin)"
+                ],
+                "summary": "List Permissions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Nama permission berformat resource:action, contoh: achievement:verify",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles \u0026 Permissions (Admin)"
+                ],
+                "summary": "Create Permission",
+                "parameters": [
+                    {
+                        "description": "Data Permission",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PermissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/permissions/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles \u0026 Permissions (Admin)"
+                ],
+                "summary": "Update Permission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Permission ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Permission",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PermissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus permission sekaligus mencabutnya dari semua role",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles \u0026 Permissions (Admin)"
+                ],
+                "summary": "Delete Permission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Permission ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/profile-change-requests": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Antrean dan riwayat pengajuan perubahan profil (Admin)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users (Admin)"
+                ],
+                "summary": "List Profile Change Requests",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pending | approved | rejected",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter per User ID (UUID)",
+                        "name": "userId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ProfileChangeRequest"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/profile-change-requests/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menyetujui pengajuan dan menerapkan perubahan ke profil mahasiswa/dosen",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users (Admin)"
+                ],
+                "summary": "Approve Profile Change Request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Change Request ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Catatan reviewer",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewProfileChangeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ProfileChangeRequest"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/profile-change-requests/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menolak pengajuan perubahan profil (catatan wajib diisi)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users (Admin)"
+                ],
+                "summary": "Reject Profile Change Request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Change Request ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alasan penolakan",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewProfileChangeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ProfileChangeRequest"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/transcripts/{code}": {
+            "get": {
+                "description": "Endpoint publik (tanpa login) untuk memastikan keaslian SKPI dari kode pada QR code. Kode tidak terdaftar menghasilkan valid=false.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public"
+                ],
+                "summary": "Verify Student Transcript (SKPI)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kode verifikasi dokumen",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.TranscriptVerification"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/verify/{code}": {
+            "get": {
+                "description": "Endpoint publik (tanpa login, dibatasi rate limit per IP) untuk memeriksa kode verifikasi prestasi. Hanya mengembalikan nama mahasiswa, judul, jenis, tanggal verifikasi, dan role verifikator; status bernilai valid, revoked, atau not_found.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public"
+                ],
+                "summary": "Verify Achievement Certificate (Public)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kode verifikasi prestasi",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AchievementVerification"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/accreditation": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tabel prestasi akademik \u0026 non-akademik per program studi untuk akreditasi (BAN-PT/LAM) selama tiga tahun terakhir (TS-2 s.d. TS), dikelompokkan per tingkat lokal/wilayah, nasional, dan internasional berdasarkan details.level. Kategori akademik ditentukan dari jenis prestasi (ACCREDITATION_ACADEMIC_TYPES) atau details.category. Prestasi dengan tingkat tidak dikenali dicantumkan di unmapped.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get Accreditation Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Program Studi (UUID); kosong = semua prodi dalam scope",
+                        "name": "studyProgramId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tahun acuan TS (default tahun berjalan)",
+                        "name": "year",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AccreditationReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/accreditation/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengunduh paket tabel akreditasi. XLSX (default) berisi satu worksheet per program studi.",
+                "produces": [
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/pdf",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Export Accreditation Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Program Studi (UUID); kosong = semua prodi dalam scope",
+                        "name": "studyProgramId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tahun acuan TS (default tahun berjalan)",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "xlsx (default) | pdf | csv",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/advisees": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Statistik prestasi khusus mahasiswa bimbingan dosen wali: total per status \u0026 tipe, poin per mahasiswa, dan jumlah pengajuan yang menunggu verifikasi. Tanpa advisorUserId dipakai user yang sedang login; Admin dapat melihat dosen lain.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get Advisee Statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID Dosen Wali (UUID)",
+                        "name": "advisorUserId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AdviseeStats"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/leaderboard": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Peringkat mahasiswa berdasarkan total poin prestasi terverifikasi (poin sama = peringkat sama). Dapat difilter per prodi, angkatan, dan tipe prestasi. Nama \u0026 NIM mahasiswa di luar scope baca pemanggil disamarkan; anonymize=true menyamarkan semua kecuali diri sendiri.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get Points Leaderboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter Program Studi (UUID)",
+                        "name": "studyProgramId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter angkatan, mis. 2021",
+                        "name": "academicYear",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter tipe prestasi",
+                        "name": "achievementType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Samarkan nama (tampilan mahasiswa)",
+                        "name": "anonymize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Halaman (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman (default 20, maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.LeaderboardResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/statistics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Melihat ringkasan data statistik prestasi (Total, by Status, by Type, by Program Studi) sesuai scope permission achievement:read:*. Semua rekap dihitung dari prestasi aktif yang sama; asOf menunjukkan kapan angka dihitung.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get Dashboard Statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter status, pisahkan dengan koma (draft,submitted,verified,rejected)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal dibuat mulai (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal dibuat sampai (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter Program Studi (UUID)",
+                        "name": "studyProgramId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.DashboardStats"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/statistics/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengunduh ringkasan statistik prestasi (per status, jenis \u0026 program studi) dalam format CSV, XLSX, atau PDF",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Export Dashboard Statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv (default) | xlsx | pdf",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter status, pisahkan dengan koma",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal dibuat mulai (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal dibuat sampai (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter Program Studi (UUID)",
+                        "name": "studyProgramId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/students/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Laporan prestasi per mahasiswa: NIM \u0026 prodi, total poin terverifikasi, jumlah per status \u0026 tipe, timeline per semester, dan prestasi teratas. Dapat diakses mahasiswa ybs., dosen wali, atau Admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get Student Achievement Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID Mahasiswa (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.StudentStats"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/students/{id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengunduh laporan prestasi per mahasiswa (timeline semester, prestasi teratas, rekap status \u0026 jenis)",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Export Student Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID Mahasiswa (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv (default) | xlsx | pdf",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/students/{id}/transcript.pdf": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menerbitkan Surat Keterangan Pendamping Ijazah berisi prestasi terverifikasi per jenis (poin, tanggal, verifikator) beserta QR code menuju endpoint verifikasi publik. Setiap unduhan mendapat nomor dokumen baru.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Download Student Transcript (SKPI)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID Mahasiswa (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/trends": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deret waktu jumlah prestasi dan poin (prestasi terverifikasi) per bulan, semester, atau tahun akademik, opsional dipecah per tipe/status/prodi, beserta persentase pertumbuhan terhadap periode sebelumnya. Data dibatasi scope permission achievement:read:*.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get Achievement Trends",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "month (default) | semester | year",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "type | status | programStudy",
+                        "name": "groupBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "submittedAt (default) | verifiedAt",
+                        "name": "dateField",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal awal (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal akhir (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.TrendReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin melihat semua role beserta permission-nya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles \u0026 Permissions (Admin)"
+                ],
+                "summary": "List Roles",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles \u0026 Permissions (Admin)"
+                ],
+                "summary": "Create Role",
+                "parameters": [
+                    {
+                        "description": "Data Role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles \u0026 Permissions (Admin)"
+                ],
+                "summary": "Get Role By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah nama/deskripsi role. Role bawaan (Admin, Dosen Wali, Mahasiswa) tidak bisa diganti nama.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles \u0026 Permissions (Admin)"
+                ],
+                "summary": "Update Role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Role bawaan dan role yang masih dipakai user tidak bisa dihapus",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles \u0026 Permissions (Admin)"
+                ],
+                "summary": "Delete Role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Role masih dipakai user",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles/{id}/permissions": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengganti seluruh permission milik role. Berlaku untuk request berikutnya tanpa perlu login ulang.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles \u0026 Permissions (Admin)"
+                ],
+                "summary": "Replace Role Permissions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Daftar nama permission",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RolePermissionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles/{id}/permissions/{permissionId}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles \u0026 Permissions (Admin)"
+                ],
+                "summary": "Assign Permission To Role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Permission ID (UUID)",
+                        "name": "permissionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles \u0026 Permissions (Admin)"
+                ],
+                "summary": "Revoke Permission From Role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Permission ID (UUID)",
+                        "name": "permissionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/study-programs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Melihat daftar program studi, opsional difilter per departemen",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Academic Units"
+                ],
+                "summary": "List Study Programs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Department ID (UUID)",
+                        "name": "departmentId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin membuat program studi di bawah departemen, opsional sekaligus menunjuk Kaprodi",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Academic Units"
+                ],
+                "summary": "Create Study Program",
+                "parameters": [
+                    {
+                        "description": "Data Program Studi",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.StudyProgramRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/study-programs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Academic Units"
+                ],
+                "summary": "Get Study Program By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Study Program ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin mengubah program studi (nama di profil mahasiswa ikut diperbarui)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Academic Units"
+                ],
+                "summary": "Update Study Program",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Study Program ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Program Studi",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.StudyProgramRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin menghapus program studi yang tidak memiliki mahasiswa",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Academic Units"
+                ],
+                "summary": "Delete Study Program",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Study Program ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin melihat daftar pengguna dengan pencarian, filter, sort, dan pagination. Profil mahasiswa/dosen disertakan.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users (Admin)"
+                ],
+                "summary": "List Users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Halaman (mulai 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman (maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cari nama, username, email, NIM, atau NIP",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nama role",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Status aktif",
+                        "name": "isActive",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID program studi",
+                        "name": "studyProgramId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nama program studi",
+                        "name": "programStudy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID dosen wali",
+                        "name": "advisorUserId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "username | name | email | role | createdAt | studentId | lecturerId",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc | desc",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserListResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin membuat akun pengguna baru",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users (Admin)"
+                ],
+                "summary": "Create New User",
+                "parameters": [
+                    {
+                        "description": "User Data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Username/email sudah dipakai (detail di errors)",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validasi gagal (detail di errors)",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/advisors/bulk-assign": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menetapkan dosen wali untuk daftar mahasiswa (studentUserIds), atau seluruh mahasiswa suatu prodi dan/atau angkatan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users (Admin)"
+                ],
+                "summary": "Bulk Assign Advisor",
+                "parameters": [
+                    {
+                        "description": "Dosen tujuan dan kriteria mahasiswa",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkAssignAdvisorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/advisors/transfer": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memindahkan seluruh mahasiswa bimbingan dosen A ke dosen B (mis. dosen pindah/pensiun), termasuk pengajuan yang menunggu verifikasi",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users (Admin)"
+                ],
+                "summary": "Transfer Advisees",
+                "parameters": [
+                    {
+                        "description": "Dosen asal dan tujuan",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TransferAdviseesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin mengimpor banyak user sekaligus beserta profil mahasiswa/dosen.\nKolom: username, email, full_name, role (wajib), nim_nip, program_study, academic_year, advisor_nip, department.\nMode transactional menyimpan semua baris atau tidak sama sekali; partial tetap menyimpan baris yang valid.\nPassword awal / link undangan di laporan hanya ditampilkan sekali.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users (Admin)"
+                ],
+                "summary": "Bulk Import Users (CSV/XLSX)",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "File .csv atau .xlsx",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "transactional (default) atau partial",
+                        "name": "mode",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "invite (default) atau password",
+                        "name": "credential",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Hanya validasi tanpa menyimpan",
+                        "name": "dryRun",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Laporan dry-run",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Laporan import",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Tidak ada baris yang disimpan, lihat laporan per baris",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin melihat detail data pengguna berdasarkan UUID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users (Admin)"
+                ],
+                "summary": "Get User By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin memperbarui data pengguna",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users (Admin)"
+                ],
+                "summary": "Update User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin menonaktifkan akun pengguna",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users (Admin)"
+                ],
+                "summary": "Deactivate User (Soft Delete)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/2fa": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin menghapus konfigurasi 2FA user (misal perangkat hilang). User harus enroll ulang.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users (Admin)"
+                ],
+                "summary": "Reset User Two-Factor (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/advisor": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghubungkan mahasiswa dengan dosen walinya. Penugasan lama ditutup di riwayat dan pengajuan yang menunggu verifikasi dialihkan ke dosen baru.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users (Admin)"
+                ],
+                "summary": "Assign Advisor to Student",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID Mahasiswa (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User ID Dosen (UUID)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AssignAdvisorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/advisor-history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Riwayat dosen wali seorang mahasiswa beserta tanggal berlakunya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users (Admin)"
+                ],
+                "summary": "Advisor Assignment History",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID Mahasiswa (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/lecturer-profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Melihat profil dosen (NIP, departemen) beserta jumlah mahasiswa bimbingan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users (Admin)"
+                ],
+                "summary": "Get Lecturer Profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID Dosen (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.LecturerProfileDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Melengkapi data kepegawaian dosen (NIP, Departemen). Departemen dipilih lewat departmentId",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users (Admin)"
+                ],
+                "summary": "Set Lecturer Profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Lecturer Profile Data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LecturerProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/password-reset": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin membuat token reset password sekali pakai. Token hanya ditampilkan sekali dan dipakai di POST /auth/password/reset.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users (Admin)"
+                ],
+                "summary": "Issue Password Reset Token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/staff-profile": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Melengkapi data staf fakultas (NIP/NIK, Departemen) untuk scope laporan per departemen",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users (Admin)"
+                ],
+                "summary": "Set Faculty Staff Profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Faculty Staff Profile Data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.FacultyStaffProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/student-profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Melihat profil akademik mahasiswa (NIM, prodi, angkatan) beserta dosen walinya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users (Admin)"
+                ],
+                "summary": "Get Student Profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID Mahasiswa (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.StudentProfileDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Melengkapi data akademik mahasiswa (NIM, Prodi, Tahun). Prodi dipilih lewat studyProgramId",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users (Admin)"
+                ],
+                "summary": "Set Student Profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Student Profile Data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.StudentProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/unlock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin membuka kunci akun yang terkunci sementara karena login gagal berulang",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users (Admin)"
+                ],
+                "summary": "Unlock User Account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JSONResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.AccreditationEntry": {
+            "type": "object",
+            "properties": {
+                "achievementType": {
+                    "type": "string"
+                },
+                "level": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "alasan tidak masuk tabel (hanya pada Unmapped)",
+                    "type": "string"
+                },
+                "refId": {
+                    "type": "string"
+                },
+                "studentName": {
+                    "type": "string"
+                },
+                "studentNumber": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AccreditationProgramReport": {
+            "type": "object",
+            "properties": {
+                "academic": {
+                    "$ref": "#/definitions/models.AccreditationTable"
+                },
+                "nonAcademic": {
+                    "$ref": "#/definitions/models.AccreditationTable"
+                },
+                "programStudy": {
+                    "type": "string"
+                },
+                "studyProgramId": {
+                    "type": "string"
+                },
+                "unmapped": {
+                    "description": "prestasi dalam rentang tahun yang tingkatnya tidak dikenali",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AccreditationEntry"
+                    }
+                }
+            }
+        },
+        "models.AccreditationReport": {
+            "type": "object",
+            "properties": {
+                "programs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AccreditationProgramReport"
+                    }
+                },
+                "referenceYear": {
+                    "type": "integer"
+                },
+                "years": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.AccreditationTable": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AccreditationEntry"
+                    }
+                },
+                "summary": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AccreditationYearCount"
+                    }
+                }
+            }
+        },
+        "models.AccreditationYearCount": {
+            "type": "object",
+            "properties": {
+                "international": {
+                    "type": "integer"
+                },
+                "label": {
+                    "description": "TS-2, TS-1, TS",
+                    "type": "string"
+                },
+                "national": {
+                    "type": "integer"
+                },
+                "regional": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AchievementRecord": {
+            "type": "object",
+            "properties": {
+                "achievementType": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "programStudy": {
+                    "type": "string"
+                },
+                "refId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "studentName": {
+                    "type": "string"
+                },
+                "studentNumber": {
+                    "description": "NIM",
+                    "type": "string"
+                },
+                "studentUserId": {
+                    "type": "string"
+                },
+                "submittedAt": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "verifiedAt": {
+                    "type": "string"
+                },
+                "verifierName": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AchievementReference": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isDeleted": {
+                    "type": "boolean"
+                },
+                "mongoAchievementId": {
+                    "type": "string"
+                },
+                "rejectionNote": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "studentId": {
+                    "type": "string"
+                },
+                "submittedAt": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "verifiedAt": {
+                    "type": "string"
+                },
+                "verifiedBy": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AchievementVerification": {
+            "type": "object",
+            "properties": {
+                "achievementType": {
+                    "type": "string"
+                },
+                "revokedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "valid | revoked | not_found",
+                    "type": "string"
+                },
+                "studentName": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "valid": {
+                    "type": "boolean"
+                },
+                "verifiedAt": {
+                    "type": "string"
+                },
+                "verifierRole": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AdviseePoints": {
+            "type": "object",
+            "properties": {
+                "fullName": {
+                    "type": "string"
+                },
+                "studentId": {
+                    "description": "NIM",
+                    "type": "string"
+                },
+                "totalPoints": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "string"
+                },
+                "verifiedAchievements": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AdviseeStats": {
+            "type": "object",
+            "properties": {
+                "adviseeCount": {
+                    "type": "integer"
+                },
+                "advisorUserId": {
+                    "type": "string"
+                },
+                "byStatus": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "byType": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "pendingVerification": {
+                    "description": "antrean pengajuan yang menunggu dosen ini",
+                    "type": "integer"
+                },
+                "students": {
+                    "description": "urut poin tertinggi",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AdviseePoints"
+                    }
+                },
+                "totalAchievements": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AdviseeSummary": {
+            "type": "object",
+            "properties": {
+                "academicYear": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "fullName": {
+                    "type": "string"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "programStudy": {
+                    "type": "string"
+                },
+                "studentId": {
+                    "description": "NIM",
+                    "type": "string"
+                },
+                "studyProgramId": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AdvisorSummary": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "fullName": {
+                    "type": "string"
+                },
+                "lecturerId": {
+                    "description": "NIP",
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AssignAdvisorRequest": {
+            "type": "object",
+            "properties": {
+                "advisorUserId": {
+                    "description": "Kita input User ID Dosen, nanti sistem cari Lecturer ID-nya",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Dicatat di riwayat penugasan",
+                    "type": "string"
+                }
+            }
+        },
+        "models.BulkAssignAdvisorRequest": {
+            "type": "object",
+            "properties": {
+                "academicYear": {
+                    "type": "string"
+                },
+                "advisorUserId": {
+                    "type": "string"
+                },
+                "programStudy": {
+                    "description": "Nama prodi, dipakai jika studyProgramId kosong",
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "studentUserIds": {
+                    "description": "Jika diisi, filter lain diabaikan",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "studyProgramId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ChangePasswordRequest": {
+            "type": "object",
+            "properties": {
+                "currentPassword": {
+                    "type": "string"
+                },
+                "newPassword": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateAchievementRequest": {
+            "type": "object",
+            "properties": {
+                "achievementType": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateUserRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "fullName": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "roleName": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DashboardStats": {
+            "type": "object",
+            "properties": {
+                "asOf": {
+                    "description": "waktu angka dihitung (entri cache tetap membawa waktu aslinya)",
+                    "type": "string"
+                },
+                "byProgramStudy": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "byStatus": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "byType": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "totalAchievements": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DepartmentRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FacultyStaffProfileRequest": {
+            "type": "object",
+            "properties": {
+                "departmentId": {
+                    "type": "string"
+                },
+                "staffId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LeaderboardEntry": {
+            "type": "object",
+            "properties": {
+                "academicYear": {
+                    "type": "string"
+                },
+                "anonymized": {
+                    "type": "boolean"
+                },
+                "fullName": {
+                    "type": "string"
+                },
+                "isCurrentUser": {
+                    "type": "boolean"
+                },
+                "programStudy": {
+                    "type": "string"
+                },
+                "rank": {
+                    "description": "nilai sama mendapat peringkat sama (1, 1, 3)",
+                    "type": "integer"
+                },
+                "studentId": {
+                    "description": "NIM (disamarkan jika anonim)",
+                    "type": "string"
+                },
+                "totalPoints": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "string"
+                },
+                "verifiedAchievements": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LeaderboardResult": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LeaderboardEntry"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                }
+            }
+        },
+        "models.Lecturer": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "department": {
+                    "type": "string"
+                },
+                "departmentId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lecturerId": {
+                    "description": "NIP",
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LecturerProfileDetail": {
+            "type": "object",
+            "properties": {
+                "adviseeCount": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "department": {
+                    "type": "string"
+                },
+                "departmentId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lecturerId": {
+                    "description": "NIP",
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LecturerProfileRequest": {
+            "type": "object",
+            "properties": {
+                "department": {
+                    "description": "Nama departemen, dipakai jika departmentId kosong",
+                    "type": "string"
+                },
+                "departmentId": {
+                    "type": "string"
+                },
+                "lecturerId": {
+                    "description": "NIP",
+                    "type": "string"
+                }
+            }
+        },
+        "models.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MFAChallengeRequest": {
+            "type": "object",
+            "properties": {
+                "challengeToken": {
+                    "type": "string"
+                },
+                "code": {
+                    "description": "kode TOTP atau kode pemulihan",
+                    "type": "string"
+                }
+            }
+        },
+        "models.MFACodeRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MFADisableRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MFAEnrollmentChallengeRequest": {
+            "type": "object",
+            "properties": {
+                "challengeToken": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Pagination": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PermissionRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "contoh: achievement:verify",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ProfileChangeRequest": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "fullName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "previous": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "profileType": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reviewNote": {
+                    "type": "string"
+                },
+                "reviewedAt": {
+                    "type": "string"
+                },
+                "reviewedBy": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ResetPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "newPassword": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ReviewProfileChangeRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RevokeAchievementRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RolePermissionsRequest": {
+            "type": "object",
+            "properties": {
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.RoleRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SelfProfileUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "academicYear": {
+                    "type": "string"
+                },
+                "departmentId": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "fullName": {
+                    "description": "Langsung diterapkan",
+                    "type": "string"
+                },
+                "lecturerId": {
+                    "description": "Butuh persetujuan Admin (profil dosen)",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Alasan perubahan data sensitif",
+                    "type": "string"
+                },
+                "studentId": {
+                    "description": "Butuh persetujuan Admin (profil mahasiswa)",
+                    "type": "string"
+                },
+                "studyProgramId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SelfProfileUpdateResult": {
+            "type": "object",
+            "properties": {
+                "changeRequest": {
+                    "description": "ada jika perubahan sensitif diajukan",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ProfileChangeRequest"
+                        }
+                    ]
                 },
-                "description": {
+                "profile": {
+                    "$ref": "#/definitions/models.UserProfile"
+                }
+            }
+        },
+        "models.SemesterStats": {
+            "type": "object",
+            "properties": {
+                "academicYear": {
                     "type": "string"
                 },
-                "details": {
-                    "type": "object",
-                    "additionalProperties": true
+                "semester": {
+                    "description": "mis. \"2024/2025 Ganjil\"",
+                    "type": "string"
                 },
-                "points": {
+                "term": {
+                    "type": "string"
+                },
+                "totalAchievements": {
                     "type": "integer"
                 },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "verifiedAchievements": {
+                    "type": "integer"
                 },
-                "title": {
-                    "type": "string"
+                "verifiedPoints": {
+                    "type": "integer"
                 }
             }
         },
-        "models.CreateUserRequest": {
+        "models.Student": {
             "type": "object",
             "properties": {
-                "email": {
+                "academicYear": {
                     "type": "string"
                 },
-                "fullName": {
+                "advisorId": {
+                    "description": "Link ke ID tabel Lecturers (bukan User ID)",
                     "type": "string"
                 },
-                "password": {
+                "createdAt": {
                     "type": "string"
                 },
-                "roleName": {
+                "id": {
                     "type": "string"
                 },
-                "username": {
+                "programStudy": {
+                    "type": "string"
+                },
+                "studentId": {
+                    "description": "NIM",
+                    "type": "string"
+                },
+                "studyProgramId": {
+                    "type": "string"
+                },
+                "userId": {
                     "type": "string"
                 }
             }
         },
-        "models.LecturerProfileRequest": {
+        "models.StudentProfileDetail": {
             "type": "object",
             "properties": {
-                "department": {
+                "academicYear": {
                     "type": "string"
                 },
-                "lecturerId": {
-                    "description": "NIP",
+                "advisor": {
+                    "$ref": "#/definitions/models.AdvisorSummary"
+                },
+                "advisorId": {
+                    "description": "Link ke ID tabel Lecturers (bukan User ID)",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "fullName": {
+                    "description": "nama mahasiswa (users.full_name)",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "programStudy": {
+                    "type": "string"
+                },
+                "studentId": {
+                    "description": "NIM",
+                    "type": "string"
+                },
+                "studyProgramId": {
+                    "type": "string"
+                },
+                "userId": {
                     "type": "string"
                 }
             }
         },
-        "models.LoginRequest": {
+        "models.StudentProfileRequest": {
             "type": "object",
             "properties": {
-                "password": {
+                "academicYear": {
                     "type": "string"
                 },
-                "username": {
+                "programStudy": {
+                    "description": "Nama prodi, dipakai jika studyProgramId kosong",
+                    "type": "string"
+                },
+                "studentId": {
+                    "description": "NIM",
+                    "type": "string"
+                },
+                "studyProgramId": {
                     "type": "string"
                 }
             }
         },
-        "models.StudentProfileRequest": {
+        "models.StudentStats": {
             "type": "object",
             "properties": {
                 "academicYear": {
                     "type": "string"
                 },
+                "byStatus": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "byType": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "fullName": {
+                    "type": "string"
+                },
                 "programStudy": {
                     "type": "string"
                 },
                 "studentId": {
                     "description": "NIM",
                     "type": "string"
+                },
+                "timeline": {
+                    "description": "urut kronologis",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SemesterStats"
+                    }
+                },
+                "topAchievements": {
+                    "description": "prestasi terverifikasi dengan poin tertinggi",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AchievementRecord"
+                    }
+                },
+                "totalAchievements": {
+                    "type": "integer"
+                },
+                "totalPoints": {
+                    "description": "hanya prestasi terverifikasi",
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.StudyProgramRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "departmentId": {
+                    "type": "string"
+                },
+                "headUserId": {
+                    "description": "User ID Kaprodi (harus punya profil dosen); kosong = tanpa Kaprodi",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TranscriptVerification": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "issuedAt": {
+                    "type": "string"
+                },
+                "programStudy": {
+                    "type": "string"
+                },
+                "studentName": {
+                    "type": "string"
+                },
+                "studentNumber": {
+                    "description": "NIM",
+                    "type": "string"
+                },
+                "totalAchievements": {
+                    "type": "integer"
+                },
+                "totalPoints": {
+                    "type": "integer"
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.TransferAdviseesRequest": {
+            "type": "object",
+            "properties": {
+                "fromAdvisorUserId": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "toAdvisorUserId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TrendPeriod": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "countGrowth": {
+                    "description": "persen; null jika periode sebelumnya nol",
+                    "type": "number"
+                },
+                "period": {
+                    "description": "mis. \"2025-03\", \"2024/2025 Genap\", \"2024/2025\"",
+                    "type": "string"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "pointsGrowth": {
+                    "description": "persen; null jika periode sebelumnya nol",
+                    "type": "number"
+                },
+                "series": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.TrendValue"
+                    }
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TrendReport": {
+            "type": "object",
+            "properties": {
+                "dateField": {
+                    "type": "string"
+                },
+                "groupBy": {
+                    "type": "string"
+                },
+                "interval": {
+                    "type": "string"
+                },
+                "periods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TrendPeriod"
+                    }
+                }
+            }
+        },
+        "models.TrendValue": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "points": {
+                    "type": "integer"
                 }
             }
         },
@@ -785,10 +5019,114 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UserListItem": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "fullName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "lecturer": {
+                    "$ref": "#/definitions/models.Lecturer"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "roleId": {
+                    "type": "string"
+                },
+                "student": {
+                    "$ref": "#/definitions/models.Student"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UserListResult": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserListItem"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                }
+            }
+        },
+        "models.UserProfile": {
+            "type": "object",
+            "properties": {
+                "fullName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lecturer": {
+                    "$ref": "#/definitions/models.LecturerProfileDetail"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "role": {
+                    "type": "string"
+                },
+                "student": {
+                    "description": "Diisi di GET /auth/profile sesuai profil yang dimiliki user",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.StudentProfileDetail"
+                        }
+                    ]
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
         "utils.JSONResponse": {
             "type": "object",
             "properties": {
                 "data": {},
+                "errors": {
+                    "description": "Detail per field (409/422)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/utils.FieldError"
+                    }
+                },
                 "message": {
                     "type": "string"
                 },
@@ -56,8 +56,13 @@ func main() {
 	app.Use(cors.New())
 	app.Use(logger.New())
 
-	// 4. Setup Routes
-	routes.SetupRoutes(app, pgPool, mongoClient)
+	// 4. Setup Routes & Background Jobs
+	startJobs := routes.SetupRoutes(app, pgPool, mongoClient)
+
+	// Context dibatalkan saat SIGINT/SIGTERM sehingga background job ikut berhenti
+	shutdownCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	startJobs(shutdownCtx)
 
 	// 5. Start Server
 	port := os.Getenv("PORT")
@@ -67,10 +72,7 @@ func main() {
 
 	// Graceful Shutdown Setup
 	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-
-		<-c
+		<-shutdownCtx.Done()
 		log.Println("Gracefully shutting down...")
 
 		if err := app.Shutdown(); err != nil {
@@ -1,12 +1,26 @@
 package middleware
 
 import (
+	"context"
 	"prestasi-mahasiswa-api/utils"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+// SessionValidator memeriksa token terhadap state di server (mis. sesi yang sudah dicabut).
+// Validator boleh memperbarui claims (role & permission terbaru) sebelum RBACRequired dijalankan.
+type SessionValidator interface {
+	ValidateSession(ctx context.Context, claims *utils.JWTCustomClaims) error
+}
+
+var sessionValidator SessionValidator
+
+// SetSessionValidator mendaftarkan validator yang dijalankan AuthRequired setelah token valid
+func SetSessionValidator(validator SessionValidator) {
+	sessionValidator = validator
+}
+
 func AuthRequired(c *fiber.Ctx) error {
 	authHeader := c.Get("Authorization")
 	if authHeader == "" {
@@ -19,6 +33,12 @@ func AuthRequired(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid or expired token")
 	}
 
+	if sessionValidator != nil {
+		if err := sessionValidator.ValidateSession(c.Context(), claims); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Session is no longer valid: "+err.Error())
+		}
+	}
+
 	c.Locals("user", claims)
 	return c.Next()
 }
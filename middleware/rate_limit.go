@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"time"
+
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+)
+
+// RateLimit membatasi jumlah request per IP dalam satu jendela waktu (dipakai untuk endpoint publik)
+func RateLimit(max int, window time.Duration) fiber.Handler {
+	return limiter.New(limiter.Config{
+		Max:        max,
+		Expiration: window,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return c.IP()
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			return utils.ErrorResponse(c, fiber.StatusTooManyRequests, "Too many requests, please try again later")
+		},
+	})
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Department merepresentasikan tabel departments
+type Department struct {
+	ID        uuid.UUID `json:"id"`
+	Code      *string   `json:"code"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// StudyProgram merepresentasikan tabel study_programs
+type StudyProgram struct {
+	ID             uuid.UUID  `json:"id"`
+	DepartmentID   *uuid.UUID `json:"departmentId"`
+	Code           *string    `json:"code"`
+	Name           string     `json:"name"`
+	HeadLecturerID *uuid.UUID `json:"headLecturerId"` // Kaprodi (ID tabel lecturers)
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+// FacultyStaff merepresentasikan tabel faculty_staff
+type FacultyStaff struct {
+	ID           uuid.UUID `json:"id"`
+	UserID       uuid.UUID `json:"userId"`
+	StaffID      string    `json:"staffId"` // NIP/NIK staf
+	DepartmentID uuid.UUID `json:"departmentId"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// Request Payload untuk membuat/mengubah departemen
+type DepartmentRequest struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// Request Payload untuk membuat/mengubah program studi
+type StudyProgramRequest struct {
+	DepartmentID string `json:"departmentId"`
+	Code         string `json:"code"`
+	Name         string `json:"name"`
+	HeadUserID   string `json:"headUserId"` // User ID Kaprodi (harus punya profil dosen); kosong = tanpa Kaprodi
+}
+
+// Request Payload untuk Set Profil Staf Fakultas
+type FacultyStaffProfileRequest struct {
+	StaffID      string `json:"staffId"`
+	DepartmentID string `json:"departmentId"`
+}
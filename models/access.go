@@ -0,0 +1,37 @@
+package models
+
+import "github.com/google/uuid"
+
+// Permission data-scope untuk membaca prestasi (dievaluasi oleh AccessPolicy)
+const (
+	PermissionAchievementReadOwn        = "achievement:read:own"
+	PermissionAchievementReadAdvisees   = "achievement:read:advisees"
+	PermissionAchievementReadProgram    = "achievement:read:program"
+	PermissionAchievementReadDepartment = "achievement:read:department"
+	PermissionAchievementReadAll        = "achievement:read:all"
+
+	// Memverifikasi/menolak pengajuan yang tidak ditugaskan ke dirinya
+	PermissionAchievementVerifyAll = "achievement:verify:all"
+
+	// Melihat & memulihkan trash prestasi milik mahasiswa lain (tanpanya hanya trash milik sendiri)
+	PermissionAchievementDeleteAll = "achievement:delete:all"
+)
+
+// AchievementScope adalah hasil evaluasi permission: semua data, atau hanya milik mahasiswa tertentu
+type AchievementScope struct {
+	All        bool
+	StudentIDs []uuid.UUID // user ID mahasiswa yang boleh dibaca ketika All = false
+}
+
+// Includes mengecek apakah prestasi milik studentID berada dalam scope
+func (s AchievementScope) Includes(studentID uuid.UUID) bool {
+	if s.All {
+		return true
+	}
+	for _, id := range s.StudentIDs {
+		if id == studentID {
+			return true
+		}
+	}
+	return false
+}
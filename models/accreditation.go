@@ -0,0 +1,88 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kategori & tingkat prestasi sesuai tabel LKPS (BAN-PT/LAM)
+const (
+	AccreditationAcademic    = "academic"
+	AccreditationNonAcademic = "non_academic"
+
+	AccreditationLevelRegional      = "regional" // lokal/wilayah
+	AccreditationLevelNational      = "national"
+	AccreditationLevelInternational = "international"
+
+	// Jumlah tahun yang dilaporkan: TS-2, TS-1, TS
+	AccreditationYearSpan = 3
+)
+
+// AccreditationQuery adalah parameter laporan akreditasi
+type AccreditationQuery struct {
+	StudyProgramID *uuid.UUID
+	ReferenceYear  int // TS; default tahun berjalan
+}
+
+// AccreditationRecord adalah prestasi terverifikasi beserta field details yang relevan untuk akreditasi
+type AccreditationRecord struct {
+	RefID           uuid.UUID  `json:"refId"`
+	StudentNumber   string     `json:"studentNumber"`
+	StudentName     string     `json:"studentName"`
+	StudyProgramID  *uuid.UUID `json:"studyProgramId,omitempty"`
+	ProgramStudy    string     `json:"programStudy"`
+	AchievementType string     `json:"achievementType"`
+	Title           string     `json:"title"`
+	Level           string     `json:"level"`    // details.level apa adanya
+	Category        string     `json:"category"` // details.category (opsional, menimpa pemetaan jenis)
+	Rank            string     `json:"rank"`     // details.rank, mis. "Juara 1"
+	EventDate       *time.Time `json:"eventDate,omitempty"`
+	VerifiedAt      *time.Time `json:"verifiedAt,omitempty"`
+}
+
+// AccreditationEntry adalah satu baris tabel prestasi
+type AccreditationEntry struct {
+	RefID           uuid.UUID `json:"refId"`
+	StudentNumber   string    `json:"studentNumber"`
+	StudentName     string    `json:"studentName"`
+	Title           string    `json:"title"`
+	AchievementType string    `json:"achievementType"`
+	Year            int       `json:"year"`
+	Level           string    `json:"level"`
+	Rank            string    `json:"rank"`
+	Reason          string    `json:"reason,omitempty"` // alasan tidak masuk tabel (hanya pada Unmapped)
+}
+
+// AccreditationYearCount adalah rekap jumlah prestasi per tingkat untuk satu tahun
+type AccreditationYearCount struct {
+	Year          int    `json:"year"`
+	Label         string `json:"label"` // TS-2, TS-1, TS
+	Regional      int    `json:"regional"`
+	National      int    `json:"national"`
+	International int    `json:"international"`
+	Total         int    `json:"total"`
+}
+
+// AccreditationTable adalah tabel prestasi akademik atau non-akademik
+type AccreditationTable struct {
+	Category string                   `json:"category"`
+	Summary  []AccreditationYearCount `json:"summary"`
+	Entries  []AccreditationEntry     `json:"entries"`
+}
+
+// AccreditationProgramReport adalah paket tabel untuk satu program studi
+type AccreditationProgramReport struct {
+	StudyProgramID *uuid.UUID           `json:"studyProgramId,omitempty"`
+	ProgramStudy   string               `json:"programStudy"`
+	Academic       AccreditationTable   `json:"academic"`
+	NonAcademic    AccreditationTable   `json:"nonAcademic"`
+	Unmapped       []AccreditationEntry `json:"unmapped"` // prestasi dalam rentang tahun yang tingkatnya tidak dikenali
+}
+
+// AccreditationReport adalah hasil generator laporan akreditasi
+type AccreditationReport struct {
+	ReferenceYear int                          `json:"referenceYear"`
+	Years         []int                        `json:"years"`
+	Programs      []AccreditationProgramReport `json:"programs"`
+}
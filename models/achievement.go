@@ -19,6 +19,7 @@ type AchievementReference struct {
 	CreatedAt          time.Time  `json:"createdAt"`
 	UpdatedAt          time.Time  `json:"updatedAt"`
 	IsDeleted          bool       `json:"isDeleted"`
+	DeletedAt          *time.Time `json:"deletedAt,omitempty"`
 }
 
 // Achievement (MongoDB)
@@ -35,6 +36,7 @@ type Achievement struct {
 	CreatedAt       time.Time              `bson:"createdAt"`
 	UpdatedAt       time.Time              `bson:"updatedAt"`
 	IsDeleted       bool                   `bson:"isDeleted"`
+	DeletedAt       *time.Time             `bson:"deletedAt,omitempty"`
 }
 
 type AttachmentFile struct {
@@ -56,14 +58,39 @@ type CreateAchievementRequest struct {
 
 // Struct untuk Response Detail (Gabungan SQL + Mongo) - INI YANG HILANG SEBELUMNYA
 type AchievementDetailResponse struct {
-	RefID           uuid.UUID              `json:"refId"`
-	Status          string                 `json:"status"`
-	AchievementType string                 `json:"achievementType"`
-	Title           string                 `json:"title"`
-	Description     string                 `json:"description"`
-	Details         map[string]interface{} `json:"details"`
-	Points          int                    `json:"points"`
-	RejectionNote   *string                `json:"rejectionNote,omitempty"`
-	SubmittedAt     *time.Time             `json:"submittedAt,omitempty"`
-	VerifiedAt      *time.Time             `json:"verifiedAt,omitempty"`
-}
\ No newline at end of file
+	RefID            uuid.UUID              `json:"refId"`
+	Status           string                 `json:"status"`
+	AchievementType  string                 `json:"achievementType"`
+	Title            string                 `json:"title"`
+	Description      string                 `json:"description"`
+	Details          map[string]interface{} `json:"details"`
+	Points           int                    `json:"points"`
+	RejectionNote    *string                `json:"rejectionNote,omitempty"`
+	SubmittedAt      *time.Time             `json:"submittedAt,omitempty"`
+	VerifiedAt       *time.Time             `json:"verifiedAt,omitempty"`
+	DeletedAt        *time.Time             `json:"deletedAt,omitempty"`
+	VerificationCode string                 `json:"verificationCode,omitempty"` // kode untuk GET /public/verify/:code
+}
+
+const (
+	VerificationValid    = "valid"
+	VerificationRevoked  = "revoked"
+	VerificationNotFound = "not_found"
+)
+
+// AchievementVerification adalah hasil verifikasi publik; sengaja minim data pribadi
+type AchievementVerification struct {
+	Valid           bool       `json:"valid"`
+	Status          string     `json:"status"` // valid | revoked | not_found
+	StudentName     string     `json:"studentName,omitempty"`
+	Title           string     `json:"title,omitempty"`
+	AchievementType string     `json:"achievementType,omitempty"`
+	VerifiedAt      *time.Time `json:"verifiedAt,omitempty"`
+	VerifierRole    string     `json:"verifierRole,omitempty"`
+	RevokedAt       *time.Time `json:"revokedAt,omitempty"`
+}
+
+// RevokeAchievementRequest adalah body POST /achievements/:id/revoke
+type RevokeAchievementRequest struct {
+	Reason string `json:"reason"`
+}
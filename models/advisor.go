@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdvisorAssignment merepresentasikan satu baris riwayat tabel advisor_assignments
+type AdvisorAssignment struct {
+	ID             uuid.UUID  `json:"id"`
+	StudentUserID  uuid.UUID  `json:"studentUserId"`
+	LecturerID     uuid.UUID  `json:"lecturerId"` // ID tabel lecturers
+	LecturerUserID uuid.UUID  `json:"lecturerUserId"`
+	LecturerNumber string     `json:"lecturerNumber"` // NIP
+	LecturerName   string     `json:"lecturerName"`
+	EffectiveFrom  time.Time  `json:"effectiveFrom"`
+	EffectiveTo    *time.Time `json:"effectiveTo"` // nil = masih berlaku
+	AssignedBy     *uuid.UUID `json:"assignedBy"`
+	Reason         string     `json:"reason"`
+}
+
+// AdviseeFilter memilih mahasiswa untuk penugasan massal (kriteria digabung dengan AND)
+type AdviseeFilter struct {
+	StudyProgramID *uuid.UUID
+	AcademicYear   string
+	AdvisorID      *uuid.UUID // ID tabel lecturers, dipakai untuk transfer
+}
+
+// AdvisorAssignmentResult adalah ringkasan hasil penugasan/transfer dosen wali
+type AdvisorAssignmentResult struct {
+	AdvisorUserID       uuid.UUID   `json:"advisorUserId"`
+	Matched             int         `json:"matched"`
+	Assigned            int         `json:"assigned"`
+	Unchanged           int         `json:"unchanged"` // sudah dibimbing dosen yang sama
+	ReroutedSubmissions int         `json:"reroutedSubmissions"`
+	NotFound            []uuid.UUID `json:"notFound,omitempty"` // user ID tanpa profil mahasiswa
+}
+
+// Request Payload untuk penugasan dosen wali massal
+type BulkAssignAdvisorRequest struct {
+	AdvisorUserID  string   `json:"advisorUserId"`
+	StudentUserIDs []string `json:"studentUserIds"` // Jika diisi, filter lain diabaikan
+	StudyProgramID string   `json:"studyProgramId"`
+	ProgramStudy   string   `json:"programStudy"` // Nama prodi, dipakai jika studyProgramId kosong
+	AcademicYear   string   `json:"academicYear"`
+	Reason         string   `json:"reason"`
+}
+
+// Request Payload untuk memindahkan seluruh mahasiswa bimbingan ke dosen lain
+type TransferAdviseesRequest struct {
+	FromAdvisorUserID string `json:"fromAdvisorUserId"`
+	ToAdvisorUserID   string `json:"toAdvisorUserId"`
+	Reason            string `json:"reason"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClientInfo berisi informasi klien yang melakukan request login
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
+// LoginAttempt merepresentasikan satu percobaan login (tabel login_attempts)
+type LoginAttempt struct {
+	ID            uuid.UUID  `json:"id"`
+	Username      string     `json:"username"`
+	UserID        *uuid.UUID `json:"userId"`
+	IPAddress     string     `json:"ipAddress"`
+	UserAgent     string     `json:"userAgent"`
+	Success       bool       `json:"success"`
+	FailureReason string     `json:"failureReason,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+// LoginFailureSummary ringkasan kegagalan login dalam suatu jangka waktu
+type LoginFailureSummary struct {
+	Count         int
+	LastFailureAt *time.Time
+}
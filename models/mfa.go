@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PermissionMFARequired: user dengan role yang memiliki permission ini wajib memakai 2FA
+const PermissionMFARequired = "mfa:required"
+
+// UserMFA merepresentasikan tabel user_mfa
+type UserMFA struct {
+	UserID             uuid.UUID  `json:"userId"`
+	Secret             string     `json:"-"`
+	Enabled            bool       `json:"enabled"`
+	RecoveryCodeHashes []string   `json:"-"`
+	LastUsedStep       int64      `json:"-"`
+	CreatedAt          time.Time  `json:"createdAt"`
+	EnabledAt          *time.Time `json:"enabledAt"`
+}
+
+// MFASetupResponse dikirim saat enrollment dimulai (secret & URI untuk QR code)
+type MFASetupResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningUri"`
+	QRCode          string `json:"qrCode,omitempty"` // data URI PNG
+}
+
+// MFAEnableResponse berisi kode pemulihan (hanya ditampilkan sekali)
+type MFAEnableResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// MFACodeRequest untuk payload verifikasi kode TOTP
+type MFACodeRequest struct {
+	Code string `json:"code"`
+}
+
+// MFADisableRequest menonaktifkan 2FA (wajib kode TOTP/pemulihan)
+type MFADisableRequest struct {
+	Code string `json:"code"`
+}
+
+// MFAChallengeRequest dipakai pada tahap kedua login
+type MFAChallengeRequest struct {
+	ChallengeToken string `json:"challengeToken"`
+	Code           string `json:"code"` // kode TOTP atau kode pemulihan
+}
+
+// MFAStatus ringkasan status 2FA milik user
+type MFAStatus struct {
+	Enabled  bool `json:"enabled"`
+	Required bool `json:"required"`
+}
+
+// MFAEnrollmentChallengeRequest memulai enrollment 2FA menggunakan challenge token login
+type MFAEnrollmentChallengeRequest struct {
+	ChallengeToken string `json:"challengeToken"`
+}
@@ -0,0 +1,23 @@
+package models
+
+// OIDCAuthRequest menyimpan parameter satu percobaan login SSO.
+// State, nonce, dan PKCE verifier disimpan di cookie browser sampai callback.
+type OIDCAuthRequest struct {
+	AuthURL      string `json:"authUrl"`
+	State        string `json:"-"`
+	Nonce        string `json:"-"`
+	CodeVerifier string `json:"-"`
+}
+
+// OIDCIdentity adalah klaim ID token yang sudah dipetakan ke atribut kampus
+type OIDCIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified *bool
+	FullName      string
+	StudentID     string // NIM
+	LecturerID    string // NIP
+	ProgramStudy  string
+	// MFAByIdP bernilai true jika klaim amr/acr menunjukkan IdP sudah melakukan MFA
+	MFAByIdP bool
+}
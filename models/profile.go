@@ -7,38 +7,75 @@ import (
 
 // Student merepresentasikan tabel students
 type Student struct {
-	ID           uuid.UUID  `json:"id"`
-	UserID       uuid.UUID  `json:"userId"`
-	StudentID    string     `json:"studentId"` // NIM
-	ProgramStudy string     `json:"programStudy"`
-	AcademicYear string     `json:"academicYear"`
-	AdvisorID    *uuid.UUID `json:"advisorId"` // Link ke ID tabel Lecturers (bukan User ID)
-	CreatedAt    time.Time  `json:"createdAt"`
+	ID             uuid.UUID  `json:"id"`
+	UserID         uuid.UUID  `json:"userId"`
+	StudentID      string     `json:"studentId"` // NIM
+	ProgramStudy   string     `json:"programStudy"`
+	StudyProgramID *uuid.UUID `json:"studyProgramId"`
+	AcademicYear   string     `json:"academicYear"`
+	AdvisorID      *uuid.UUID `json:"advisorId"` // Link ke ID tabel Lecturers (bukan User ID)
+	CreatedAt      time.Time  `json:"createdAt"`
 }
 
 // Lecturer merepresentasikan tabel lecturers
 type Lecturer struct {
-	ID         uuid.UUID `json:"id"`
-	UserID     uuid.UUID `json:"userId"`
-	LecturerID string    `json:"lecturerId"` // NIP
-	Department string    `json:"department"`
-	CreatedAt  time.Time `json:"createdAt"`
+	ID           uuid.UUID  `json:"id"`
+	UserID       uuid.UUID  `json:"userId"`
+	LecturerID   string     `json:"lecturerId"` // NIP
+	Department   string     `json:"department"`
+	DepartmentID *uuid.UUID `json:"departmentId"`
+	CreatedAt    time.Time  `json:"createdAt"`
 }
 
 // Request Payload untuk Set Profil Mahasiswa
 type StudentProfileRequest struct {
-	StudentID    string `json:"studentId"`    // NIM
-	ProgramStudy string `json:"programStudy"`
-	AcademicYear string `json:"academicYear"`
+	StudentID      string `json:"studentId"`    // NIM
+	StudyProgramID string `json:"studyProgramId"`
+	ProgramStudy   string `json:"programStudy"` // Nama prodi, dipakai jika studyProgramId kosong
+	AcademicYear   string `json:"academicYear"`
 }
 
 // Request Payload untuk Set Profil Dosen
 type LecturerProfileRequest struct {
-	LecturerID string `json:"lecturerId"` // NIP
-	Department string `json:"department"`
+	LecturerID   string `json:"lecturerId"` // NIP
+	DepartmentID string `json:"departmentId"`
+	Department   string `json:"department"` // Nama departemen, dipakai jika departmentId kosong
 }
 
 // Request Payload untuk Assign Dosen Wali
 type AssignAdvisorRequest struct {
 	AdvisorUserID string `json:"advisorUserId"` // Kita input User ID Dosen, nanti sistem cari Lecturer ID-nya
-}
\ No newline at end of file
+	Reason        string `json:"reason"`        // Dicatat di riwayat penugasan
+}
+// AdvisorSummary adalah info ringkas dosen wali yang ditampilkan bersama profil mahasiswa
+type AdvisorSummary struct {
+	UserID     uuid.UUID `json:"userId"`
+	LecturerID string    `json:"lecturerId"` // NIP
+	FullName   string    `json:"fullName"`
+	Email      string    `json:"email"`
+}
+
+// StudentProfileDetail adalah profil mahasiswa beserta dosen walinya (GET /users/:id/student-profile)
+type StudentProfileDetail struct {
+	Student
+	FullName string          `json:"fullName"` // nama mahasiswa (users.full_name)
+	Advisor  *AdvisorSummary `json:"advisor"`
+}
+
+// LecturerProfileDetail adalah profil dosen beserta jumlah mahasiswa bimbingannya
+type LecturerProfileDetail struct {
+	Lecturer
+	AdviseeCount int `json:"adviseeCount"`
+}
+
+// AdviseeSummary adalah satu baris GET /lecturers/:id/advisees
+type AdviseeSummary struct {
+	UserID         uuid.UUID  `json:"userId"`
+	StudentID      string     `json:"studentId"` // NIM
+	FullName       string     `json:"fullName"`
+	Email          string     `json:"email"`
+	ProgramStudy   string     `json:"programStudy"`
+	StudyProgramID *uuid.UUID `json:"studyProgramId"`
+	AcademicYear   string     `json:"academicYear"`
+	IsActive       bool       `json:"isActive"`
+}
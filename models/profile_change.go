@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ProfileChangePending  = "pending"
+	ProfileChangeApproved = "approved"
+	ProfileChangeRejected = "rejected"
+
+	ProfileTypeStudent  = "student"
+	ProfileTypeLecturer = "lecturer"
+)
+
+// Field yang perubahannya butuh persetujuan Admin (key pada Changes/Previous)
+const (
+	ProfileFieldStudentID      = "studentId"
+	ProfileFieldStudyProgramID = "studyProgramId"
+	ProfileFieldProgramStudy   = "programStudy" // nama prodi, informatif untuk reviewer
+	ProfileFieldAcademicYear   = "academicYear"
+	ProfileFieldLecturerID     = "lecturerId"
+	ProfileFieldDepartmentID   = "departmentId"
+	ProfileFieldDepartment     = "department" // nama departemen, informatif untuk reviewer
+)
+
+// ProfileChangeRequest merepresentasikan tabel profile_change_requests
+type ProfileChangeRequest struct {
+	ID          uuid.UUID         `json:"id"`
+	UserID      uuid.UUID         `json:"userId"`
+	Username    string            `json:"username,omitempty"`
+	FullName    string            `json:"fullName,omitempty"`
+	ProfileType string            `json:"profileType"`
+	Changes     map[string]string `json:"changes"`
+	Previous    map[string]string `json:"previous"`
+	Reason      string            `json:"reason"`
+	Status      string            `json:"status"`
+	ReviewNote  string            `json:"reviewNote"`
+	ReviewedBy  *uuid.UUID        `json:"reviewedBy"`
+	ReviewedAt  *time.Time        `json:"reviewedAt"`
+	CreatedAt   time.Time         `json:"createdAt"`
+}
+
+// ProfileChangeFilter dipakai untuk daftar pengajuan (riwayat user atau antrean Admin)
+type ProfileChangeFilter struct {
+	UserID *uuid.UUID
+	Status string
+}
+
+// Request Payload untuk PUT /auth/profile (hanya field di sini yang boleh diubah sendiri)
+type SelfProfileUpdateRequest struct {
+	// Langsung diterapkan
+	FullName *string `json:"fullName"`
+	Email    *string `json:"email"`
+
+	// Butuh persetujuan Admin (profil mahasiswa)
+	StudentID      *string `json:"studentId"`
+	StudyProgramID *string `json:"studyProgramId"`
+	AcademicYear   *string `json:"academicYear"`
+
+	// Butuh persetujuan Admin (profil dosen)
+	LecturerID   *string `json:"lecturerId"`
+	DepartmentID *string `json:"departmentId"`
+
+	Reason string `json:"reason"` // Alasan perubahan data sensitif
+}
+
+// SelfProfileUpdateResult adalah respons PUT /auth/profile
+type SelfProfileUpdateResult struct {
+	Profile       *UserProfile          `json:"profile"`
+	ChangeRequest *ProfileChangeRequest `json:"changeRequest,omitempty"` // ada jika perubahan sensitif diajukan
+}
+
+// Request Payload untuk approve/reject pengajuan
+type ReviewProfileChangeRequest struct {
+	Note string `json:"note"`
+}
@@ -1,16 +1,197 @@
 package models
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 // DashboardStats merepresentasikan ringkasan statistik untuk dashboard
 type DashboardStats struct {
 	TotalAchievements int            `json:"totalAchievements"`
 	ByStatus          map[string]int `json:"byStatus"`
 	ByType            map[string]int `json:"byType"`
+	ByProgramStudy    map[string]int `json:"byProgramStudy,omitempty"`
+	AsOf              time.Time      `json:"asOf"` // waktu angka dihitung (entri cache tetap membawa waktu aslinya)
+}
+
+// StatsUnknownType menampung prestasi yang dokumen MongoDB-nya tidak ditemukan, agar ByType tetap berjumlah sama dengan total
+const StatsUnknownType = "unknown"
+
+// StatsFilter mempersempit himpunan prestasi yang dihitung statistik (GET /reports/statistics)
+type StatsFilter struct {
+	Statuses       []string
+	From           *time.Time // created_at, inklusif
+	To             *time.Time // created_at, inklusif (sampai akhir hari)
+	StudyProgramID *uuid.UUID
 }
 
-// StudentStats merepresentasikan statistik spesifik mahasiswa
+// IsZero bernilai true jika tidak ada filter (statistik dapat dilayani dari agregat materialized)
+func (f StatsFilter) IsZero() bool {
+	return len(f.Statuses) == 0 && f.From == nil && f.To == nil && f.StudyProgramID == nil
+}
+
+// StatsAggregateRow adalah satu baris agregat materialized (achievement_stats) beserta program studi mahasiswanya
+type StatsAggregateRow struct {
+	StudentID       uuid.UUID
+	ProgramStudy    string
+	Status          string
+	AchievementType string
+	Total           int
+	Points          int
+}
+
+// StudentStats merepresentasikan laporan prestasi seorang mahasiswa (GET /reports/students/:id)
 type StudentStats struct {
-	StudentID         string         `json:"studentId"`
-	TotalPoints       int            `json:"totalPoints"`
-	TotalAchievements int            `json:"totalAchievements"`
-	ByStatus          map[string]int `json:"byStatus"`
-}
\ No newline at end of file
+	UserID            uuid.UUID           `json:"userId"`
+	StudentID         string              `json:"studentId"` // NIM
+	FullName          string              `json:"fullName"`
+	ProgramStudy      string              `json:"programStudy"`
+	AcademicYear      string              `json:"academicYear"`
+	TotalPoints       int                 `json:"totalPoints"` // hanya prestasi terverifikasi
+	TotalAchievements int                 `json:"totalAchievements"`
+	ByStatus          map[string]int      `json:"byStatus"`
+	ByType            map[string]int      `json:"byType"`
+	Timeline          []SemesterStats     `json:"timeline"`        // urut kronologis
+	TopAchievements   []AchievementRecord `json:"topAchievements"` // prestasi terverifikasi dengan poin tertinggi
+}
+
+// SemesterStats adalah satu titik timeline per semester akademik
+type SemesterStats struct {
+	Semester             string `json:"semester"` // mis. "2024/2025 Ganjil"
+	AcademicYear         string `json:"academicYear"`
+	Term                 string `json:"term"`
+	TotalAchievements    int    `json:"totalAchievements"`
+	VerifiedAchievements int    `json:"verifiedAchievements"`
+	VerifiedPoints       int    `json:"verifiedPoints"`
+}
+
+// AchievementRecord menggabungkan referensi PostgreSQL dengan ringkasan dokumen MongoDB untuk keperluan laporan
+type AchievementRecord struct {
+	RefID           uuid.UUID  `json:"refId"`
+	StudentID       uuid.UUID  `json:"studentUserId"`
+	StudentNumber   string     `json:"studentNumber,omitempty"` // NIM
+	StudentName     string     `json:"studentName,omitempty"`
+	ProgramStudy    string     `json:"programStudy,omitempty"`
+	Status          string     `json:"status"`
+	AchievementType string     `json:"achievementType"`
+	Title           string     `json:"title"`
+	Points          int        `json:"points"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	SubmittedAt     *time.Time `json:"submittedAt,omitempty"`
+	VerifiedAt      *time.Time `json:"verifiedAt,omitempty"`
+	VerifierName    string     `json:"verifierName,omitempty"`
+}
+
+// StudentPointTotal adalah akumulasi poin prestasi terverifikasi milik satu mahasiswa
+type StudentPointTotal struct {
+	Points   int `json:"points"`
+	Verified int `json:"verified"`
+}
+
+// AdviseePoints merepresentasikan poin satu mahasiswa bimbingan
+type AdviseePoints struct {
+	UserID               uuid.UUID `json:"userId"`
+	StudentID            string    `json:"studentId"` // NIM
+	FullName             string    `json:"fullName"`
+	TotalPoints          int       `json:"totalPoints"`
+	VerifiedAchievements int       `json:"verifiedAchievements"`
+}
+
+// AdviseeStats merepresentasikan statistik yang dibatasi pada mahasiswa bimbingan seorang dosen wali
+type AdviseeStats struct {
+	AdvisorUserID       uuid.UUID       `json:"advisorUserId"`
+	AdviseeCount        int             `json:"adviseeCount"`
+	TotalAchievements   int             `json:"totalAchievements"`
+	ByStatus            map[string]int  `json:"byStatus"`
+	ByType              map[string]int  `json:"byType"`
+	PendingVerification int             `json:"pendingVerification"` // antrean pengajuan yang menunggu dosen ini
+	Students            []AdviseePoints `json:"students"`            // urut poin tertinggi
+}
+
+const (
+	TrendIntervalMonth    = "month"
+	TrendIntervalSemester = "semester"
+	TrendIntervalYear     = "year" // tahun akademik (Agustus - Juli)
+
+	TrendGroupType         = "type"
+	TrendGroupStatus       = "status"
+	TrendGroupProgramStudy = "programStudy"
+
+	TrendDateSubmitted = "submittedAt"
+	TrendDateVerified  = "verifiedAt"
+)
+
+// TrendQuery adalah parameter GET /reports/trends
+type TrendQuery struct {
+	Interval  string     // month | semester | year
+	GroupBy   string     // kosong | type | status | programStudy
+	DateField string     // submittedAt | verifiedAt
+	From      *time.Time // inklusif
+	To        *time.Time // inklusif (sampai akhir hari)
+}
+
+// TrendRow adalah jumlah prestasi dan total poin untuk satu kombinasi periode, status, prodi, dan tipe
+type TrendRow struct {
+	PeriodStart     time.Time
+	Status          string
+	ProgramStudy    string
+	AchievementType string
+	Count           int
+	Points          int
+}
+
+// TrendValue berisi jumlah prestasi dan poin (hanya prestasi terverifikasi) dalam satu periode
+type TrendValue struct {
+	Count  int `json:"count"`
+	Points int `json:"points"`
+}
+
+// TrendPeriod adalah satu titik deret waktu beserta pertumbuhan terhadap periode sebelumnya
+type TrendPeriod struct {
+	Period       string                `json:"period"` // mis. "2025-03", "2024/2025 Genap", "2024/2025"
+	Start        time.Time             `json:"start"`
+	Count        int                   `json:"count"`
+	Points       int                   `json:"points"`
+	CountGrowth  *float64              `json:"countGrowth"`  // persen; null jika periode sebelumnya nol
+	PointsGrowth *float64              `json:"pointsGrowth"` // persen; null jika periode sebelumnya nol
+	Series       map[string]TrendValue `json:"series,omitempty"`
+}
+
+// TrendReport adalah respons GET /reports/trends
+type TrendReport struct {
+	Interval  string        `json:"interval"`
+	GroupBy   string        `json:"groupBy,omitempty"`
+	DateField string        `json:"dateField"`
+	Periods   []TrendPeriod `json:"periods"`
+}
+
+// LeaderboardQuery adalah parameter GET /reports/leaderboard
+type LeaderboardQuery struct {
+	StudyProgramID  *uuid.UUID
+	AcademicYear    string // angkatan
+	AchievementType string
+	Page            int
+	Limit           int
+	Anonymize       bool // paksa anonim (tampilan untuk mahasiswa/publik)
+}
+
+// LeaderboardEntry adalah satu peringkat mahasiswa berdasarkan poin terverifikasi
+type LeaderboardEntry struct {
+	Rank                 int        `json:"rank"` // nilai sama mendapat peringkat sama (1, 1, 3)
+	UserID               *uuid.UUID `json:"userId,omitempty"`
+	StudentID            string     `json:"studentId"` // NIM (disamarkan jika anonim)
+	FullName             string     `json:"fullName"`
+	ProgramStudy         string     `json:"programStudy"`
+	AcademicYear         string     `json:"academicYear"`
+	TotalPoints          int        `json:"totalPoints"`
+	VerifiedAchievements int        `json:"verifiedAchievements"`
+	Anonymized           bool       `json:"anonymized"`
+	IsCurrentUser        bool       `json:"isCurrentUser,omitempty"`
+}
+
+// LeaderboardResult adalah respons GET /reports/leaderboard
+type LeaderboardResult struct {
+	Items      []LeaderboardEntry `json:"items"`
+	Pagination Pagination         `json:"pagination"`
+}
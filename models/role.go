@@ -0,0 +1,29 @@
+package models
+
+import "github.com/google/uuid"
+
+// Permission merepresentasikan tabel permissions (format nama resource:action)
+type Permission struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Resource    string    `json:"resource"`
+	Action      string    `json:"action"`
+	Description string    `json:"description"`
+}
+
+// RoleRequest untuk membuat/mengubah role
+type RoleRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// PermissionRequest untuk membuat/mengubah permission
+type PermissionRequest struct {
+	Name        string `json:"name"` // contoh: achievement:verify
+	Description string `json:"description"`
+}
+
+// RolePermissionsRequest mengganti seluruh permission milik role (berdasarkan nama)
+type RolePermissionsRequest struct {
+	Permissions []string `json:"permissions"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StudentTranscript merepresentasikan tabel student_transcripts (SKPI yang pernah diterbitkan)
+type StudentTranscript struct {
+	ID                uuid.UUID  `json:"id"`
+	Code              string     `json:"code"`
+	StudentUserID     uuid.UUID  `json:"studentUserId"`
+	IssuedBy          *uuid.UUID `json:"issuedBy,omitempty"`
+	TotalAchievements int        `json:"totalAchievements"`
+	TotalPoints       int        `json:"totalPoints"`
+	IssuedAt          time.Time  `json:"issuedAt"`
+}
+
+// TranscriptVerification adalah hasil verifikasi publik SKPI; hanya memuat data yang tercetak di dokumen
+type TranscriptVerification struct {
+	Valid             bool       `json:"valid"`
+	Code              string     `json:"code"`
+	StudentName       string     `json:"studentName,omitempty"`
+	StudentNumber     string     `json:"studentNumber,omitempty"` // NIM
+	ProgramStudy      string     `json:"programStudy,omitempty"`
+	TotalAchievements int        `json:"totalAchievements,omitempty"`
+	TotalPoints       int        `json:"totalPoints,omitempty"`
+	IssuedAt          *time.Time `json:"issuedAt,omitempty"`
+}
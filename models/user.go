@@ -18,8 +18,16 @@ type User struct {
     IsActive     bool      `json:"isActive"`
     CreatedAt    time.Time `json:"createdAt"`
     UpdatedAt    time.Time `json:"updatedAt"`
+    SessionVersion int `json:"-"` // Naik setiap ganti/reset password; token dengan versi lain tidak berlaku
+    AuthSource   string    `json:"authSource"` // local atau ldap (akun yang terhubung ke direktori)
 }
 
+// Sumber autentikasi password akun (kolom users.auth_source)
+const (
+    AuthSourceLocal = "local"
+    AuthSourceLDAP  = "ldap"
+)
+
 // LoginRequest untuk payload login
 type LoginRequest struct {
     Username string `json:"username"`
@@ -33,13 +41,23 @@ type UserProfile struct {
     FullName    string   `json:"fullName"`
     Role        string   `json:"role"`
     Permissions []string `json:"permissions"`
+
+    // Diisi di GET /auth/profile sesuai profil yang dimiliki user
+    Student  *StudentProfileDetail  `json:"student,omitempty"`
+    Lecturer *LecturerProfileDetail `json:"lecturer,omitempty"`
 }
 
 // LoginData menyimpan token dan profil
 type LoginData struct {
-    Token        string `json:"token"`
-    RefreshToken string `json:"refreshToken"`
+    Token        string `json:"token,omitempty"`
+    RefreshToken string `json:"refreshToken,omitempty"`
     User         UserProfile `json:"user"`
+
+    // Login dua tahap (2FA): token di atas baru diterbitkan setelah challenge diverifikasi
+    MFARequired           bool     `json:"mfaRequired,omitempty"`
+    MFAEnrollmentRequired bool     `json:"mfaEnrollmentRequired,omitempty"`
+    ChallengeToken        string   `json:"challengeToken,omitempty"`
+    RecoveryCodes         []string `json:"recoveryCodes,omitempty"`
 }
 
 // LoginResponse untuk respons sukses login
@@ -54,6 +72,7 @@ type LoginResponse struct {
 type Role struct {
     ID          uuid.UUID `json:"id"`
     Name        string    `json:"name"`
+    Description string    `json:"description"`
     Permissions []string  `json:"permissions"`
 }
 
@@ -72,4 +91,12 @@ type UpdateUserRequest struct {
     Email    *string `json:"email"`
     RoleName *string `json:"roleName"`
     IsActive *bool   `json:"isActive"`
+}
+
+// UserUniqueFields berisi nilai yang wajib unik antar user (string kosong = tidak dicek)
+type UserUniqueFields struct {
+    Username   string // username & email dibandingkan tanpa membedakan huruf besar/kecil
+    Email      string
+    StudentID  string // NIM
+    LecturerID string // NIP
 }
\ No newline at end of file
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Mode import: transactional = semua baris atau tidak sama sekali, partial = baris valid tetap disimpan
+	UserImportModeTransactional = "transactional"
+	UserImportModePartial       = "partial"
+
+	// Kredensial awal: password acak ditampilkan sekali, atau link undangan berbasis token reset password
+	UserImportCredentialPassword = "password"
+	UserImportCredentialInvite   = "invite"
+
+	UserImportStatusValid    = "valid"    // lolos validasi (dry-run)
+	UserImportStatusImported = "imported" // tersimpan
+	UserImportStatusFailed   = "failed"   // gagal validasi atau gagal disimpan
+	UserImportStatusSkipped  = "skipped"  // valid tetapi tidak disimpan karena mode transactional gagal
+)
+
+// UserImportOptions adalah parameter form untuk POST /users/import
+type UserImportOptions struct {
+	Mode       string
+	Credential string
+	DryRun     bool
+}
+
+// UserImportRow adalah satu baris mentah dari file CSV/XLSX
+type UserImportRow struct {
+	Row            int // nomor baris di file (header = 1)
+	Username       string
+	Email          string
+	FullName       string
+	RoleName       string
+	IdentityNumber string // NIM untuk mahasiswa, NIP untuk dosen
+	ProgramStudy   string
+	AcademicYear   string
+	AdvisorNIP     string
+	Department     string
+}
+
+// UserImportRecord adalah baris yang sudah divalidasi dan siap disimpan dalam satu transaksi
+type UserImportRecord struct {
+	Row      int
+	User     *User
+	Student  *Student  // diisi untuk mahasiswa
+	Lecturer *Lecturer // diisi untuk dosen (baris dengan NIP)
+}
+
+// UserImportExisting adalah identitas yang sudah terpakai di database
+type UserImportExisting struct {
+	Usernames map[string]bool
+	Emails    map[string]bool
+	NIMs      map[string]bool
+	NIPs      map[string]bool
+}
+
+// UserImportRowResult adalah hasil per baris di laporan import
+type UserImportRowResult struct {
+	Row             int        `json:"row"`
+	Username        string     `json:"username"`
+	Status          string     `json:"status"`
+	Errors          []string   `json:"errors,omitempty"`
+	UserID          *uuid.UUID `json:"userId,omitempty"`
+	InitialPassword string     `json:"initialPassword,omitempty"` // hanya ditampilkan sekali
+	InviteLink      string     `json:"inviteLink,omitempty"`      // hanya ditampilkan sekali
+	InviteExpiresAt *time.Time `json:"inviteExpiresAt,omitempty"`
+}
+
+// UserImportReport adalah laporan lengkap import (dry-run maupun commit)
+type UserImportReport struct {
+	DryRun       bool                  `json:"dryRun"`
+	Mode         string                `json:"mode"`
+	Credential   string                `json:"credential"`
+	TotalRows    int                   `json:"totalRows"`
+	ValidRows    int                   `json:"validRows"`
+	ImportedRows int                   `json:"importedRows"`
+	FailedRows   int                   `json:"failedRows"`
+	Rows         []UserImportRowResult `json:"rows"`
+}
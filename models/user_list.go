@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kolom yang boleh dipakai untuk sort GET /users
+const (
+	UserSortUsername   = "username"
+	UserSortName       = "name"
+	UserSortEmail      = "email"
+	UserSortRole       = "role"
+	UserSortCreatedAt  = "createdAt"
+	UserSortStudentID  = "studentId"
+	UserSortLecturerID = "lecturerId"
+)
+
+// UserListQuery adalah parameter pencarian, filter, sort, dan halaman untuk GET /users
+type UserListQuery struct {
+	Page           int
+	Limit          int
+	Search         string // nama, username, email, NIM, atau NIP
+	Role           string // nama role
+	IsActive       *bool
+	StudyProgramID *uuid.UUID
+	ProgramStudy   string // nama prodi, dipakai jika StudyProgramID kosong
+	AdvisorUserID  *uuid.UUID
+	SortBy         string
+	SortDesc       bool
+}
+
+// UserListItem adalah satu baris GET /users beserta profil mahasiswa/dosen (jika ada)
+type UserListItem struct {
+	ID        uuid.UUID `json:"id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	FullName  string    `json:"fullName"`
+	RoleID    uuid.UUID `json:"roleId"`
+	Role      string    `json:"role"`
+	IsActive  bool      `json:"isActive"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Student   *Student  `json:"student,omitempty"`
+	Lecturer  *Lecturer `json:"lecturer,omitempty"`
+}
+
+// UserListResult adalah respons GET /users
+type UserListResult struct {
+	Items      []UserListItem `json:"items"`
+	Pagination Pagination     `json:"pagination"`
+}
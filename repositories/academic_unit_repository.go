@@ -0,0 +1,220 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"prestasi-mahasiswa-api/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AcademicUnitRepository mengelola departemen dan program studi
+type AcademicUnitRepository interface {
+	ListDepartments(ctx context.Context) ([]models.Department, error)
+	GetDepartmentByID(ctx context.Context, departmentID uuid.UUID) (*models.Department, error)
+	GetDepartmentByName(ctx context.Context, name string) (*models.Department, error)
+	CreateDepartment(ctx context.Context, department *models.Department) error
+	UpdateDepartment(ctx context.Context, department *models.Department) error
+	DeleteDepartment(ctx context.Context, departmentID uuid.UUID) error
+
+	ListStudyPrograms(ctx context.Context, departmentID *uuid.UUID) ([]models.StudyProgram, error)
+	GetStudyProgramByID(ctx context.Context, programID uuid.UUID) (*models.StudyProgram, error)
+	GetStudyProgramByName(ctx context.Context, name string) (*models.StudyProgram, error)
+	CreateStudyProgram(ctx context.Context, program *models.StudyProgram) error
+	UpdateStudyProgram(ctx context.Context, program *models.StudyProgram) error
+	DeleteStudyProgram(ctx context.Context, programID uuid.UUID) error
+}
+
+type academicUnitRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAcademicUnitRepository(db *pgxpool.Pool) AcademicUnitRepository {
+	return &academicUnitRepository{db: db}
+}
+
+// --- Departments ---
+
+const departmentSelectQuery = `SELECT id, code, name, created_at FROM departments`
+
+func scanDepartment(row pgx.Row) (*models.Department, error) {
+	var d models.Department
+	err := row.Scan(&d.ID, &d.Code, &d.Name, &d.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *academicUnitRepository) ListDepartments(ctx context.Context) ([]models.Department, error) {
+	rows, err := r.db.Query(ctx, departmentSelectQuery+` ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	departments := []models.Department{}
+	for rows.Next() {
+		var d models.Department
+		if err := rows.Scan(&d.ID, &d.Code, &d.Name, &d.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning department: %w", err)
+		}
+		departments = append(departments, d)
+	}
+	return departments, rows.Err()
+}
+
+// GetDepartmentByID mengembalikan nil (tanpa error) jika departemen tidak ditemukan
+func (r *academicUnitRepository) GetDepartmentByID(ctx context.Context, departmentID uuid.UUID) (*models.Department, error) {
+	return scanDepartment(r.db.QueryRow(ctx, departmentSelectQuery+` WHERE id = $1`, departmentID))
+}
+
+// GetDepartmentByName mencocokkan nama tanpa membedakan huruf besar/kecil
+func (r *academicUnitRepository) GetDepartmentByName(ctx context.Context, name string) (*models.Department, error) {
+	return scanDepartment(r.db.QueryRow(ctx, departmentSelectQuery+` WHERE LOWER(name) = LOWER($1)`, name))
+}
+
+func (r *academicUnitRepository) CreateDepartment(ctx context.Context, d *models.Department) error {
+	query := `INSERT INTO departments (id, code, name, created_at) VALUES ($1, $2, $3, NOW()) RETURNING created_at`
+	if err := r.db.QueryRow(ctx, query, d.ID, d.Code, d.Name).Scan(&d.CreatedAt); err != nil {
+		return fmt.Errorf("failed to create department: %w", err)
+	}
+	return nil
+}
+
+// UpdateDepartment ikut menyinkronkan kolom teks lama lecturers.department
+func (r *academicUnitRepository) UpdateDepartment(ctx context.Context, d *models.Department) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `UPDATE departments SET code = $2, name = $3 WHERE id = $1`, d.ID, d.Code, d.Name); err != nil {
+		return fmt.Errorf("failed to update department: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `UPDATE lecturers SET department = $2 WHERE department_id = $1`, d.ID, d.Name); err != nil {
+		return fmt.Errorf("failed to sync lecturer department: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// DeleteDepartment hanya menghapus departemen yang tidak lagi direferensikan
+func (r *academicUnitRepository) DeleteDepartment(ctx context.Context, departmentID uuid.UUID) error {
+	query := `
+		DELETE FROM departments d
+		WHERE d.id = $1
+		  AND NOT EXISTS (SELECT 1 FROM study_programs WHERE department_id = d.id)
+		  AND NOT EXISTS (SELECT 1 FROM lecturers WHERE department_id = d.id)
+		  AND NOT EXISTS (SELECT 1 FROM faculty_staff WHERE department_id = d.id)`
+	cmd, err := r.db.Exec(ctx, query, departmentID)
+	if err != nil {
+		return fmt.Errorf("failed to delete department: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New("department not found or still in use")
+	}
+	return nil
+}
+
+// --- Study Programs ---
+
+const studyProgramSelectQuery = `SELECT id, department_id, code, name, head_lecturer_id, created_at FROM study_programs`
+
+func scanStudyProgram(row pgx.Row) (*models.StudyProgram, error) {
+	var p models.StudyProgram
+	err := row.Scan(&p.ID, &p.DepartmentID, &p.Code, &p.Name, &p.HeadLecturerID, &p.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// ListStudyPrograms mengambil semua prodi, atau hanya prodi milik satu departemen
+func (r *academicUnitRepository) ListStudyPrograms(ctx context.Context, departmentID *uuid.UUID) ([]models.StudyProgram, error) {
+	query := studyProgramSelectQuery
+	args := []interface{}{}
+	if departmentID != nil {
+		query += ` WHERE department_id = $1`
+		args = append(args, *departmentID)
+	}
+	query += ` ORDER BY name`
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	programs := []models.StudyProgram{}
+	for rows.Next() {
+		var p models.StudyProgram
+		if err := rows.Scan(&p.ID, &p.DepartmentID, &p.Code, &p.Name, &p.HeadLecturerID, &p.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning study program: %w", err)
+		}
+		programs = append(programs, p)
+	}
+	return programs, rows.Err()
+}
+
+// GetStudyProgramByID mengembalikan nil (tanpa error) jika prodi tidak ditemukan
+func (r *academicUnitRepository) GetStudyProgramByID(ctx context.Context, programID uuid.UUID) (*models.StudyProgram, error) {
+	return scanStudyProgram(r.db.QueryRow(ctx, studyProgramSelectQuery+` WHERE id = $1`, programID))
+}
+
+// GetStudyProgramByName mencocokkan nama tanpa membedakan huruf besar/kecil
+func (r *academicUnitRepository) GetStudyProgramByName(ctx context.Context, name string) (*models.StudyProgram, error) {
+	return scanStudyProgram(r.db.QueryRow(ctx, studyProgramSelectQuery+` WHERE LOWER(name) = LOWER($1)`, name))
+}
+
+func (r *academicUnitRepository) CreateStudyProgram(ctx context.Context, p *models.StudyProgram) error {
+	query := `
+		INSERT INTO study_programs (id, department_id, code, name, head_lecturer_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		RETURNING created_at`
+	if err := r.db.QueryRow(ctx, query, p.ID, p.DepartmentID, p.Code, p.Name, p.HeadLecturerID).Scan(&p.CreatedAt); err != nil {
+		return fmt.Errorf("failed to create study program: %w", err)
+	}
+	return nil
+}
+
+// UpdateStudyProgram ikut menyinkronkan kolom teks lama students.program_study
+func (r *academicUnitRepository) UpdateStudyProgram(ctx context.Context, p *models.StudyProgram) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE study_programs SET department_id = $2, code = $3, name = $4, head_lecturer_id = $5 WHERE id = $1`
+	if _, err := tx.Exec(ctx, query, p.ID, p.DepartmentID, p.Code, p.Name, p.HeadLecturerID); err != nil {
+		return fmt.Errorf("failed to update study program: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `UPDATE students SET program_study = $2 WHERE study_program_id = $1`, p.ID, p.Name); err != nil {
+		return fmt.Errorf("failed to sync student program: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// DeleteStudyProgram hanya menghapus prodi yang tidak memiliki mahasiswa
+func (r *academicUnitRepository) DeleteStudyProgram(ctx context.Context, programID uuid.UUID) error {
+	query := `DELETE FROM study_programs sp WHERE sp.id = $1 AND NOT EXISTS (SELECT 1 FROM students WHERE study_program_id = sp.id)`
+	cmd, err := r.db.Exec(ctx, query, programID)
+	if err != nil {
+		return fmt.Errorf("failed to delete study program: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New("study program not found or still has students")
+	}
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"prestasi-mahasiswa-api/models"
@@ -14,6 +16,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 const (
@@ -27,13 +30,31 @@ type AchievementRepository interface {
 	UpdateReferenceStatus(ctx context.Context, refID uuid.UUID, currentStatus string, newStatus string, rejectionNote string, verifiedBy uuid.UUID) (*models.AchievementReference, error)
 	GetAchievementDetail(ctx context.Context, mongoID string) (*models.Achievement, error)
 	GetReferenceByID(ctx context.Context, refID uuid.UUID) (*models.AchievementReference, error)
+	GetAssignedAdvisorUserID(ctx context.Context, refID uuid.UUID) (*uuid.UUID, error)
 	UpdateAchievement(ctx context.Context, mongoID string, update interface{}) error
 	UpdateReferenceUpdatedAt(ctx context.Context, refID uuid.UUID) (*models.AchievementReference, error)
-	ListAchievementReferences(ctx context.Context, studentIDs []uuid.UUID) ([]models.AchievementReference, error)
-	GetStatsByStatus(ctx context.Context, studentID *uuid.UUID) (map[string]int, error)
-	GetStatsByType(ctx context.Context, studentID *uuid.UUID) (map[string]int, error)
+	ListAchievementReferences(ctx context.Context, scope models.AchievementScope) ([]models.AchievementReference, error)
+	GetVerifiedPointsByStudent(ctx context.Context, studentIDs []uuid.UUID) (map[uuid.UUID]models.StudentPointTotal, error)
+	CountPendingVerification(ctx context.Context, advisorUserID uuid.UUID) (int, error)
+	ListAchievementRecords(ctx context.Context, scope models.AchievementScope) ([]models.AchievementRecord, error)
+	EachAchievementRecord(ctx context.Context, scope models.AchievementScope, fn func(models.AchievementRecord) error) error
+	ListTrendRows(ctx context.Context, scope models.AchievementScope, query models.TrendQuery) ([]models.TrendRow, error)
+	ListLeaderboardEntries(ctx context.Context, query models.LeaderboardQuery) ([]models.LeaderboardEntry, error)
+	ListAccreditationRecords(ctx context.Context, scope models.AchievementScope, studyProgramID *uuid.UUID) ([]models.AccreditationRecord, error)
 	// NEW: Hard Delete
-	HardDeleteAchievement(ctx context.Context, refID uuid.UUID) error
+	HardDeleteAchievement(ctx context.Context, refID uuid.UUID) ([]models.AttachmentFile, error)
+
+	// Trash (soft-deleted achievements)
+	ListTrashedReferences(ctx context.Context, scope models.AchievementScope) ([]models.AchievementReference, error)
+	GetTrashedReferenceByID(ctx context.Context, refID uuid.UUID) (*models.AchievementReference, error)
+	GetAchievementDetailWithDeleted(ctx context.Context, mongoID string) (*models.Achievement, error)
+	RestoreAchievementAndReference(ctx context.Context, refID uuid.UUID) (*models.AchievementReference, error)
+	ListExpiredTrashReferenceIDs(ctx context.Context, deletedBefore time.Time) ([]uuid.UUID, error)
+
+	// Verifikasi publik
+	RevokeVerification(ctx context.Context, refID uuid.UUID, revokedBy uuid.UUID, reason string) (*models.AchievementReference, error)
+	GetActiveVerificationCode(ctx context.Context, refID uuid.UUID) (string, error)
+	GetPublicVerification(ctx context.Context, code string) (*models.AchievementVerification, error)
 }
 
 type achievementRepository struct {
@@ -106,12 +127,12 @@ func (r *achievementRepository) SoftDeleteAchievementAndReference(ctx context.Co
 
 	objID, _ := primitive.ObjectIDFromHex(mongoID)
 	mongoColl := r.mongoClient.Database(MongoDatabaseName).Collection(MongoCollectionAchievements)
-	_, err = mongoColl.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": bson.M{"isDeleted": true}})
+	_, err = mongoColl.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": bson.M{"isDeleted": true, "deletedAt": time.Now()}})
 	if err != nil {
 		return err
 	}
 
-	_, err = r.pgDB.Exec(ctx, "UPDATE achievement_references SET is_deleted = true, updated_at = NOW() WHERE id = $1", achievementRefID)
+	_, err = r.pgDB.Exec(ctx, "UPDATE achievement_references SET is_deleted = true, deleted_at = NOW(), updated_at = NOW() WHERE id = $1", achievementRefID)
 	return err
 }
 
@@ -126,7 +147,8 @@ func (r *achievementRepository) UpdateReferenceStatus(ctx context.Context, refID
 	
 	// Perbaikan S1039: Hapus fmt.Sprintf jika tidak ada format verbs (%s, %d, dll)
 	if newStatus == "submitted" {
-		query += ", submitted_at = NOW()"
+		// Pengajuan diarahkan ke dosen wali aktif saat submit
+		query += ", submitted_at = NOW(), assigned_advisor_id = (SELECT s.advisor_id FROM students s WHERE s.user_id = achievement_references.student_id)"
 	}
 	if newStatus == "verified" {
 		query += fmt.Sprintf(", verified_at = NOW(), verified_by = $%d", argID)
@@ -141,6 +163,13 @@ func (r *achievementRepository) UpdateReferenceStatus(ctx context.Context, refID
 	
 	query += fmt.Sprintf(" WHERE id = $%d AND status = $%d RETURNING id, student_id, mongo_achievement_id, status, submitted_at, verified_at, verified_by, rejection_note", argID, argID+1)
 	args = append(args, refID, currentStatus)
+	if newStatus == "verified" {
+		// Kode verifikasi publik diterbitkan dalam statement yang sama dengan perubahan status
+		query = `WITH updated AS (` + query + `), issued AS (
+			INSERT INTO achievement_verification_codes (code, achievement_ref_id)
+			SELECT replace(gen_random_uuid()::text, '-', ''), id FROM updated
+		) SELECT * FROM updated`
+	}
 
 	err := r.pgDB.QueryRow(ctx, query, args...).Scan(
 		&ref.ID, &ref.StudentID, &ref.MongoAchievementID, &ref.Status, 
@@ -153,6 +182,24 @@ func (r *achievementRepository) UpdateReferenceStatus(ctx context.Context, refID
 	return &ref, err
 }
 
+// GetAssignedAdvisorUserID mengembalikan user ID dosen yang bertugas memverifikasi pengajuan.
+// Jika pengajuan belum punya penugasan, dipakai dosen wali aktif mahasiswa. nil = tidak ada dosen.
+func (r *achievementRepository) GetAssignedAdvisorUserID(ctx context.Context, refID uuid.UUID) (*uuid.UUID, error) {
+	query := `
+		SELECT l.user_id
+		FROM achievement_references ar
+		LEFT JOIN students s ON s.user_id = ar.student_id
+		LEFT JOIN lecturers l ON l.id = COALESCE(ar.assigned_advisor_id, s.advisor_id)
+		WHERE ar.id = $1 AND ar.is_deleted = FALSE`
+
+	var advisorUserID *uuid.UUID
+	err := r.pgDB.QueryRow(ctx, query, refID).Scan(&advisorUserID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return advisorUserID, err
+}
+
 // UpdateAchievement
 func (r *achievementRepository) UpdateAchievement(ctx context.Context, mongoID string, update interface{}) error {
 	objID, _ := primitive.ObjectIDFromHex(mongoID)
@@ -192,13 +239,14 @@ func (r *achievementRepository) GetAchievementDetail(ctx context.Context, mongoI
 }
 
 // ListAchievementReferences
-func (r *achievementRepository) ListAchievementReferences(ctx context.Context, studentIDs []uuid.UUID) ([]models.AchievementReference, error) {
+func (r *achievementRepository) ListAchievementReferences(ctx context.Context, scope models.AchievementScope) ([]models.AchievementReference, error) {
 	query := `SELECT id, student_id, mongo_achievement_id, status, submitted_at, verified_at, verified_by, rejection_note FROM achievement_references WHERE is_deleted = FALSE`
 	args := []interface{}{}
 	
-	if len(studentIDs) > 0 {
+	// Scope terbatas dengan daftar kosong menghasilkan nol baris, bukan seluruh data
+	if !scope.All {
 		query += fmt.Sprintf(" AND student_id = ANY($%d)", 1)
-		args = append(args, studentIDs)
+		args = append(args, scope.StudentIDs)
 	}
 
 	rows, err := r.pgDB.Query(ctx, query, args...)
@@ -221,17 +269,241 @@ func (r *achievementRepository) ListAchievementReferences(ctx context.Context, s
 	return achievements, nil
 }
 
-// GetStatsByStatus menghitung jumlah prestasi berdasarkan status dari PostgreSQL
-func (r *achievementRepository) GetStatsByStatus(ctx context.Context, studentID *uuid.UUID) (map[string]int, error) {
-	query := `SELECT status, COUNT(*) FROM achievement_references WHERE is_deleted = FALSE`
+// GetVerifiedPointsByStudent menjumlahkan poin prestasi terverifikasi per mahasiswa.
+// Status ada di PostgreSQL sedangkan poin di MongoDB, jadi ID dokumen diambil dulu dari PostgreSQL.
+func (r *achievementRepository) GetVerifiedPointsByStudent(ctx context.Context, studentIDs []uuid.UUID) (map[uuid.UUID]models.StudentPointTotal, error) {
+	totals := make(map[uuid.UUID]models.StudentPointTotal)
+	if len(studentIDs) == 0 {
+		return totals, nil
+	}
+
+	rows, err := r.pgDB.Query(ctx, `
+		SELECT mongo_achievement_id FROM achievement_references
+		WHERE is_deleted = FALSE AND status = 'verified' AND student_id = ANY($1)`, studentIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	objIDs := []primitive.ObjectID{}
+	for rows.Next() {
+		var mongoID string
+		if err := rows.Scan(&mongoID); err != nil {
+			return nil, err
+		}
+		if objID, err := primitive.ObjectIDFromHex(mongoID); err == nil {
+			objIDs = append(objIDs, objID)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(objIDs) == 0 {
+		return totals, nil
+	}
+
+	coll := r.mongoClient.Database(MongoDatabaseName).Collection(MongoCollectionAchievements)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"_id": bson.M{"$in": objIDs}, "isDeleted": false}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":    "$studentId",
+			"points": bson.M{"$sum": "$points"},
+			"count":  bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		ID     uuid.UUID `bson:"_id"`
+		Points int       `bson:"points"`
+		Count  int       `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	for _, res := range results {
+		totals[res.ID] = models.StudentPointTotal{Points: res.Points, Verified: res.Count}
+	}
+	return totals, nil
+}
+
+// CountPendingVerification menghitung pengajuan (submitted) yang menunggu verifikasi dosen tersebut
+func (r *achievementRepository) CountPendingVerification(ctx context.Context, advisorUserID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM achievement_references ar
+		LEFT JOIN students s ON s.user_id = ar.student_id
+		JOIN lecturers l ON l.id = COALESCE(ar.assigned_advisor_id, s.advisor_id)
+		WHERE ar.is_deleted = FALSE AND ar.status = 'submitted' AND l.user_id = $1`
+
+	var count int
+	err := r.pgDB.QueryRow(ctx, query, advisorUserID).Scan(&count)
+	return count, err
+}
+
+// ListAchievementRecords mengambil prestasi (tidak terhapus) dalam scope beserta judul, tipe, dan poinnya dari MongoDB
+func (r *achievementRepository) ListAchievementRecords(ctx context.Context, scope models.AchievementScope) ([]models.AchievementRecord, error) {
+	records := []models.AchievementRecord{}
+	err := r.EachAchievementRecord(ctx, scope, func(rec models.AchievementRecord) error {
+		records = append(records, rec)
+		return nil
+	})
+	return records, err
+}
+
+// Jumlah referensi per pengambilan dokumen MongoDB saat mengalirkan data ekspor
+const achievementRecordBatchSize = 500
+
+// EachAchievementRecord mengalirkan prestasi dalam scope (urut tanggal dibuat) per batch,
+// sehingga ekspor data besar tidak perlu menampung seluruh baris di memori.
+func (r *achievementRepository) EachAchievementRecord(ctx context.Context, scope models.AchievementScope, fn func(models.AchievementRecord) error) error {
+	query := `
+		SELECT ar.id, ar.student_id, COALESCE(s.student_id, ''), COALESCE(u.full_name, ''), COALESCE(s.program_study, ''),
+		       ar.mongo_achievement_id, ar.status, ar.created_at, ar.submitted_at, ar.verified_at, COALESCE(vu.full_name, '')
+		FROM achievement_references ar
+		LEFT JOIN users u ON u.id = ar.student_id
+		LEFT JOIN students s ON s.user_id = ar.student_id
+		LEFT JOIN users vu ON vu.id = ar.verified_by
+		WHERE ar.is_deleted = FALSE`
 	args := []interface{}{}
-	
-	if studentID != nil {
-		query += ` AND student_id = $1`
-		args = append(args, *studentID)
+	if !scope.All {
+		query += ` AND ar.student_id = ANY($1)`
+		args = append(args, scope.StudentIDs)
 	}
-	
-	query += ` GROUP BY status`
+	query += ` ORDER BY ar.created_at, ar.id`
+
+	rows, err := r.pgDB.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	batch := make([]models.AchievementRecord, 0, achievementRecordBatchSize)
+	mongoIDs := make([]string, 0, achievementRecordBatchSize)
+	for rows.Next() {
+		var rec models.AchievementRecord
+		var mongoID string
+		if err := rows.Scan(&rec.RefID, &rec.StudentID, &rec.StudentNumber, &rec.StudentName, &rec.ProgramStudy,
+			&mongoID, &rec.Status, &rec.CreatedAt, &rec.SubmittedAt, &rec.VerifiedAt, &rec.VerifierName); err != nil {
+			return fmt.Errorf("error scanning achievement reference: %w", err)
+		}
+		batch = append(batch, rec)
+		mongoIDs = append(mongoIDs, mongoID)
+
+		if len(batch) == achievementRecordBatchSize {
+			if err := r.emitRecordBatch(ctx, batch, mongoIDs, fn); err != nil {
+				return err
+			}
+			batch, mongoIDs = batch[:0], mongoIDs[:0]
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return r.emitRecordBatch(ctx, batch, mongoIDs, fn)
+}
+
+// emitRecordBatch melengkapi satu batch dengan data MongoDB lalu meneruskannya ke fn sesuai urutan
+func (r *achievementRepository) emitRecordBatch(ctx context.Context, batch []models.AchievementRecord, mongoIDs []string, fn func(models.AchievementRecord) error) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	indexByMongoID := make(map[primitive.ObjectID]int, len(batch))
+	objIDs := make([]primitive.ObjectID, 0, len(batch))
+	for i, mongoID := range mongoIDs {
+		if objID, err := primitive.ObjectIDFromHex(mongoID); err == nil {
+			indexByMongoID[objID] = i
+			objIDs = append(objIDs, objID)
+		}
+	}
+
+	if len(objIDs) > 0 {
+		coll := r.mongoClient.Database(MongoDatabaseName).Collection(MongoCollectionAchievements)
+		projection := bson.M{"achievementType": 1, "title": 1, "points": 1}
+		cursor, err := coll.Find(ctx, bson.M{"_id": bson.M{"$in": objIDs}}, options.Find().SetProjection(projection))
+		if err != nil {
+			return err
+		}
+		var docs []struct {
+			ID              primitive.ObjectID `bson:"_id"`
+			AchievementType string             `bson:"achievementType"`
+			Title           string             `bson:"title"`
+			Points          int                `bson:"points"`
+		}
+		err = cursor.All(ctx, &docs)
+		if err != nil {
+			return err
+		}
+		for _, doc := range docs {
+			rec := &batch[indexByMongoID[doc.ID]]
+			rec.AchievementType, rec.Title, rec.Points = doc.AchievementType, doc.Title, doc.Points
+		}
+	}
+
+	for _, rec := range batch {
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Kolom tanggal yang boleh dipakai untuk deret waktu (whitelist, bukan input mentah)
+var trendDateColumns = map[string]string{
+	models.TrendDateSubmitted: "ar.submitted_at",
+	models.TrendDateVerified:  "ar.verified_at",
+}
+
+// trendBucketExpr mengembalikan ekspresi SQL awal periode; semester & tahun akademik dimulai Agustus/Februari
+func trendBucketExpr(interval string, column string) string {
+	year := "EXTRACT(YEAR FROM " + column + ")::int"
+	month := "EXTRACT(MONTH FROM " + column + ")"
+	switch interval {
+	case models.TrendIntervalSemester:
+		return "CASE WHEN " + month + " >= 8 THEN make_date(" + year + ", 8, 1)" +
+			" WHEN " + month + " = 1 THEN make_date(" + year + " - 1, 8, 1)" +
+			" ELSE make_date(" + year + ", 2, 1) END::timestamp"
+	case models.TrendIntervalYear:
+		return "CASE WHEN " + month + " >= 8 THEN make_date(" + year + ", 8, 1)" +
+			" ELSE make_date(" + year + " - 1, 8, 1) END::timestamp"
+	default:
+		return "date_trunc('month', " + column + ")"
+	}
+}
+
+// ListTrendRows menentukan periode (serta status & prodi) tiap prestasi di PostgreSQL, lalu menjumlahkan
+// jumlah & poin per periode dan tipe dengan $group di MongoDB. Hasil diurutkan menurut awal periode.
+func (r *achievementRepository) ListTrendRows(ctx context.Context, scope models.AchievementScope, q models.TrendQuery) ([]models.TrendRow, error) {
+	column, ok := trendDateColumns[q.DateField]
+	if !ok {
+		return nil, fmt.Errorf("unsupported trend date field: %s", q.DateField)
+	}
+
+	query := `
+		SELECT ` + trendBucketExpr(q.Interval, column) + `, ar.status, COALESCE(s.program_study, ''), ar.mongo_achievement_id
+		FROM achievement_references ar
+		LEFT JOIN students s ON s.user_id = ar.student_id
+		WHERE ar.is_deleted = FALSE AND ` + column + ` IS NOT NULL`
+	args := []interface{}{}
+	if !scope.All {
+		args = append(args, scope.StudentIDs)
+		query += fmt.Sprintf(" AND ar.student_id = ANY($%d)", len(args))
+	}
+	if q.From != nil {
+		args = append(args, *q.From)
+		query += fmt.Sprintf(" AND %s >= $%d", column, len(args))
+	}
+	if q.To != nil {
+		args = append(args, *q.To)
+		query += fmt.Sprintf(" AND %s <= $%d", column, len(args))
+	}
+	query += ` ORDER BY 1`
 
 	rows, err := r.pgDB.Query(ctx, query, args...)
 	if err != nil {
@@ -239,71 +511,512 @@ func (r *achievementRepository) GetStatsByStatus(ctx context.Context, studentID
 	}
 	defer rows.Close()
 
-	stats := make(map[string]int)
+	// Bucket = kombinasi periode, status, dan prodi; objIDs[i] termasuk bucket bucketOf[i]
+	type trendBucket struct {
+		PeriodStart  time.Time
+		Status       string
+		ProgramStudy string
+	}
+	buckets := []trendBucket{}
+	unmatched := []int{} // jumlah prestasi per bucket yang belum terhitung di MongoDB
+	bucketIndex := make(map[trendBucket]int)
+	seen := make(map[primitive.ObjectID]bool)
+	objIDs := []primitive.ObjectID{}
+	bucketOf := []int{}
 	for rows.Next() {
-		var status string
-		var count int
-		if err := rows.Scan(&status, &count); err != nil {
+		var b trendBucket
+		var mongoID string
+		if err := rows.Scan(&b.PeriodStart, &b.Status, &b.ProgramStudy, &mongoID); err != nil {
 			return nil, err
 		}
-		stats[status] = count
+		idx, ok := bucketIndex[b]
+		if !ok {
+			idx = len(buckets)
+			bucketIndex[b] = idx
+			buckets = append(buckets, b)
+			unmatched = append(unmatched, 0)
+		}
+		unmatched[idx]++
+		if objID, err := primitive.ObjectIDFromHex(mongoID); err == nil && !seen[objID] {
+			seen[objID] = true
+			objIDs = append(objIDs, objID)
+			bucketOf = append(bucketOf, idx)
+		}
 	}
-	return stats, nil
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	trendRows := []models.TrendRow{}
+	if len(objIDs) > 0 {
+		coll := r.mongoClient.Database(MongoDatabaseName).Collection(MongoCollectionAchievements)
+		pipeline := mongo.Pipeline{
+			{{Key: "$match", Value: bson.M{"_id": bson.M{"$in": objIDs}}}},
+			{{Key: "$group", Value: bson.M{
+				"_id": bson.M{
+					"bucket": bson.M{"$arrayElemAt": bson.A{bucketOf, bson.M{"$indexOfArray": bson.A{objIDs, "$_id"}}}},
+					"type":   "$achievementType",
+				},
+				"count":  bson.M{"$sum": 1},
+				"points": bson.M{"$sum": "$points"},
+			}}},
+		}
+		cursor, err := coll.Aggregate(ctx, pipeline)
+		if err != nil {
+			return nil, err
+		}
+		defer cursor.Close(ctx)
+
+		var results []struct {
+			ID struct {
+				Bucket int    `bson:"bucket"`
+				Type   string `bson:"type"`
+			} `bson:"_id"`
+			Count  int `bson:"count"`
+			Points int `bson:"points"`
+		}
+		if err := cursor.All(ctx, &results); err != nil {
+			return nil, err
+		}
+		for _, res := range results {
+			if res.ID.Bucket < 0 || res.ID.Bucket >= len(buckets) {
+				continue
+			}
+			b := buckets[res.ID.Bucket]
+			unmatched[res.ID.Bucket] -= res.Count
+			trendRows = append(trendRows, models.TrendRow{
+				PeriodStart: b.PeriodStart, Status: b.Status, ProgramStudy: b.ProgramStudy,
+				AchievementType: res.ID.Type, Count: res.Count, Points: res.Points,
+			})
+		}
+	}
+
+	// Referensi tanpa dokumen MongoDB tetap dihitung (tanpa tipe & poin)
+	for i, b := range buckets {
+		if unmatched[i] > 0 {
+			trendRows = append(trendRows, models.TrendRow{
+				PeriodStart: b.PeriodStart, Status: b.Status, ProgramStudy: b.ProgramStudy, Count: unmatched[i],
+			})
+		}
+	}
+
+	sort.SliceStable(trendRows, func(i, j int) bool { return trendRows[i].PeriodStart.Before(trendRows[j].PeriodStart) })
+	return trendRows, nil
 }
 
-// GetStatsByType menghitung jumlah prestasi berdasarkan tipe dari MongoDB
-func (r *achievementRepository) GetStatsByType(ctx context.Context, studentID *uuid.UUID) (map[string]int, error) {
-	coll := r.mongoClient.Database(MongoDatabaseName).Collection(MongoCollectionAchievements)
-	
-	matchStage := bson.M{"isDeleted": false}
-	if studentID != nil {
-		matchStage["studentId"] = *studentID
+// ListLeaderboardEntries menjumlahkan poin prestasi terverifikasi per mahasiswa (belum diurutkan/diberi peringkat).
+// Filter prodi & angkatan diterapkan di PostgreSQL, filter tipe di MongoDB.
+func (r *achievementRepository) ListLeaderboardEntries(ctx context.Context, q models.LeaderboardQuery) ([]models.LeaderboardEntry, error) {
+	query := `
+		SELECT ar.student_id, ar.mongo_achievement_id, COALESCE(s.student_id, ''), u.full_name,
+		       COALESCE(s.program_study, ''), COALESCE(s.academic_year, '')
+		FROM achievement_references ar
+		JOIN users u ON u.id = ar.student_id
+		LEFT JOIN students s ON s.user_id = ar.student_id
+		WHERE ar.is_deleted = FALSE AND ar.status = 'verified'`
+	args := []interface{}{}
+	if q.StudyProgramID != nil {
+		args = append(args, *q.StudyProgramID)
+		query += fmt.Sprintf(" AND s.study_program_id = $%d", len(args))
 	}
+	if q.AcademicYear != "" {
+		args = append(args, q.AcademicYear)
+		query += fmt.Sprintf(" AND s.academic_year = $%d", len(args))
+	}
+
+	rows, err := r.pgDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
+	students := make(map[uuid.UUID]*models.LeaderboardEntry)
+	objIDs := []primitive.ObjectID{}
+	for rows.Next() {
+		var userID uuid.UUID
+		var mongoID string
+		var entry models.LeaderboardEntry
+		if err := rows.Scan(&userID, &mongoID, &entry.StudentID, &entry.FullName, &entry.ProgramStudy, &entry.AcademicYear); err != nil {
+			return nil, err
+		}
+		if _, ok := students[userID]; !ok {
+			entry.UserID = &userID
+			students[userID] = &entry
+		}
+		if objID, err := primitive.ObjectIDFromHex(mongoID); err == nil {
+			objIDs = append(objIDs, objID)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	entries := []models.LeaderboardEntry{}
+	if len(objIDs) == 0 {
+		return entries, nil
+	}
+
+	match := bson.M{"_id": bson.M{"$in": objIDs}, "isDeleted": false}
+	if q.AchievementType != "" {
+		match["achievementType"] = q.AchievementType
+	}
+	coll := r.mongoClient.Database(MongoDatabaseName).Collection(MongoCollectionAchievements)
 	pipeline := mongo.Pipeline{
-		{{Key: "$match", Value: matchStage}},
+		{{Key: "$match", Value: match}},
 		{{Key: "$group", Value: bson.M{
-			"_id":   "$achievementType",
-			"count": bson.M{"$sum": 1},
+			"_id":    "$studentId",
+			"points": bson.M{"$sum": "$points"},
+			"count":  bson.M{"$sum": 1},
 		}}},
 	}
-
 	cursor, err := coll.Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
-	stats := make(map[string]int)
 	var results []struct {
-		ID    string `bson:"_id"`
-		Count int    `bson:"count"`
+		ID     uuid.UUID `bson:"_id"`
+		Points int       `bson:"points"`
+		Count  int       `bson:"count"`
 	}
-
 	if err := cursor.All(ctx, &results); err != nil {
 		return nil, err
 	}
-
 	for _, res := range results {
-		stats[res.ID] = res.Count
+		entry, ok := students[res.ID]
+		if !ok {
+			continue
+		}
+		entry.TotalPoints, entry.VerifiedAchievements = res.Points, res.Count
+		entries = append(entries, *entry)
 	}
-	return stats, nil
+	return entries, nil
 }
 
-func (r *achievementRepository) HardDeleteAchievement(ctx context.Context, refID uuid.UUID) error {
+// ListAccreditationRecords mengambil prestasi terverifikasi beserta details.level/category/rank/eventDate
+func (r *achievementRepository) ListAccreditationRecords(ctx context.Context, scope models.AchievementScope, studyProgramID *uuid.UUID) ([]models.AccreditationRecord, error) {
+	query := `
+		SELECT ar.id, ar.mongo_achievement_id, COALESCE(s.student_id, ''), COALESCE(u.full_name, ''),
+		       s.study_program_id, COALESCE(sp.name, s.program_study, ''), ar.verified_at
+		FROM achievement_references ar
+		LEFT JOIN users u ON u.id = ar.student_id
+		LEFT JOIN students s ON s.user_id = ar.student_id
+		LEFT JOIN study_programs sp ON sp.id = s.study_program_id
+		WHERE ar.is_deleted = FALSE AND ar.status = 'verified'`
+	args := []interface{}{}
+	if !scope.All {
+		args = append(args, scope.StudentIDs)
+		query += fmt.Sprintf(" AND ar.student_id = ANY($%d)", len(args))
+	}
+	if studyProgramID != nil {
+		args = append(args, *studyProgramID)
+		query += fmt.Sprintf(" AND s.study_program_id = $%d", len(args))
+	}
+
+	rows, err := r.pgDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := []models.AccreditationRecord{}
+	indexByMongoID := make(map[primitive.ObjectID]int)
+	objIDs := []primitive.ObjectID{}
+	for rows.Next() {
+		var rec models.AccreditationRecord
+		var mongoID string
+		if err := rows.Scan(&rec.RefID, &mongoID, &rec.StudentNumber, &rec.StudentName,
+			&rec.StudyProgramID, &rec.ProgramStudy, &rec.VerifiedAt); err != nil {
+			return nil, fmt.Errorf("error scanning achievement reference: %w", err)
+		}
+		if objID, err := primitive.ObjectIDFromHex(mongoID); err == nil {
+			indexByMongoID[objID] = len(records)
+			objIDs = append(objIDs, objID)
+		}
+		records = append(records, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(objIDs) == 0 {
+		return records, nil
+	}
+
+	coll := r.mongoClient.Database(MongoDatabaseName).Collection(MongoCollectionAchievements)
+	projection := bson.M{"achievementType": 1, "title": 1, "details": 1}
+	cursor, err := coll.Find(ctx, bson.M{"_id": bson.M{"$in": objIDs}, "isDeleted": false}, options.Find().SetProjection(projection))
+	if err != nil {
+		return nil, err
+	}
+	var docs []struct {
+		ID              primitive.ObjectID `bson:"_id"`
+		AchievementType string             `bson:"achievementType"`
+		Title           string             `bson:"title"`
+		Details         bson.M             `bson:"details"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	found := make([]bool, len(records))
+	for _, doc := range docs {
+		i, ok := indexByMongoID[doc.ID]
+		if !ok {
+			continue
+		}
+		found[i] = true
+		rec := &records[i]
+		rec.AchievementType, rec.Title = doc.AchievementType, doc.Title
+		rec.Level = detailString(doc.Details, "level")
+		rec.Category = detailString(doc.Details, "category")
+		rec.Rank = detailString(doc.Details, "rank")
+		rec.EventDate = detailDate(doc.Details, "eventDate")
+	}
+
+	// Referensi tanpa dokumen MongoDB tidak bisa dipetakan, jadi tidak dilaporkan
+	result := records[:0]
+	for i, rec := range records {
+		if found[i] {
+			result = append(result, rec)
+		}
+	}
+	return result, nil
+}
+
+func detailString(details bson.M, key string) string {
+	if v, ok := details[key]; ok && v != nil {
+		return strings.TrimSpace(fmt.Sprint(v))
+	}
+	return ""
+}
+
+// detailDate menerima tanggal yang disimpan sebagai BSON date maupun string (YYYY-MM-DD / RFC3339)
+func detailDate(details bson.M, key string) *time.Time {
+	switch v := details[key].(type) {
+	case primitive.DateTime:
+		t := v.Time()
+		return &t
+	case time.Time:
+		return &v
+	case string:
+		for _, layout := range []string{"2006-01-02", time.RFC3339} {
+			if t, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+				return &t
+			}
+		}
+	}
+	return nil
+}
+
+// HardDeleteAchievement menghapus permanen dan mengembalikan daftar lampiran agar file fisiknya bisa dibersihkan
+func (r *achievementRepository) HardDeleteAchievement(ctx context.Context, refID uuid.UUID) ([]models.AttachmentFile, error) {
 	var mongoID string
 	err := r.pgDB.QueryRow(ctx, "SELECT mongo_achievement_id FROM achievement_references WHERE id = $1", refID).Scan(&mongoID)
 	if err != nil {
-		return fmt.Errorf("reference not found: %w", err)
+		return nil, fmt.Errorf("reference not found: %w", err)
 	}
 
 	objID, _ := primitive.ObjectIDFromHex(mongoID)
 	coll := r.mongoClient.Database(MongoDatabaseName).Collection(MongoCollectionAchievements)
-	_, err = coll.DeleteOne(ctx, bson.M{"_id": objID})
-	if err != nil {
-		return fmt.Errorf("failed to delete mongo doc: %w", err)
+
+	var deleted models.Achievement
+	err = coll.FindOneAndDelete(ctx, bson.M{"_id": objID}).Decode(&deleted)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("failed to delete mongo doc: %w", err)
 	}
 
 	_, err = r.pgDB.Exec(ctx, "DELETE FROM achievement_references WHERE id = $1", refID)
-	return err
-}
\ No newline at end of file
+	if err != nil {
+		return nil, err
+	}
+	return deleted.Attachments, nil
+}
+
+// ListTrashedReferences mengambil prestasi yang ada di trash (is_deleted = TRUE)
+func (r *achievementRepository) ListTrashedReferences(ctx context.Context, scope models.AchievementScope) ([]models.AchievementReference, error) {
+	query := `SELECT id, student_id, mongo_achievement_id, status, submitted_at, verified_at, verified_by, rejection_note, deleted_at FROM achievement_references WHERE is_deleted = TRUE`
+	args := []interface{}{}
+
+	if !scope.All {
+		query += " AND student_id = ANY($1)"
+		args = append(args, scope.StudentIDs)
+	}
+	query += " ORDER BY deleted_at DESC"
+
+	rows, err := r.pgDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var references []models.AchievementReference
+	for rows.Next() {
+		ref := models.AchievementReference{IsDeleted: true}
+		if err := rows.Scan(
+			&ref.ID, &ref.StudentID, &ref.MongoAchievementID, &ref.Status,
+			&ref.SubmittedAt, &ref.VerifiedAt, &ref.VerifiedBy, &ref.RejectionNote, &ref.DeletedAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning achievement reference: %w", err)
+		}
+		references = append(references, ref)
+	}
+	return references, nil
+}
+
+// GetTrashedReferenceByID
+func (r *achievementRepository) GetTrashedReferenceByID(ctx context.Context, refID uuid.UUID) (*models.AchievementReference, error) {
+	query := `SELECT id, student_id, mongo_achievement_id, status, submitted_at, verified_at, verified_by, rejection_note, deleted_at FROM achievement_references WHERE id = $1 AND is_deleted = TRUE`
+	ref := models.AchievementReference{IsDeleted: true}
+	err := r.pgDB.QueryRow(ctx, query, refID).Scan(
+		&ref.ID, &ref.StudentID, &ref.MongoAchievementID, &ref.Status,
+		&ref.SubmittedAt, &ref.VerifiedAt, &ref.VerifiedBy, &ref.RejectionNote, &ref.DeletedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errors.New("achievement not found in trash")
+	}
+	return &ref, err
+}
+
+// GetAchievementDetailWithDeleted sama seperti GetAchievementDetail tetapi ikut membaca dokumen yang sudah di-soft delete
+func (r *achievementRepository) GetAchievementDetailWithDeleted(ctx context.Context, mongoID string) (*models.Achievement, error) {
+	objID, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return nil, errors.New("invalid mongo ID")
+	}
+	coll := r.mongoClient.Database(MongoDatabaseName).Collection(MongoCollectionAchievements)
+
+	var achievement models.Achievement
+	err = coll.FindOne(ctx, bson.M{"_id": objID}).Decode(&achievement)
+	return &achievement, err
+}
+
+// RestoreAchievementAndReference mengembalikan prestasi dari trash (Mongo + PostgreSQL)
+func (r *achievementRepository) RestoreAchievementAndReference(ctx context.Context, refID uuid.UUID) (*models.AchievementReference, error) {
+	ref, err := r.GetTrashedReferenceByID(ctx, refID)
+	if err != nil {
+		return nil, err
+	}
+
+	objID, _ := primitive.ObjectIDFromHex(ref.MongoAchievementID)
+	mongoColl := r.mongoClient.Database(MongoDatabaseName).Collection(MongoCollectionAchievements)
+	_, err = mongoColl.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{
+		"$set":   bson.M{"isDeleted": false, "updatedAt": time.Now()},
+		"$unset": bson.M{"deletedAt": ""},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore mongo doc: %w", err)
+	}
+
+	_, err = r.pgDB.Exec(ctx, "UPDATE achievement_references SET is_deleted = false, deleted_at = NULL, updated_at = NOW() WHERE id = $1", refID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore reference: %w", err)
+	}
+
+	ref.IsDeleted = false
+	ref.DeletedAt = nil
+	return ref, nil
+}
+
+// ListExpiredTrashReferenceIDs mengambil ID prestasi di trash yang sudah melewati masa retensi
+func (r *achievementRepository) ListExpiredTrashReferenceIDs(ctx context.Context, deletedBefore time.Time) ([]uuid.UUID, error) {
+	query := `SELECT id FROM achievement_references WHERE is_deleted = TRUE AND COALESCE(deleted_at, updated_at) < $1`
+	rows, err := r.pgDB.Query(ctx, query, deletedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error scanning achievement reference ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// RevokeVerification mengembalikan prestasi terverifikasi ke status rejected dan menghanguskan kode verifikasinya
+func (r *achievementRepository) RevokeVerification(ctx context.Context, refID uuid.UUID, revokedBy uuid.UUID, reason string) (*models.AchievementReference, error) {
+	query := `
+		WITH revoked AS (
+			UPDATE achievement_references
+			SET status = 'rejected', rejection_note = $3, verified_by = $2, verified_at = NULL, updated_at = NOW()
+			WHERE id = $1 AND status = 'verified' AND is_deleted = FALSE
+			RETURNING id, student_id, mongo_achievement_id, status, submitted_at, verified_at, verified_by, rejection_note
+		), codes AS (
+			UPDATE achievement_verification_codes SET revoked_at = NOW()
+			WHERE achievement_ref_id IN (SELECT id FROM revoked) AND revoked_at IS NULL
+		)
+		SELECT * FROM revoked`
+
+	ref := models.AchievementReference{}
+	err := r.pgDB.QueryRow(ctx, query, refID, revokedBy, reason).Scan(
+		&ref.ID, &ref.StudentID, &ref.MongoAchievementID, &ref.Status,
+		&ref.SubmittedAt, &ref.VerifiedAt, &ref.VerifiedBy, &ref.RejectionNote,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errors.New("achievement not found or status already changed")
+	}
+	return &ref, err
+}
+
+// GetActiveVerificationCode mengembalikan string kosong jika prestasi belum/tidak lagi punya kode aktif
+func (r *achievementRepository) GetActiveVerificationCode(ctx context.Context, refID uuid.UUID) (string, error) {
+	var code string
+	err := r.pgDB.QueryRow(ctx, `SELECT code FROM achievement_verification_codes WHERE achievement_ref_id = $1 AND revoked_at IS NULL`, refID).Scan(&code)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	return code, err
+}
+
+// GetPublicVerification mengembalikan nil, nil jika kode tidak dikenal (atau prestasinya sudah dihapus permanen)
+func (r *achievementRepository) GetPublicVerification(ctx context.Context, code string) (*models.AchievementVerification, error) {
+	query := `
+		SELECT c.revoked_at, ar.status, ar.is_deleted, ar.mongo_achievement_id, ar.verified_at,
+		       COALESCE(su.full_name, ''), COALESCE(vr.name, '')
+		FROM achievement_verification_codes c
+		JOIN achievement_references ar ON ar.id = c.achievement_ref_id
+		LEFT JOIN users su ON su.id = ar.student_id
+		LEFT JOIN users vu ON vu.id = ar.verified_by
+		LEFT JOIN roles vr ON vr.id = vu.role_id
+		WHERE c.code = $1`
+
+	var (
+		revokedAt  *time.Time
+		status     string
+		isDeleted  bool
+		mongoID    string
+		verifiedAt *time.Time
+		result     models.AchievementVerification
+	)
+	err := r.pgDB.QueryRow(ctx, query, code).Scan(&revokedAt, &status, &isDeleted, &mongoID, &verifiedAt, &result.StudentName, &result.VerifierRole)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Kode yang dicabut, atau prestasi yang tidak lagi terverifikasi/sudah dibuang ke trash, tidak berlaku
+	if revokedAt != nil || status != "verified" || isDeleted {
+		return &models.AchievementVerification{Status: models.VerificationRevoked, RevokedAt: revokedAt}, nil
+	}
+
+	detail, err := r.GetAchievementDetail(ctx, mongoID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Valid = true
+	result.Status = models.VerificationValid
+	result.Title = detail.Title
+	result.AchievementType = detail.AchievementType
+	result.VerifiedAt = verifiedAt
+	return &result, nil
+}
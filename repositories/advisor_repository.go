@@ -0,0 +1,179 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"prestasi-mahasiswa-api/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AdvisorRepository mengelola penugasan dosen wali beserta riwayatnya
+type AdvisorRepository interface {
+	FindStudentUserIDs(ctx context.Context, filter models.AdviseeFilter) ([]uuid.UUID, error)
+	AssignAdvisor(ctx context.Context, studentUserIDs []uuid.UUID, lecturerID uuid.UUID, assignedBy uuid.UUID, reason string) (*models.AdvisorAssignmentResult, error)
+	ListAssignmentHistory(ctx context.Context, studentUserID uuid.UUID) ([]models.AdvisorAssignment, error)
+}
+
+type advisorRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAdvisorRepository(db *pgxpool.Pool) AdvisorRepository {
+	return &advisorRepository{db: db}
+}
+
+// FindStudentUserIDs mencari user ID mahasiswa yang memenuhi semua kriteria filter
+func (r *advisorRepository) FindStudentUserIDs(ctx context.Context, filter models.AdviseeFilter) ([]uuid.UUID, error) {
+	query := `SELECT user_id FROM students WHERE 1=1`
+	args := []interface{}{}
+
+	if filter.StudyProgramID != nil {
+		args = append(args, *filter.StudyProgramID)
+		query += fmt.Sprintf(" AND study_program_id = $%d", len(args))
+	}
+	if filter.AcademicYear != "" {
+		args = append(args, filter.AcademicYear)
+		query += fmt.Sprintf(" AND academic_year = $%d", len(args))
+	}
+	if filter.AdvisorID != nil {
+		args = append(args, *filter.AdvisorID)
+		query += fmt.Sprintf(" AND advisor_id = $%d", len(args))
+	}
+
+	rows, err := r.db.Query(ctx, query+` ORDER BY student_id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// AssignAdvisor menutup penugasan lama, membuka penugasan baru, dan memindahkan
+// pengajuan berstatus 'submitted' ke dosen baru dalam satu transaksi
+func (r *advisorRepository) AssignAdvisor(ctx context.Context, studentUserIDs []uuid.UUID, lecturerID uuid.UUID, assignedBy uuid.UUID, reason string) (*models.AdvisorAssignmentResult, error) {
+	result := &models.AdvisorAssignmentResult{NotFound: []uuid.UUID{}}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT id, user_id, advisor_id FROM students
+		WHERE user_id = ANY($1)
+		FOR UPDATE`, studentUserIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock students: %w", err)
+	}
+
+	found := map[uuid.UUID]bool{}
+	var changedStudentIDs, changedUserIDs []uuid.UUID
+	for rows.Next() {
+		var studentID, userID uuid.UUID
+		var advisorID *uuid.UUID
+		if err := rows.Scan(&studentID, &userID, &advisorID); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		found[userID] = true
+		if advisorID != nil && *advisorID == lecturerID {
+			result.Unchanged++
+			continue
+		}
+		changedStudentIDs = append(changedStudentIDs, studentID)
+		changedUserIDs = append(changedUserIDs, userID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, id := range studentUserIDs {
+		if !found[id] {
+			result.NotFound = append(result.NotFound, id)
+		}
+	}
+	result.Matched = len(found)
+
+	if len(changedStudentIDs) > 0 {
+		// NOW() bernilai sama dalam satu transaksi, sehingga effective_to lama = effective_from baru
+		if _, err := tx.Exec(ctx, `
+			UPDATE advisor_assignments SET effective_to = NOW()
+			WHERE student_id = ANY($1) AND effective_to IS NULL`, changedStudentIDs); err != nil {
+			return nil, fmt.Errorf("failed to close previous assignments: %w", err)
+		}
+
+		var by *uuid.UUID
+		if assignedBy != uuid.Nil {
+			by = &assignedBy
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO advisor_assignments (student_id, lecturer_id, effective_from, assigned_by, reason)
+			SELECT sid, $2, NOW(), $3, $4 FROM UNNEST($1::uuid[]) AS sid`,
+			changedStudentIDs, lecturerID, by, reason); err != nil {
+			return nil, fmt.Errorf("failed to record assignments: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, `UPDATE students SET advisor_id = $2 WHERE id = ANY($1)`, changedStudentIDs, lecturerID); err != nil {
+			return nil, fmt.Errorf("failed to update advisor: %w", err)
+		}
+
+		cmd, err := tx.Exec(ctx, `
+			UPDATE achievement_references SET assigned_advisor_id = $2, updated_at = NOW()
+			WHERE student_id = ANY($1) AND status = 'submitted' AND is_deleted = FALSE`,
+			changedUserIDs, lecturerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to re-route submissions: %w", err)
+		}
+		result.ReroutedSubmissions = int(cmd.RowsAffected())
+		result.Assigned = len(changedStudentIDs)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit assignment: %w", err)
+	}
+	return result, nil
+}
+
+// ListAssignmentHistory mengembalikan riwayat dosen wali seorang mahasiswa, terbaru lebih dulu
+func (r *advisorRepository) ListAssignmentHistory(ctx context.Context, studentUserID uuid.UUID) ([]models.AdvisorAssignment, error) {
+	query := `
+		SELECT a.id, s.user_id, a.lecturer_id, l.user_id, l.lecturer_id, u.full_name,
+		       a.effective_from, a.effective_to, a.assigned_by, a.reason
+		FROM advisor_assignments a
+		JOIN students s ON s.id = a.student_id
+		JOIN lecturers l ON l.id = a.lecturer_id
+		JOIN users u ON u.id = l.user_id
+		WHERE s.user_id = $1
+		ORDER BY a.effective_from DESC, a.created_at DESC`
+
+	rows, err := r.db.Query(ctx, query, studentUserID)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	history := []models.AdvisorAssignment{}
+	for rows.Next() {
+		var a models.AdvisorAssignment
+		if err := rows.Scan(&a.ID, &a.StudentUserID, &a.LecturerID, &a.LecturerUserID, &a.LecturerNumber, &a.LecturerName,
+			&a.EffectiveFrom, &a.EffectiveTo, &a.AssignedBy, &a.Reason); err != nil {
+			return nil, err
+		}
+		history = append(history, a)
+	}
+	return history, rows.Err()
+}
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// DuplicateFieldError dikembalikan saat insert/update melanggar unique constraint
+type DuplicateFieldError struct {
+	Field      string // nama field JSON (username, email, studentId, lecturerId)
+	Constraint string
+}
+
+func (e *DuplicateFieldError) Error() string {
+	if e.Field == "" {
+		return "value already exists"
+	}
+	return e.Field + " already exists"
+}
+
+// asDuplicateFieldError memetakan unique violation (23505) Postgres ke DuplicateFieldError.
+// Error lain dikembalikan apa adanya.
+func asDuplicateFieldError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" {
+		return err
+	}
+
+	field := ""
+	switch name := pgErr.ConstraintName; {
+	case strings.Contains(name, "email"):
+		field = "email"
+	case strings.Contains(name, "username"):
+		field = "username"
+	case strings.Contains(name, "student_id"):
+		field = "studentId"
+	case strings.Contains(name, "lecturer_id"):
+		field = "lecturerId"
+	}
+	return &DuplicateFieldError{Field: field, Constraint: pgErr.ConstraintName}
+}
@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"prestasi-mahasiswa-api/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// LoginAttemptStore menyimpan riwayat percobaan login untuk proteksi brute-force.
+// Tersedia backend PostgreSQL (persisten, bisa dipakai banyak instance) dan in-memory.
+type LoginAttemptStore interface {
+	RecordAttempt(ctx context.Context, attempt *models.LoginAttempt) error
+	GetFailuresByUsername(ctx context.Context, username string, since time.Time) (*models.LoginFailureSummary, error)
+	GetFailuresByIP(ctx context.Context, ipAddress string, since time.Time) (*models.LoginFailureSummary, error)
+	// ClearFailures mereset hitungan gagal milik username (login sukses / unlock Admin)
+	ClearFailures(ctx context.Context, username string) error
+}
+
+// --- PostgreSQL ---
+
+type postgresLoginAttemptStore struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresLoginAttemptStore(db *pgxpool.Pool) LoginAttemptStore {
+	return &postgresLoginAttemptStore{db: db}
+}
+
+func (r *postgresLoginAttemptStore) RecordAttempt(ctx context.Context, a *models.LoginAttempt) error {
+	query := `
+		INSERT INTO login_attempts (id, username, user_id, ip_address, user_agent, success, failure_reason, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NOW())
+		RETURNING created_at`
+
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+
+	err := r.db.QueryRow(ctx, query, a.ID, a.Username, a.UserID, a.IPAddress, a.UserAgent, a.Success, a.FailureReason).Scan(&a.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record login attempt: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresLoginAttemptStore) GetFailuresByUsername(ctx context.Context, username string, since time.Time) (*models.LoginFailureSummary, error) {
+	query := `SELECT COUNT(*), MAX(created_at) FROM login_attempts WHERE username = $1 AND success = FALSE AND cleared_at IS NULL AND created_at >= $2`
+	summary := models.LoginFailureSummary{}
+	err := r.db.QueryRow(ctx, query, username, since).Scan(&summary.Count, &summary.LastFailureAt)
+	return &summary, err
+}
+
+func (r *postgresLoginAttemptStore) GetFailuresByIP(ctx context.Context, ipAddress string, since time.Time) (*models.LoginFailureSummary, error) {
+	query := `SELECT COUNT(*), MAX(created_at) FROM login_attempts WHERE ip_address = $1 AND success = FALSE AND created_at >= $2`
+	summary := models.LoginFailureSummary{}
+	err := r.db.QueryRow(ctx, query, ipAddress, since).Scan(&summary.Count, &summary.LastFailureAt)
+	return &summary, err
+}
+
+func (r *postgresLoginAttemptStore) ClearFailures(ctx context.Context, username string) error {
+	_, err := r.db.Exec(ctx, `UPDATE login_attempts SET cleared_at = NOW() WHERE username = $1 AND success = FALSE AND cleared_at IS NULL`, username)
+	return err
+}
+
+// --- In-Memory ---
+
+// inMemoryRetention batas umur data percobaan login yang disimpan di memori
+const inMemoryRetention = 24 * time.Hour
+
+type inMemoryLoginAttempt struct {
+	attempt models.LoginAttempt
+	cleared bool
+}
+
+type inMemoryLoginAttemptStore struct {
+	mu       sync.Mutex
+	attempts []inMemoryLoginAttempt
+}
+
+// NewInMemoryLoginAttemptStore cocok untuk development atau deployment satu instance
+func NewInMemoryLoginAttemptStore() LoginAttemptStore {
+	return &inMemoryLoginAttemptStore{}
+}
+
+func (s *inMemoryLoginAttemptStore) RecordAttempt(ctx context.Context, a *models.LoginAttempt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	a.CreatedAt = time.Now()
+
+	// Buang data lama agar memori tidak tumbuh tanpa batas
+	cutoff := a.CreatedAt.Add(-inMemoryRetention)
+	kept := s.attempts[:0]
+	for _, entry := range s.attempts {
+		if entry.attempt.CreatedAt.After(cutoff) {
+			kept = append(kept, entry)
+		}
+	}
+	s.attempts = append(kept, inMemoryLoginAttempt{attempt: *a})
+	return nil
+}
+
+func (s *inMemoryLoginAttemptStore) GetFailuresByUsername(ctx context.Context, username string, since time.Time) (*models.LoginFailureSummary, error) {
+	return s.summarize(func(entry inMemoryLoginAttempt) bool {
+		return entry.attempt.Username == username && !entry.cleared
+	}, since), nil
+}
+
+func (s *inMemoryLoginAttemptStore) GetFailuresByIP(ctx context.Context, ipAddress string, since time.Time) (*models.LoginFailureSummary, error) {
+	return s.summarize(func(entry inMemoryLoginAttempt) bool {
+		return entry.attempt.IPAddress == ipAddress
+	}, since), nil
+}
+
+func (s *inMemoryLoginAttemptStore) ClearFailures(ctx context.Context, username string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.attempts {
+		if s.attempts[i].attempt.Username == username && !s.attempts[i].attempt.Success {
+			s.attempts[i].cleared = true
+		}
+	}
+	return nil
+}
+
+func (s *inMemoryLoginAttemptStore) summarize(match func(inMemoryLoginAttempt) bool, since time.Time) *models.LoginFailureSummary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	summary := &models.LoginFailureSummary{}
+	for _, entry := range s.attempts {
+		if entry.attempt.Success || entry.attempt.CreatedAt.Before(since) || !match(entry) {
+			continue
+		}
+		summary.Count++
+		createdAt := entry.attempt.CreatedAt
+		if summary.LastFailureAt == nil || createdAt.After(*summary.LastFailureAt) {
+			summary.LastFailureAt = &createdAt
+		}
+	}
+	return summary
+}
@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"prestasi-mahasiswa-api/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type MFARepository interface {
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*models.UserMFA, error)
+	SavePendingSecret(ctx context.Context, userID uuid.UUID, secret string) error
+	Enable(ctx context.Context, userID uuid.UUID, recoveryCodeHashes []string, usedStep int64) error
+	MarkStepUsed(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+	ConsumeRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error)
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
+
+type mfaRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewMFARepository(db *pgxpool.Pool) MFARepository {
+	return &mfaRepository{db: db}
+}
+
+// GetByUserID mengembalikan nil (tanpa error) jika user belum pernah setup 2FA
+func (r *mfaRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*models.UserMFA, error) {
+	query := `SELECT user_id, secret, enabled, recovery_code_hashes, last_used_step, created_at, enabled_at FROM user_mfa WHERE user_id = $1`
+	var m models.UserMFA
+	err := r.db.QueryRow(ctx, query, userID).Scan(&m.UserID, &m.Secret, &m.Enabled, &m.RecoveryCodeHashes, &m.LastUsedStep, &m.CreatedAt, &m.EnabledAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	return &m, nil
+}
+
+// SavePendingSecret menyimpan secret baru yang belum aktif (ditolak jika 2FA sudah aktif)
+func (r *mfaRepository) SavePendingSecret(ctx context.Context, userID uuid.UUID, secret string) error {
+	query := `
+		INSERT INTO user_mfa (user_id, secret, enabled, created_at)
+		VALUES ($1, $2, FALSE, NOW())
+		ON CONFLICT (user_id)
+		DO UPDATE SET secret = EXCLUDED.secret, created_at = NOW()
+		WHERE user_mfa.enabled = FALSE`
+	cmd, err := r.db.Exec(ctx, query, userID, secret)
+	if err != nil {
+		return fmt.Errorf("failed to save mfa secret: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New("two-factor authentication is already enabled")
+	}
+	return nil
+}
+
+// Enable mengaktifkan 2FA beserta hash kode pemulihan
+func (r *mfaRepository) Enable(ctx context.Context, userID uuid.UUID, recoveryCodeHashes []string, usedStep int64) error {
+	query := `UPDATE user_mfa SET enabled = TRUE, enabled_at = NOW(), recovery_code_hashes = $1, last_used_step = $2 WHERE user_id = $3 AND enabled = FALSE`
+	cmd, err := r.db.Exec(ctx, query, recoveryCodeHashes, usedStep, userID)
+	if err != nil {
+		return fmt.Errorf("failed to enable mfa: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New("no pending two-factor setup found")
+	}
+	return nil
+}
+
+// MarkStepUsed mencatat time-step TOTP terakhir; false jika kode sudah pernah dipakai
+func (r *mfaRepository) MarkStepUsed(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	cmd, err := r.db.Exec(ctx, `UPDATE user_mfa SET last_used_step = $1 WHERE user_id = $2 AND last_used_step < $1`, step, userID)
+	if err != nil {
+		return false, err
+	}
+	return cmd.RowsAffected() > 0, nil
+}
+
+// ConsumeRecoveryCode menghapus kode pemulihan yang dipakai; false jika tidak ditemukan
+func (r *mfaRepository) ConsumeRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	query := `UPDATE user_mfa SET recovery_code_hashes = array_remove(recovery_code_hashes, $1) WHERE user_id = $2 AND enabled = TRUE AND $1 = ANY(recovery_code_hashes)`
+	cmd, err := r.db.Exec(ctx, query, codeHash, userID)
+	if err != nil {
+		return false, err
+	}
+	return cmd.RowsAffected() > 0, nil
+}
+
+func (r *mfaRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM user_mfa WHERE user_id = $1`, userID)
+	return err
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"prestasi-mahasiswa-api/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PasswordResetRepository interface {
+	CreateToken(ctx context.Context, token *models.PasswordResetToken) error
+	FindUnusedTokenByHash(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error)
+	MarkTokenUsed(ctx context.Context, tokenID uuid.UUID) error
+	InvalidateUserTokens(ctx context.Context, userID uuid.UUID) error
+}
+
+type passwordResetRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPasswordResetRepository(db *pgxpool.Pool) PasswordResetRepository {
+	return &passwordResetRepository{db: db}
+}
+
+// CreateToken menyimpan token reset (hanya hash-nya)
+func (r *passwordResetRepository) CreateToken(ctx context.Context, t *models.PasswordResetToken) error {
+	query := `
+		INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		RETURNING created_at`
+
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+
+	err := r.db.QueryRow(ctx, query, t.ID, t.UserID, t.TokenHash, t.ExpiresAt, t.CreatedBy).Scan(&t.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create reset token: %w", err)
+	}
+	return nil
+}
+
+// FindUnusedTokenByHash mencari token yang belum dipakai (kedaluwarsa dicek di service)
+func (r *passwordResetRepository) FindUnusedTokenByHash(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	query := `SELECT id, user_id, token_hash, expires_at, used_at, created_by, created_at FROM password_reset_tokens WHERE token_hash = $1 AND used_at IS NULL`
+	var t models.PasswordResetToken
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(&t.ID, &t.UserID, &t.TokenHash, &t.ExpiresAt, &t.UsedAt, &t.CreatedBy, &t.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errors.New("reset token not found")
+	}
+	return &t, err
+}
+
+// MarkTokenUsed menandai token sudah dipakai. Gagal jika token sudah dipakai sebelumnya.
+func (r *passwordResetRepository) MarkTokenUsed(ctx context.Context, tokenID uuid.UUID) error {
+	cmd, err := r.db.Exec(ctx, `UPDATE password_reset_tokens SET used_at = NOW() WHERE id = $1 AND used_at IS NULL`, tokenID)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New("reset token already used")
+	}
+	return nil
+}
+
+// InvalidateUserTokens menonaktifkan semua token reset yang belum dipakai milik user
+func (r *passwordResetRepository) InvalidateUserTokens(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `UPDATE password_reset_tokens SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL`, userID)
+	return err
+}
@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"prestasi-mahasiswa-api/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PermissionRepository interface {
+	ListPermissions(ctx context.Context) ([]models.Permission, error)
+	GetPermissionByID(ctx context.Context, permissionID uuid.UUID) (*models.Permission, error)
+	GetPermissionsByNames(ctx context.Context, names []string) ([]models.Permission, error)
+	CreatePermission(ctx context.Context, permission *models.Permission) error
+	UpdatePermission(ctx context.Context, permission *models.Permission) error
+	DeletePermission(ctx context.Context, permissionID uuid.UUID) error
+}
+
+type permissionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPermissionRepository(db *pgxpool.Pool) PermissionRepository {
+	return &permissionRepository{db: db}
+}
+
+const permissionSelectQuery = `SELECT id, name, resource, action, description FROM permissions`
+
+func scanPermissions(rows pgx.Rows) ([]models.Permission, error) {
+	defer rows.Close()
+
+	permissions := []models.Permission{}
+	for rows.Next() {
+		var p models.Permission
+		if err := rows.Scan(&p.ID, &p.Name, &p.Resource, &p.Action, &p.Description); err != nil {
+			return nil, fmt.Errorf("error scanning permission: %w", err)
+		}
+		permissions = append(permissions, p)
+	}
+	return permissions, rows.Err()
+}
+
+func (r *permissionRepository) ListPermissions(ctx context.Context) ([]models.Permission, error) {
+	rows, err := r.db.Query(ctx, permissionSelectQuery+` ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	return scanPermissions(rows)
+}
+
+// GetPermissionByID mengembalikan nil (tanpa error) jika permission tidak ditemukan
+func (r *permissionRepository) GetPermissionByID(ctx context.Context, permissionID uuid.UUID) (*models.Permission, error) {
+	var p models.Permission
+	err := r.db.QueryRow(ctx, permissionSelectQuery+` WHERE id = $1`, permissionID).Scan(&p.ID, &p.Name, &p.Resource, &p.Action, &p.Description)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	return &p, nil
+}
+
+func (r *permissionRepository) GetPermissionsByNames(ctx context.Context, names []string) ([]models.Permission, error) {
+	rows, err := r.db.Query(ctx, permissionSelectQuery+` WHERE name = ANY($1) ORDER BY name`, names)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	return scanPermissions(rows)
+}
+
+func (r *permissionRepository) CreatePermission(ctx context.Context, p *models.Permission) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	query := `INSERT INTO permissions (id, name, resource, action, description) VALUES ($1, $2, $3, $4, $5)`
+	if _, err := r.db.Exec(ctx, query, p.ID, p.Name, p.Resource, p.Action, p.Description); err != nil {
+		return fmt.Errorf("failed to create permission: %w", err)
+	}
+	return nil
+}
+
+func (r *permissionRepository) UpdatePermission(ctx context.Context, p *models.Permission) error {
+	query := `UPDATE permissions SET name = $1, resource = $2, action = $3, description = $4 WHERE id = $5`
+	cmd, err := r.db.Exec(ctx, query, p.Name, p.Resource, p.Action, p.Description, p.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update permission: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New("permission not found")
+	}
+	return nil
+}
+
+// DeletePermission sekaligus mencabut permission dari semua role
+func (r *permissionRepository) DeletePermission(ctx context.Context, permissionID uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM role_permissions WHERE permission_id = $1`, permissionID); err != nil {
+		return fmt.Errorf("failed to revoke permission from roles: %w", err)
+	}
+	cmd, err := tx.Exec(ctx, `DELETE FROM permissions WHERE id = $1`, permissionID)
+	if err != nil {
+		return fmt.Errorf("failed to delete permission: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New("permission not found")
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,183 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"prestasi-mahasiswa-api/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrPendingChangeExists     = errors.New("a pending profile change request already exists")
+	ErrChangeRequestNotPending = errors.New("profile change request is no longer pending")
+)
+
+// ProfileChangeRepository mengelola pengajuan perubahan profil yang butuh persetujuan Admin
+type ProfileChangeRepository interface {
+	Create(ctx context.Context, req *models.ProfileChangeRequest) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ProfileChangeRequest, error)
+	List(ctx context.Context, filter models.ProfileChangeFilter) ([]models.ProfileChangeRequest, error)
+	HasPending(ctx context.Context, userID uuid.UUID) (bool, error)
+	Reject(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID, note string) error
+	ApproveStudentChange(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID, note string, student *models.Student) error
+	ApproveLecturerChange(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID, note string, lecturer *models.Lecturer) error
+}
+
+type profileChangeRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewProfileChangeRepository(db *pgxpool.Pool) ProfileChangeRepository {
+	return &profileChangeRepository{db: db}
+}
+
+const profileChangeSelectQuery = `
+	SELECT c.id, c.user_id, u.username, u.full_name, c.profile_type, c.changes, c.previous, c.reason,
+	       c.status, c.review_note, c.reviewed_by, c.reviewed_at, c.created_at
+	FROM profile_change_requests c
+	JOIN users u ON u.id = c.user_id`
+
+func scanProfileChange(row pgx.Row) (*models.ProfileChangeRequest, error) {
+	var c models.ProfileChangeRequest
+	err := row.Scan(&c.ID, &c.UserID, &c.Username, &c.FullName, &c.ProfileType, &c.Changes, &c.Previous, &c.Reason,
+		&c.Status, &c.ReviewNote, &c.ReviewedBy, &c.ReviewedAt, &c.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *profileChangeRepository) Create(ctx context.Context, req *models.ProfileChangeRequest) error {
+	if req.ID == uuid.Nil {
+		req.ID = uuid.New()
+	}
+	query := `
+		INSERT INTO profile_change_requests (id, user_id, profile_type, changes, previous, reason, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, 'pending', NOW())
+		RETURNING status, created_at`
+
+	err := r.db.QueryRow(ctx, query, req.ID, req.UserID, req.ProfileType, req.Changes, req.Previous, req.Reason).
+		Scan(&req.Status, &req.CreatedAt)
+	var dupErr *DuplicateFieldError
+	if errors.As(asDuplicateFieldError(err), &dupErr) {
+		return ErrPendingChangeExists // indeks unik pending per user
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create profile change request: %w", err)
+	}
+	return nil
+}
+
+func (r *profileChangeRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ProfileChangeRequest, error) {
+	c, err := scanProfileChange(r.db.QueryRow(ctx, profileChangeSelectQuery+` WHERE c.id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return c, err
+}
+
+func (r *profileChangeRepository) List(ctx context.Context, filter models.ProfileChangeFilter) ([]models.ProfileChangeRequest, error) {
+	query := profileChangeSelectQuery + ` WHERE 1=1`
+	args := []interface{}{}
+	if filter.UserID != nil {
+		args = append(args, *filter.UserID)
+		query += fmt.Sprintf(" AND c.user_id = $%d", len(args))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		query += fmt.Sprintf(" AND c.status = $%d", len(args))
+	}
+
+	rows, err := r.db.Query(ctx, query+` ORDER BY c.created_at DESC`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	requests := []models.ProfileChangeRequest{}
+	for rows.Next() {
+		c, err := scanProfileChange(rows)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, *c)
+	}
+	return requests, rows.Err()
+}
+
+func (r *profileChangeRepository) HasPending(ctx context.Context, userID uuid.UUID) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM profile_change_requests WHERE user_id = $1 AND status = 'pending')`, userID).Scan(&exists)
+	return exists, err
+}
+
+func (r *profileChangeRepository) Reject(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID, note string) error {
+	return markReviewed(ctx, r.db, id, models.ProfileChangeRejected, reviewerID, note)
+}
+
+// ApproveStudentChange menerapkan data mahasiswa baru dan menandai pengajuan disetujui dalam satu transaksi
+func (r *profileChangeRepository) ApproveStudentChange(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID, note string, student *models.Student) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := markReviewed(ctx, tx, id, models.ProfileChangeApproved, reviewerID, note); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE students SET student_id = $2, program_study = $3, study_program_id = $4, academic_year = $5
+		WHERE user_id = $1`,
+		student.UserID, student.StudentID, student.ProgramStudy, student.StudyProgramID, student.AcademicYear)
+	if err != nil {
+		return fmt.Errorf("failed to update student profile: %w", asDuplicateFieldError(err))
+	}
+	return tx.Commit(ctx)
+}
+
+// ApproveLecturerChange menerapkan data dosen baru dan menandai pengajuan disetujui dalam satu transaksi
+func (r *profileChangeRepository) ApproveLecturerChange(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID, note string, lecturer *models.Lecturer) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := markReviewed(ctx, tx, id, models.ProfileChangeApproved, reviewerID, note); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE lecturers SET lecturer_id = $2, department = $3, department_id = $4
+		WHERE user_id = $1`,
+		lecturer.UserID, lecturer.LecturerID, lecturer.Department, lecturer.DepartmentID)
+	if err != nil {
+		return fmt.Errorf("failed to update lecturer profile: %w", asDuplicateFieldError(err))
+	}
+	return tx.Commit(ctx)
+}
+
+type execer interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+// markReviewed hanya mengubah pengajuan yang masih pending (mencegah review ganda)
+func markReviewed(ctx context.Context, db execer, id uuid.UUID, status string, reviewerID uuid.UUID, note string) error {
+	cmd, err := db.Exec(ctx, `
+		UPDATE profile_change_requests
+		SET status = $2, reviewed_by = $3, review_note = $4, reviewed_at = NOW()
+		WHERE id = $1 AND status = 'pending'`, id, status, reviewerID, note)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrChangeRequestNotPending
+	}
+	return nil
+}
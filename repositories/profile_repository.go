@@ -15,8 +15,13 @@ import (
 type ProfileRepository interface {
 	UpsertStudent(ctx context.Context, student *models.Student) (*models.Student, error)
 	UpsertLecturer(ctx context.Context, lecturer *models.Lecturer) (*models.Lecturer, error)
-	AssignAdvisor(ctx context.Context, studentUserID uuid.UUID, advisorLecturerID uuid.UUID) error
 	GetLecturerByUserID(ctx context.Context, userID uuid.UUID) (*models.Lecturer, error)
+	UpsertFacultyStaff(ctx context.Context, staff *models.FacultyStaff) (*models.FacultyStaff, error)
+
+	// Read (nil, nil jika profil belum ada)
+	GetStudentProfileByUserID(ctx context.Context, userID uuid.UUID) (*models.StudentProfileDetail, error)
+	GetLecturerProfileByUserID(ctx context.Context, userID uuid.UUID) (*models.LecturerProfileDetail, error)
+	ListAdviseesByLecturerUserID(ctx context.Context, lecturerUserID uuid.UUID) ([]models.AdviseeSummary, error)
 }
 
 type profileRepository struct {
@@ -30,12 +35,13 @@ func NewProfileRepository(db *pgxpool.Pool) ProfileRepository {
 // UpsertStudent (Insert atau Update jika user_id sudah ada)
 func (r *profileRepository) UpsertStudent(ctx context.Context, s *models.Student) (*models.Student, error) {
 	query := `
-		INSERT INTO students (id, user_id, student_id, program_study, academic_year, created_at)
-		VALUES ($1, $2, $3, $4, $5, NOW())
+		INSERT INTO students (id, user_id, student_id, program_study, study_program_id, academic_year, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
 		ON CONFLICT (user_id) 
 		DO UPDATE SET 
 			student_id = EXCLUDED.student_id,
 			program_study = EXCLUDED.program_study,
+			study_program_id = EXCLUDED.study_program_id,
 			academic_year = EXCLUDED.academic_year
 		RETURNING id, advisor_id`
 	
@@ -43,9 +49,9 @@ func (r *profileRepository) UpsertStudent(ctx context.Context, s *models.Student
 		s.ID = uuid.New()
 	}
 
-	err := r.db.QueryRow(ctx, query, s.ID, s.UserID, s.StudentID, s.ProgramStudy, s.AcademicYear).Scan(&s.ID, &s.AdvisorID)
+	err := r.db.QueryRow(ctx, query, s.ID, s.UserID, s.StudentID, s.ProgramStudy, s.StudyProgramID, s.AcademicYear).Scan(&s.ID, &s.AdvisorID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to upsert student: %w", err)
+		return nil, fmt.Errorf("failed to upsert student: %w", asDuplicateFieldError(err))
 	}
 	return s, nil
 }
@@ -53,45 +59,139 @@ func (r *profileRepository) UpsertStudent(ctx context.Context, s *models.Student
 // UpsertLecturer
 func (r *profileRepository) UpsertLecturer(ctx context.Context, l *models.Lecturer) (*models.Lecturer, error) {
 	query := `
-		INSERT INTO lecturers (id, user_id, lecturer_id, department, created_at)
-		VALUES ($1, $2, $3, $4, NOW())
+		INSERT INTO lecturers (id, user_id, lecturer_id, department, department_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
 		ON CONFLICT (user_id)
 		DO UPDATE SET
 			lecturer_id = EXCLUDED.lecturer_id,
-			department = EXCLUDED.department
+			department = EXCLUDED.department,
+			department_id = EXCLUDED.department_id
 		RETURNING id`
 
 	if l.ID == uuid.Nil {
 		l.ID = uuid.New()
 	}
 
-	err := r.db.QueryRow(ctx, query, l.ID, l.UserID, l.LecturerID, l.Department).Scan(&l.ID)
+	err := r.db.QueryRow(ctx, query, l.ID, l.UserID, l.LecturerID, l.Department, l.DepartmentID).Scan(&l.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to upsert lecturer: %w", err)
+		return nil, fmt.Errorf("failed to upsert lecturer: %w", asDuplicateFieldError(err))
 	}
 	return l, nil
 }
 
-// AssignAdvisor menghubungkan student ke lecturer
-func (r *profileRepository) AssignAdvisor(ctx context.Context, studentUserID uuid.UUID, advisorLecturerID uuid.UUID) error {
-	query := `UPDATE students SET advisor_id = $1 WHERE user_id = $2`
-	cmd, err := r.db.Exec(ctx, query, advisorLecturerID, studentUserID)
-	if err != nil {
-		return err
-	}
-	if cmd.RowsAffected() == 0 {
-		return errors.New("student profile not found for this user")
-	}
-	return nil
-}
-
 // GetLecturerByUserID
 func (r *profileRepository) GetLecturerByUserID(ctx context.Context, userID uuid.UUID) (*models.Lecturer, error) {
-	query := `SELECT id, user_id, lecturer_id, department FROM lecturers WHERE user_id = $1`
+	query := `SELECT id, user_id, lecturer_id, department, department_id FROM lecturers WHERE user_id = $1`
 	var l models.Lecturer
-	err := r.db.QueryRow(ctx, query, userID).Scan(&l.ID, &l.UserID, &l.LecturerID, &l.Department)
+	err := r.db.QueryRow(ctx, query, userID).Scan(&l.ID, &l.UserID, &l.LecturerID, &l.Department, &l.DepartmentID)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, errors.New("lecturer profile not found")
 	}
 	return &l, err
-}
\ No newline at end of file
+}
+
+// UpsertFacultyStaff (Insert atau Update jika user_id sudah ada)
+func (r *profileRepository) UpsertFacultyStaff(ctx context.Context, st *models.FacultyStaff) (*models.FacultyStaff, error) {
+	query := `
+		INSERT INTO faculty_staff (id, user_id, staff_id, department_id, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (user_id)
+		DO UPDATE SET
+			staff_id = EXCLUDED.staff_id,
+			department_id = EXCLUDED.department_id
+		RETURNING id, created_at`
+
+	if st.ID == uuid.Nil {
+		st.ID = uuid.New()
+	}
+
+	err := r.db.QueryRow(ctx, query, st.ID, st.UserID, st.StaffID, st.DepartmentID).Scan(&st.ID, &st.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert faculty staff: %w", err)
+	}
+	return st, nil
+}
+
+// GetStudentProfileByUserID mengambil profil mahasiswa beserta ringkasan dosen walinya
+func (r *profileRepository) GetStudentProfileByUserID(ctx context.Context, userID uuid.UUID) (*models.StudentProfileDetail, error) {
+	query := `
+		SELECT s.id, s.user_id, s.student_id, s.program_study, s.study_program_id, s.academic_year, s.advisor_id, s.created_at,
+		       su.full_name, l.user_id, l.lecturer_id, u.full_name, u.email
+		FROM students s
+		JOIN users su ON su.id = s.user_id
+		LEFT JOIN lecturers l ON l.id = s.advisor_id
+		LEFT JOIN users u ON u.id = l.user_id
+		WHERE s.user_id = $1`
+
+	var p models.StudentProfileDetail
+	var advisorUserID *uuid.UUID
+	var advisorNIP, advisorName, advisorEmail *string
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&p.ID, &p.UserID, &p.StudentID, &p.ProgramStudy, &p.StudyProgramID, &p.AcademicYear, &p.AdvisorID, &p.CreatedAt,
+		&p.FullName, &advisorUserID, &advisorNIP, &advisorName, &advisorEmail,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if advisorUserID != nil {
+		p.Advisor = &models.AdvisorSummary{
+			UserID:     *advisorUserID,
+			LecturerID: derefString(advisorNIP),
+			FullName:   derefString(advisorName),
+			Email:      derefString(advisorEmail),
+		}
+	}
+	return &p, nil
+}
+
+// GetLecturerProfileByUserID mengambil profil dosen beserta jumlah mahasiswa bimbingannya
+func (r *profileRepository) GetLecturerProfileByUserID(ctx context.Context, userID uuid.UUID) (*models.LecturerProfileDetail, error) {
+	query := `
+		SELECT l.id, l.user_id, l.lecturer_id, l.department, l.department_id, l.created_at,
+		       (SELECT COUNT(*) FROM students s WHERE s.advisor_id = l.id)
+		FROM lecturers l
+		WHERE l.user_id = $1`
+
+	var p models.LecturerProfileDetail
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&p.ID, &p.UserID, &p.LecturerID, &p.Department, &p.DepartmentID, &p.CreatedAt, &p.AdviseeCount,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// ListAdviseesByLecturerUserID mengembalikan mahasiswa bimbingan aktif seorang dosen, urut NIM
+func (r *profileRepository) ListAdviseesByLecturerUserID(ctx context.Context, lecturerUserID uuid.UUID) ([]models.AdviseeSummary, error) {
+	query := `
+		SELECT s.user_id, s.student_id, u.full_name, u.email, s.program_study, s.study_program_id, s.academic_year, u.is_active
+		FROM students s
+		JOIN lecturers l ON l.id = s.advisor_id
+		JOIN users u ON u.id = s.user_id
+		WHERE l.user_id = $1
+		ORDER BY s.student_id`
+
+	rows, err := r.db.Query(ctx, query, lecturerUserID)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	advisees := []models.AdviseeSummary{}
+	for rows.Next() {
+		var a models.AdviseeSummary
+		if err := rows.Scan(&a.UserID, &a.StudentID, &a.FullName, &a.Email, &a.ProgramStudy, &a.StudyProgramID, &a.AcademicYear, &a.IsActive); err != nil {
+			return nil, err
+		}
+		advisees = append(advisees, a)
+	}
+	return advisees, rows.Err()
+}
@@ -16,6 +16,17 @@ type RoleRepository interface {
 	GetAllRoles(ctx context.Context) ([]models.Role, error)
 	GetRoleByName(ctx context.Context, name string) (*models.Role, error)
 	GetRoleByID(ctx context.Context, roleID uuid.UUID) (*models.Role, error)
+
+	// Manajemen role (Admin)
+	CreateRole(ctx context.Context, role *models.Role) error
+	UpdateRole(ctx context.Context, role *models.Role) error
+	DeleteRole(ctx context.Context, roleID uuid.UUID) error
+	CountUsersWithRole(ctx context.Context, roleID uuid.UUID) (int, error)
+
+	// Permission milik role
+	SetRolePermissions(ctx context.Context, roleID uuid.UUID, permissionIDs []uuid.UUID) error
+	AddRolePermission(ctx context.Context, roleID uuid.UUID, permissionID uuid.UUID) error
+	RemoveRolePermission(ctx context.Context, roleID uuid.UUID, permissionID uuid.UUID) error
 }
 
 type roleRepository struct {
@@ -26,44 +37,152 @@ func NewRoleRepository(db *pgxpool.Pool) RoleRepository {
 	return &roleRepository{db: db}
 }
 
-// GetRoleByName mengambil detail role berdasarkan nama, termasuk permissions
-func (r *roleRepository) GetRoleByName(ctx context.Context, name string) (*models.Role, error) {
-	query := `
-		SELECT 
-			r.id, r.name, ARRAY_AGG(p.name) AS permissions
-		FROM 
-			roles r
-		LEFT JOIN 
-			role_permissions rp ON r.id = rp.role_id
-		LEFT JOIN 
-			permissions p ON rp.permission_id = p.id
-		WHERE 
-			r.name = $1
-		GROUP BY 
-			r.id, r.name`
+// ARRAY(subquery) dipakai (bukan ARRAY_AGG + LEFT JOIN) agar role tanpa permission menghasilkan array kosong, bukan {NULL}
+const roleSelectQuery = `
+	SELECT 
+		r.id, r.name, r.description,
+		ARRAY(
+			SELECT p.name 
+			FROM role_permissions rp 
+			JOIN permissions p ON rp.permission_id = p.id 
+			WHERE rp.role_id = r.id
+			ORDER BY p.name
+		) AS permissions
+	FROM roles r`
 
+func scanRoleRow(row pgx.Row) (*models.Role, error) {
 	role := models.Role{}
-	var permissionsPgArray []string
-	err := r.db.QueryRow(ctx, query, name).Scan(&role.ID, &role.Name, &permissionsPgArray)
+	if err := row.Scan(&role.ID, &role.Name, &role.Description, &role.Permissions); err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
 
+// GetRoleByName mengambil detail role berdasarkan nama, termasuk permissions
+func (r *roleRepository) GetRoleByName(ctx context.Context, name string) (*models.Role, error) {
+	role, err := scanRoleRow(r.db.QueryRow(ctx, roleSelectQuery+` WHERE r.name = $1`, name))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil // Role not found
 		}
 		return nil, fmt.Errorf("database query failed: %w", err)
 	}
-	
-	role.Permissions = permissionsPgArray
-	return &role, nil
+	return role, nil
 }
 
-// GetAllRoles, GetRoleByID (metode lainnya dapat diimplementasikan serupa jika diperlukan)
-// Saat ini hanya GetRoleByName yang diimplementasikan karena langsung dibutuhkan oleh UserService.
+// GetAllRoles mengambil semua role beserta permissions
 func (r *roleRepository) GetAllRoles(ctx context.Context) ([]models.Role, error) {
-    // Implementasi untuk mengambil semua roles
-    return nil, nil // Placeholder
+	rows, err := r.db.Query(ctx, roleSelectQuery+` ORDER BY r.name`)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	roles := []models.Role{}
+	for rows.Next() {
+		role, err := scanRoleRow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning role: %w", err)
+		}
+		roles = append(roles, *role)
+	}
+	return roles, rows.Err()
 }
+
+// GetRoleByID mengembalikan nil (tanpa error) jika role tidak ditemukan, sama seperti GetRoleByName
 func (r *roleRepository) GetRoleByID(ctx context.Context, roleID uuid.UUID) (*models.Role, error) {
-    // Implementasi untuk mengambil role berdasarkan ID
-    return nil, nil // Placeholder
-}
\ No newline at end of file
+	role, err := scanRoleRow(r.db.QueryRow(ctx, roleSelectQuery+` WHERE r.id = $1`, roleID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	return role, nil
+}
+
+func (r *roleRepository) CreateRole(ctx context.Context, role *models.Role) error {
+	if role.ID == uuid.Nil {
+		role.ID = uuid.New()
+	}
+	_, err := r.db.Exec(ctx, `INSERT INTO roles (id, name, description, created_at) VALUES ($1, $2, $3, NOW())`, role.ID, role.Name, role.Description)
+	if err != nil {
+		return fmt.Errorf("failed to create role: %w", err)
+	}
+	return nil
+}
+
+func (r *roleRepository) UpdateRole(ctx context.Context, role *models.Role) error {
+	cmd, err := r.db.Exec(ctx, `UPDATE roles SET name = $1, description = $2 WHERE id = $3`, role.Name, role.Description, role.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New("role not found")
+	}
+	return nil
+}
+
+// DeleteRole menghapus role beserta relasi permission-nya (cek pemakaian dilakukan di service)
+func (r *roleRepository) DeleteRole(ctx context.Context, roleID uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM role_permissions WHERE role_id = $1`, roleID); err != nil {
+		return fmt.Errorf("failed to delete role permissions: %w", err)
+	}
+	// Guard di level query: role yang masih dipakai user tidak ikut terhapus walau ada race
+	cmd, err := tx.Exec(ctx, `DELETE FROM roles WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM users WHERE role_id = $1)`, roleID)
+	if err != nil {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New("role not found or still assigned to users")
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *roleRepository) CountUsersWithRole(ctx context.Context, roleID uuid.UUID) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM users WHERE role_id = $1`, roleID).Scan(&count)
+	return count, err
+}
+
+// SetRolePermissions mengganti seluruh permission milik role dalam satu transaksi
+func (r *roleRepository) SetRolePermissions(ctx context.Context, roleID uuid.UUID, permissionIDs []uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM role_permissions WHERE role_id = $1`, roleID); err != nil {
+		return fmt.Errorf("failed to clear role permissions: %w", err)
+	}
+	if len(permissionIDs) > 0 {
+		query := `INSERT INTO role_permissions (role_id, permission_id) SELECT $1, UNNEST($2::uuid[]) ON CONFLICT DO NOTHING`
+		if _, err := tx.Exec(ctx, query, roleID, permissionIDs); err != nil {
+			return fmt.Errorf("failed to assign role permissions: %w", err)
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *roleRepository) AddRolePermission(ctx context.Context, roleID uuid.UUID, permissionID uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO role_permissions (role_id, permission_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, roleID, permissionID)
+	if err != nil {
+		return fmt.Errorf("failed to assign permission: %w", err)
+	}
+	return nil
+}
+
+func (r *roleRepository) RemoveRolePermission(ctx context.Context, roleID uuid.UUID, permissionID uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM role_permissions WHERE role_id = $1 AND permission_id = $2`, roleID, permissionID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke permission: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// StatsCache menyimpan hasil statistik dashboard yang sudah dihitung.
+// Tersedia backend in-memory (satu instance) dan Redis (dipakai bersama banyak instance).
+type StatsCache interface {
+	// GetOrCompute mengembalikan entri key, atau menjalankan compute dan menyimpan hasilnya selama ttl.
+	// Hasil compute yang berjalan bersamaan dengan Invalidate tidak disimpan agar angka lama tidak hidup kembali.
+	// Jika backend cache gagal, hasil compute tetap dikembalikan bersama error cache-nya.
+	GetOrCompute(ctx context.Context, key string, ttl time.Duration, compute func() ([]byte, error)) ([]byte, error)
+	// Invalidate membuang seluruh entri (dipanggil setiap ada transisi workflow)
+	Invalidate(ctx context.Context) error
+}
+
+// --- In-Memory ---
+
+type memoryCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+type inMemoryStatsCache struct {
+	mu         sync.Mutex
+	entries    map[string]memoryCacheEntry
+	generation uint64
+}
+
+func NewInMemoryStatsCache() StatsCache {
+	return &inMemoryStatsCache{entries: make(map[string]memoryCacheEntry)}
+}
+
+func (c *inMemoryStatsCache) GetOrCompute(ctx context.Context, key string, ttl time.Duration, compute func() ([]byte, error)) ([]byte, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	generation := c.generation
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.value, nil
+	}
+
+	value, err := compute()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation != generation {
+		return value, nil
+	}
+	// Buang entri kedaluwarsa agar map tidak tumbuh tanpa batas (satu entri per scope)
+	now := time.Now()
+	for k, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = memoryCacheEntry{value: value, expiresAt: now.Add(ttl)}
+	return value, nil
+}
+
+func (c *inMemoryStatsCache) Invalidate(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]memoryCacheEntry)
+	c.generation++
+	return nil
+}
+
+// --- Redis ---
+
+// redisStatsCache memakai nomor generasi: Invalidate cukup menaikkan generasi (INCR) sehingga
+// entri lama tidak terbaca lagi dan habis sendiri oleh TTL, tanpa perlu SCAN/DEL.
+type redisStatsCache struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+func NewRedisStatsCache(client redis.UniversalClient, prefix string) StatsCache {
+	return &redisStatsCache{client: client, prefix: prefix}
+}
+
+func (c *redisStatsCache) GetOrCompute(ctx context.Context, key string, ttl time.Duration, compute func() ([]byte, error)) ([]byte, error) {
+	generation, err := c.client.Get(ctx, c.prefix+":gen").Result()
+	if errors.Is(err, redis.Nil) {
+		generation, err = "0", nil
+	}
+	if err != nil {
+		return computeWithCacheError(compute, err)
+	}
+
+	entryKey := c.prefix + ":" + generation + ":" + key
+	value, err := c.client.Get(ctx, entryKey).Bytes()
+	if err == nil {
+		return value, nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		return computeWithCacheError(compute, err)
+	}
+
+	value, err = compute()
+	if err != nil {
+		return nil, err
+	}
+	// Bila generasi sudah naik selama compute, entri ini tersimpan di generasi lama dan tidak akan terbaca
+	if err := c.client.Set(ctx, entryKey, value, ttl).Err(); err != nil {
+		return value, fmt.Errorf("stats cache: %w", err)
+	}
+	return value, nil
+}
+
+func (c *redisStatsCache) Invalidate(ctx context.Context) error {
+	return c.client.Incr(ctx, c.prefix+":gen").Err()
+}
+
+func computeWithCacheError(compute func() ([]byte, error), cacheErr error) ([]byte, error) {
+	value, err := compute()
+	if err != nil {
+		return nil, err
+	}
+	return value, fmt.Errorf("stats cache: %w", cacheErr)
+}
@@ -0,0 +1,266 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"prestasi-mahasiswa-api/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// StatsRepository adalah satu-satunya jalur perhitungan statistik prestasi. Semua angka berangkat dari
+// himpunan referensi PostgreSQL yang aktif (is_deleted = FALSE); jenis & poin dari MongoDB hanya
+// dilengkapkan ke referensi tersebut, sehingga rekap per status dan per jenis selalu konsisten.
+// Hasilnya juga dimaterialisasi per (mahasiswa, status, jenis) di tabel achievement_stats.
+type StatsRepository interface {
+	// QueryStats menghitung langsung dari sumber dengan filter (tanpa agregat materialized)
+	QueryStats(ctx context.Context, scope models.AchievementScope, filter models.StatsFilter) ([]models.StatsAggregateRow, error)
+	// RefreshStudent menghitung ulang agregat satu mahasiswa (dipanggil setelah transisi workflow)
+	RefreshStudent(ctx context.Context, studentID uuid.UUID) error
+	// RebuildAll menghitung ulang seluruh agregat untuk membersihkan drift
+	RebuildAll(ctx context.Context) error
+	ListAggregates(ctx context.Context, scope models.AchievementScope) ([]models.StatsAggregateRow, error)
+}
+
+type statsRepository struct {
+	pgDB        *pgxpool.Pool
+	mongoClient *mongo.Client
+}
+
+func NewStatsRepository(pgDB *pgxpool.Pool, mongoClient *mongo.Client) StatsRepository {
+	return &statsRepository{pgDB: pgDB, mongoClient: mongoClient}
+}
+
+// statsQuerier dipenuhi pgxpool.Pool maupun pgx.Tx
+type statsQuerier interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+}
+
+// Program studi ditentukan oleh mahasiswa, jadi key tetap unik per (mahasiswa, status, jenis)
+type statsKey struct {
+	studentID       uuid.UUID
+	programStudy    string
+	status          string
+	achievementType string
+}
+
+type statsValue struct {
+	total  int
+	points int
+}
+
+type statsDocSummary struct {
+	ID              primitive.ObjectID `bson:"_id"`
+	AchievementType string             `bson:"achievementType"`
+	Points          int                `bson:"points"`
+}
+
+func (r *statsRepository) RefreshStudent(ctx context.Context, studentID uuid.UUID) error {
+	tx, err := r.pgDB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Refresh untuk mahasiswa yang sama dijalankan bergantian agar hasil terakhir tidak tertimpa hasil lama
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, "achievement_stats:"+studentID.String()); err != nil {
+		return fmt.Errorf("failed to lock student stats: %w", err)
+	}
+
+	aggregates, err := r.collect(ctx, tx, models.AchievementScope{StudentIDs: []uuid.UUID{studentID}}, models.StatsFilter{})
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM achievement_stats WHERE student_id = $1`, studentID); err != nil {
+		return fmt.Errorf("failed to clear student stats: %w", err)
+	}
+	if err := insertStats(ctx, tx, aggregates); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *statsRepository) RebuildAll(ctx context.Context) error {
+	tx, err := r.pgDB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Refresh per mahasiswa yang berjalan bersamaan menunggu rebuild selesai lalu menulis hasil yang lebih baru
+	if _, err := tx.Exec(ctx, `LOCK TABLE achievement_stats IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		return fmt.Errorf("failed to lock achievement_stats: %w", err)
+	}
+
+	aggregates, err := r.collect(ctx, tx, models.AchievementScope{All: true}, models.StatsFilter{})
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM achievement_stats`); err != nil {
+		return fmt.Errorf("failed to clear stats: %w", err)
+	}
+	if err := insertStats(ctx, tx, aggregates); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *statsRepository) QueryStats(ctx context.Context, scope models.AchievementScope, filter models.StatsFilter) ([]models.StatsAggregateRow, error) {
+	aggregates, err := r.collect(ctx, r.pgDB, scope, filter)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.StatsAggregateRow, 0, len(aggregates))
+	for key, value := range aggregates {
+		result = append(result, models.StatsAggregateRow{
+			StudentID: key.studentID, ProgramStudy: key.programStudy, Status: key.status,
+			AchievementType: key.achievementType, Total: value.total, Points: value.points,
+		})
+	}
+	return result, nil
+}
+
+// collect menghitung agregat dari himpunan referensi aktif dalam scope & filter
+func (r *statsRepository) collect(ctx context.Context, q statsQuerier, scope models.AchievementScope, filter models.StatsFilter) (map[statsKey]statsValue, error) {
+	query := `
+		SELECT ar.student_id, ar.mongo_achievement_id, ar.status, COALESCE(sp.name, s.program_study, '')
+		FROM achievement_references ar
+		LEFT JOIN students s ON s.user_id = ar.student_id
+		LEFT JOIN study_programs sp ON sp.id = s.study_program_id
+		WHERE ar.is_deleted = FALSE`
+	args := []interface{}{}
+	if !scope.All {
+		args = append(args, scope.StudentIDs)
+		query += fmt.Sprintf(" AND ar.student_id = ANY($%d)", len(args))
+	}
+	if len(filter.Statuses) > 0 {
+		args = append(args, filter.Statuses)
+		query += fmt.Sprintf(" AND ar.status = ANY($%d)", len(args))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		query += fmt.Sprintf(" AND ar.created_at >= $%d", len(args))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		query += fmt.Sprintf(" AND ar.created_at <= $%d", len(args))
+	}
+	if filter.StudyProgramID != nil {
+		args = append(args, *filter.StudyProgramID)
+		query += fmt.Sprintf(" AND s.study_program_id = $%d", len(args))
+	}
+
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	type reference struct {
+		key     statsKey
+		mongoID string
+	}
+	references := []reference{}
+	objIDs := []primitive.ObjectID{}
+	for rows.Next() {
+		var ref reference
+		if err := rows.Scan(&ref.key.studentID, &ref.mongoID, &ref.key.status, &ref.key.programStudy); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("error scanning achievement reference: %w", err)
+		}
+		if objID, err := primitive.ObjectIDFromHex(ref.mongoID); err == nil {
+			objIDs = append(objIDs, objID)
+		}
+		references = append(references, ref)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	aggregates := make(map[statsKey]statsValue)
+	if len(references) == 0 {
+		return aggregates, nil
+	}
+
+	// Rebuild penuh membaca seluruh koleksi sekali jalan daripada $in berisi semua ID
+	filterDocs := bson.M{"isDeleted": false}
+	if !scope.All || !filter.IsZero() {
+		filterDocs["_id"] = bson.M{"$in": objIDs}
+	}
+	coll := r.mongoClient.Database(MongoDatabaseName).Collection(MongoCollectionAchievements)
+	cursor, err := coll.Find(ctx, filterDocs, options.Find().SetProjection(bson.M{"achievementType": 1, "points": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var docs []statsDocSummary
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	docByID := make(map[string]statsDocSummary, len(docs))
+	for _, doc := range docs {
+		docByID[doc.ID.Hex()] = doc
+	}
+
+	for _, ref := range references {
+		// Referensi tanpa dokumen tetap dihitung dengan jenis kosong (dilaporkan sebagai "unknown")
+		doc := docByID[ref.mongoID]
+		key := ref.key
+		key.achievementType = doc.AchievementType
+		value := aggregates[key]
+		value.total++
+		value.points += doc.Points
+		aggregates[key] = value
+	}
+	return aggregates, nil
+}
+
+func insertStats(ctx context.Context, tx pgx.Tx, aggregates map[statsKey]statsValue) error {
+	if len(aggregates) == 0 {
+		return nil
+	}
+	rows := make([][]interface{}, 0, len(aggregates))
+	for key, value := range aggregates {
+		rows = append(rows, []interface{}{key.studentID, key.status, key.achievementType, value.total, value.points})
+	}
+	_, err := tx.CopyFrom(ctx, pgx.Identifier{"achievement_stats"},
+		[]string{"student_id", "status", "achievement_type", "total", "points"}, pgx.CopyFromRows(rows))
+	if err != nil {
+		return fmt.Errorf("failed to write achievement stats: %w", err)
+	}
+	return nil
+}
+
+func (r *statsRepository) ListAggregates(ctx context.Context, scope models.AchievementScope) ([]models.StatsAggregateRow, error) {
+	query := `
+		SELECT st.student_id, COALESCE(sp.name, s.program_study, ''), st.status, st.achievement_type, st.total, st.points
+		FROM achievement_stats st
+		LEFT JOIN students s ON s.user_id = st.student_id
+		LEFT JOIN study_programs sp ON sp.id = s.study_program_id`
+	args := []interface{}{}
+	if !scope.All {
+		query += ` WHERE st.student_id = ANY($1)`
+		args = append(args, scope.StudentIDs)
+	}
+
+	rows, err := r.pgDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []models.StatsAggregateRow{}
+	for rows.Next() {
+		var row models.StatsAggregateRow
+		if err := rows.Scan(&row.StudentID, &row.ProgramStudy, &row.Status, &row.AchievementType, &row.Total, &row.Points); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"prestasi-mahasiswa-api/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TranscriptRepository menyimpan SKPI yang diterbitkan agar kodenya dapat diverifikasi publik
+type TranscriptRepository interface {
+	Create(ctx context.Context, transcript *models.StudentTranscript) error
+	GetVerificationByCode(ctx context.Context, code string) (*models.TranscriptVerification, error)
+}
+
+type transcriptRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTranscriptRepository(db *pgxpool.Pool) TranscriptRepository {
+	return &transcriptRepository{db: db}
+}
+
+func (r *transcriptRepository) Create(ctx context.Context, transcript *models.StudentTranscript) error {
+	if transcript.ID == uuid.Nil {
+		transcript.ID = uuid.New()
+	}
+	query := `
+		INSERT INTO student_transcripts (id, code, student_user_id, issued_by, total_achievements, total_points)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING issued_at`
+	return r.db.QueryRow(ctx, query, transcript.ID, transcript.Code, transcript.StudentUserID, transcript.IssuedBy,
+		transcript.TotalAchievements, transcript.TotalPoints).Scan(&transcript.IssuedAt)
+}
+
+// GetVerificationByCode mengembalikan nil, nil jika kode tidak terdaftar
+func (r *transcriptRepository) GetVerificationByCode(ctx context.Context, code string) (*models.TranscriptVerification, error) {
+	query := `
+		SELECT t.code, u.full_name, COALESCE(s.student_id, ''), COALESCE(s.program_study, ''),
+		       t.total_achievements, t.total_points, t.issued_at
+		FROM student_transcripts t
+		JOIN users u ON u.id = t.student_user_id
+		LEFT JOIN students s ON s.user_id = t.student_user_id
+		WHERE t.code = $1`
+	v := models.TranscriptVerification{Valid: true}
+	err := r.db.QueryRow(ctx, query, code).Scan(&v.Code, &v.StudentName, &v.StudentNumber, &v.ProgramStudy,
+		&v.TotalAchievements, &v.TotalPoints, &v.IssuedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
@@ -0,0 +1,177 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"prestasi-mahasiswa-api/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrImportRolledBack dikembalikan jika import transactional dibatalkan karena ada baris yang gagal
+var ErrImportRolledBack = errors.New("import rolled back")
+
+// UserImportRepository menyimpan hasil bulk import user beserta profilnya
+type UserImportRepository interface {
+	FindExisting(ctx context.Context, usernames, emails, nims, nips []string) (*models.UserImportExisting, error)
+	FindLecturerIDsByNIP(ctx context.Context, nips []string) (map[string]uuid.UUID, error)
+	ImportUsers(ctx context.Context, records []models.UserImportRecord, atomic bool) ([]error, error)
+}
+
+type userImportRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewUserImportRepository(db *pgxpool.Pool) UserImportRepository {
+	return &userImportRepository{db: db}
+}
+
+// FindExisting mengecek sekaligus username, email, NIM dan NIP yang sudah terdaftar (username/email case-insensitive)
+func (r *userImportRepository) FindExisting(ctx context.Context, usernames, emails, nims, nips []string) (*models.UserImportExisting, error) {
+	existing := &models.UserImportExisting{
+		Usernames: map[string]bool{}, Emails: map[string]bool{}, NIMs: map[string]bool{}, NIPs: map[string]bool{},
+	}
+
+	lookups := []struct {
+		query  string
+		values []string
+		target map[string]bool
+	}{
+		{`SELECT LOWER(username) FROM users WHERE LOWER(username) = ANY($1)`, lowerAll(usernames), existing.Usernames},
+		{`SELECT LOWER(email) FROM users WHERE LOWER(email) = ANY($1)`, lowerAll(emails), existing.Emails},
+		{`SELECT student_id FROM students WHERE student_id = ANY($1)`, nims, existing.NIMs},
+		{`SELECT lecturer_id FROM lecturers WHERE lecturer_id = ANY($1)`, nips, existing.NIPs},
+	}
+	for _, l := range lookups {
+		if len(l.values) == 0 {
+			continue
+		}
+		rows, err := r.db.Query(ctx, l.query, l.values)
+		if err != nil {
+			return nil, fmt.Errorf("database query failed: %w", err)
+		}
+		for rows.Next() {
+			var v string
+			if err := rows.Scan(&v); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			l.target[v] = true
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return existing, nil
+}
+
+// FindLecturerIDsByNIP memetakan NIP ke ID tabel lecturers (untuk kolom advisor_nip)
+func (r *userImportRepository) FindLecturerIDsByNIP(ctx context.Context, nips []string) (map[string]uuid.UUID, error) {
+	result := map[string]uuid.UUID{}
+	if len(nips) == 0 {
+		return result, nil
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT lecturer_id, id FROM lecturers WHERE lecturer_id = ANY($1)`, nips)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var nip string
+		var id uuid.UUID
+		if err := rows.Scan(&nip, &id); err != nil {
+			return nil, err
+		}
+		result[nip] = id
+	}
+	return result, rows.Err()
+}
+
+// ImportUsers menyimpan semua record dalam satu transaksi. Setiap baris memakai savepoint sendiri:
+// pada mode atomic kegagalan satu baris membatalkan seluruh import (ErrImportRolledBack),
+// pada mode partial hanya baris itu yang dibatalkan. Slice error sejajar dengan records.
+func (r *userImportRepository) ImportUsers(ctx context.Context, records []models.UserImportRecord, atomic bool) ([]error, error) {
+	rowErrors := make([]error, len(records))
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return rowErrors, err
+	}
+	defer tx.Rollback(ctx)
+
+	for i, record := range records {
+		if err := importUserRecord(ctx, tx, record); err != nil {
+			rowErrors[i] = err
+			if atomic {
+				return rowErrors, ErrImportRolledBack
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return rowErrors, fmt.Errorf("failed to commit import: %w", err)
+	}
+	return rowErrors, nil
+}
+
+func importUserRecord(ctx context.Context, tx pgx.Tx, record models.UserImportRecord) error {
+	sp, err := tx.Begin(ctx) // savepoint
+	if err != nil {
+		return err
+	}
+	defer sp.Rollback(ctx)
+
+	u := record.User
+	_, err = sp.Exec(ctx, `
+		INSERT INTO users (id, username, email, password_hash, full_name, role_id, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())`,
+		u.ID, u.Username, u.Email, u.PasswordHash, u.FullName, u.RoleID, u.IsActive)
+	if err != nil {
+		return fmt.Errorf("failed to insert user: %w", err)
+	}
+
+	if l := record.Lecturer; l != nil {
+		_, err = sp.Exec(ctx, `
+			INSERT INTO lecturers (id, user_id, lecturer_id, department, department_id, created_at)
+			VALUES ($1, $2, $3, $4, $5, NOW())`,
+			l.ID, l.UserID, l.LecturerID, l.Department, l.DepartmentID)
+		if err != nil {
+			return fmt.Errorf("failed to insert lecturer profile: %w", err)
+		}
+	}
+
+	if s := record.Student; s != nil {
+		_, err = sp.Exec(ctx, `
+			INSERT INTO students (id, user_id, student_id, program_study, study_program_id, academic_year, advisor_id, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())`,
+			s.ID, s.UserID, s.StudentID, s.ProgramStudy, s.StudyProgramID, s.AcademicYear, s.AdvisorID)
+		if err != nil {
+			return fmt.Errorf("failed to insert student profile: %w", err)
+		}
+		if s.AdvisorID != nil {
+			_, err = sp.Exec(ctx, `
+				INSERT INTO advisor_assignments (student_id, lecturer_id, effective_from, reason)
+				VALUES ($1, $2, NOW(), 'import')`, s.ID, s.AdvisorID)
+			if err != nil {
+				return fmt.Errorf("failed to record advisor assignment: %w", err)
+			}
+		}
+	}
+
+	return sp.Commit(ctx)
+}
+
+func lowerAll(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		result = append(result, strings.ToLower(v))
+	}
+	return result
+}
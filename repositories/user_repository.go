@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"prestasi-mahasiswa-api/models"
 
@@ -17,12 +18,23 @@ type UserRepository interface {
 	FindUserByUsernameOrEmail(ctx context.Context, identifier string) (*models.User, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error)
 	GetAdviseeStudentUserIDsByAdvisorUserID(ctx context.Context, advisorUserID uuid.UUID) ([]uuid.UUID, error)
+	GetDepartmentStudentUserIDsByUserID(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	GetProgramStudentUserIDsByHeadUserID(ctx context.Context, headUserID uuid.UUID) ([]uuid.UUID, error)
+
+	// Pencocokan identitas SSO (NIM/NIP/email)
+	FindUserByStudentNumber(ctx context.Context, nim string) (*models.User, error)
+	FindUserByLecturerNumber(ctx context.Context, nip string) (*models.User, error)
+	FindUserByEmail(ctx context.Context, email string) (*models.User, error)
 	
 	// Admin CRUD
 	CreateUser(ctx context.Context, user *models.User) (*models.User, error)
 	UpdateUser(ctx context.Context, userID uuid.UUID, req *models.UpdateUserRequest, roleID *uuid.UUID) (*models.User, error)
 	DeleteUser(ctx context.Context, userID uuid.UUID) error
-	ListAllUsers(ctx context.Context) ([]models.User, error)
+	ListUsers(ctx context.Context, query models.UserListQuery) ([]models.UserListItem, int, error)
+	FindDuplicateFields(ctx context.Context, fields models.UserUniqueFields, excludeUserID uuid.UUID) ([]string, error)
+
+	// Password & Session
+	UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error
 }
 
 type userRepository struct {
@@ -49,6 +61,8 @@ func scanUserRow(row pgx.Row) (*models.User, error) {
 		&user.Role, 
 		&user.IsActive, 
 		&permissionsPgArray,
+		&user.SessionVersion,
+		&user.AuthSource,
 	)
 
 	if err != nil {
@@ -72,10 +86,12 @@ func (r *userRepository) FindUserByUsernameOrEmail(ctx context.Context, identifi
                 FROM role_permissions rp 
                 JOIN permissions p ON rp.permission_id = p.id 
                 WHERE rp.role_id = r.id
-            ) AS permissions
+            ) AS permissions,
+            u.session_version,
+            u.auth_source
         FROM users u
         JOIN roles r ON u.role_id = r.id
-        WHERE u.username = $1 OR u.email = $1
+        WHERE u.username = $1 OR LOWER(u.email) = LOWER($1)
     `
 	return scanUserRow(r.db.QueryRow(ctx, query, strings.ToLower(identifier)))
 }
@@ -89,7 +105,9 @@ func (r *userRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*models
                 FROM role_permissions rp 
                 JOIN permissions p ON rp.permission_id = p.id 
                 WHERE rp.role_id = r.id
-            ) AS permissions
+            ) AS permissions,
+            u.session_version,
+            u.auth_source
         FROM users u
         JOIN roles r ON u.role_id = r.id
         WHERE u.id = $1
@@ -97,6 +115,68 @@ func (r *userRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*models
 	return scanUserRow(r.db.QueryRow(ctx, query, id))
 }
 
+// FindUserByStudentNumber mencari user Mahasiswa berdasarkan NIM
+func (r *userRepository) FindUserByStudentNumber(ctx context.Context, nim string) (*models.User, error) {
+	query := `
+        SELECT 
+            u.id, u.username, u.email, u.password_hash, u.full_name, u.role_id, r.name AS role, u.is_active, 
+            ARRAY(
+                SELECT p.name 
+                FROM role_permissions rp 
+                JOIN permissions p ON rp.permission_id = p.id 
+                WHERE rp.role_id = r.id
+            ) AS permissions,
+            u.session_version,
+            u.auth_source
+        FROM users u
+        JOIN roles r ON u.role_id = r.id
+        JOIN students s ON s.user_id = u.id
+        WHERE s.student_id = $1
+    `
+	return scanUserRow(r.db.QueryRow(ctx, query, nim))
+}
+
+// FindUserByLecturerNumber mencari user Dosen berdasarkan NIP
+func (r *userRepository) FindUserByLecturerNumber(ctx context.Context, nip string) (*models.User, error) {
+	query := `
+        SELECT 
+            u.id, u.username, u.email, u.password_hash, u.full_name, u.role_id, r.name AS role, u.is_active, 
+            ARRAY(
+                SELECT p.name 
+                FROM role_permissions rp 
+                JOIN permissions p ON rp.permission_id = p.id 
+                WHERE rp.role_id = r.id
+            ) AS permissions,
+            u.session_version,
+            u.auth_source
+        FROM users u
+        JOIN roles r ON u.role_id = r.id
+        JOIN lecturers l ON l.user_id = u.id
+        WHERE l.lecturer_id = $1
+    `
+	return scanUserRow(r.db.QueryRow(ctx, query, nip))
+}
+
+// FindUserByEmail hanya mencocokkan kolom email (tidak username) untuk menautkan akun SSO
+func (r *userRepository) FindUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	query := `
+        SELECT 
+            u.id, u.username, u.email, u.password_hash, u.full_name, u.role_id, r.name AS role, u.is_active, 
+            ARRAY(
+                SELECT p.name 
+                FROM role_permissions rp 
+                JOIN permissions p ON rp.permission_id = p.id 
+                WHERE rp.role_id = r.id
+            ) AS permissions,
+            u.session_version,
+            u.auth_source
+        FROM users u
+        JOIN roles r ON u.role_id = r.id
+        WHERE LOWER(u.email) = LOWER($1)
+    `
+	return scanUserRow(r.db.QueryRow(ctx, query, email))
+}
+
 func (r *userRepository) GetAdviseeStudentUserIDsByAdvisorUserID(ctx context.Context, advisorUserID uuid.UUID) ([]uuid.UUID, error) {
     query := `
         SELECT s.user_id
@@ -121,21 +201,72 @@ func (r *userRepository) GetAdviseeStudentUserIDsByAdvisorUserID(ctx context.Con
     return studentUserIDs, nil
 }
 
+// GetDepartmentStudentUserIDsByUserID mengambil mahasiswa yang prodinya berada di departemen user
+// (departemen dosen atau staf fakultas)
+func (r *userRepository) GetDepartmentStudentUserIDsByUserID(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		SELECT s.user_id
+		FROM students s
+		JOIN study_programs sp ON s.study_program_id = sp.id
+		WHERE sp.department_id IN (
+			SELECT department_id FROM lecturers WHERE user_id = $1 AND department_id IS NOT NULL
+			UNION
+			SELECT department_id FROM faculty_staff WHERE user_id = $1
+		)
+	`
+	return r.queryStudentUserIDs(ctx, query, userID)
+}
+
+// GetProgramStudentUserIDsByHeadUserID mengambil mahasiswa di prodi yang dipimpin user (Kaprodi)
+func (r *userRepository) GetProgramStudentUserIDsByHeadUserID(ctx context.Context, headUserID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		SELECT s.user_id
+		FROM students s
+		JOIN study_programs sp ON s.study_program_id = sp.id
+		JOIN lecturers l ON sp.head_lecturer_id = l.id
+		WHERE l.user_id = $1
+	`
+	return r.queryStudentUserIDs(ctx, query, headUserID)
+}
+
+func (r *userRepository) queryStudentUserIDs(ctx context.Context, query string, args ...interface{}) ([]uuid.UUID, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	var studentUserIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error scanning student ID: %w", err)
+		}
+		studentUserIDs = append(studentUserIDs, id)
+	}
+	return studentUserIDs, nil
+}
+
 // --- Admin CRUD Implementation ---
 
 func (r *userRepository) CreateUser(ctx context.Context, user *models.User) (*models.User, error) {
 	query := `
-		INSERT INTO users (id, username, email, password_hash, full_name, role_id, is_active, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+		INSERT INTO users (id, username, email, password_hash, full_name, role_id, is_active, auth_source, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
 		RETURNING id`
+
+	authSource := user.AuthSource
+	if authSource == "" {
+		authSource = models.AuthSourceLocal
+	}
 	
 	var createdID uuid.UUID
 	err := r.db.QueryRow(ctx, query,
-		user.ID, user.Username, user.Email, user.PasswordHash, user.FullName, user.RoleID, user.IsActive,
+		user.ID, user.Username, user.Email, user.PasswordHash, user.FullName, user.RoleID, user.IsActive, authSource,
 	).Scan(&createdID)
 	
 	if err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
+		return nil, fmt.Errorf("failed to create user: %w", asDuplicateFieldError(err))
 	}
 	return r.GetUserByID(ctx, createdID)
 }
@@ -169,54 +300,187 @@ func (r *userRepository) UpdateUser(ctx context.Context, userID uuid.UUID, req *
 
 	_, err := r.db.Exec(ctx, query, values...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update user: %w", err)
+		return nil, fmt.Errorf("failed to update user: %w", asDuplicateFieldError(err))
 	}
 	return r.GetUserByID(ctx, userID)
 }
 
+// FindDuplicateFields mengembalikan nama field (JSON) yang nilainya sudah dipakai user lain
+func (r *userRepository) FindDuplicateFields(ctx context.Context, fields models.UserUniqueFields, excludeUserID uuid.UUID) ([]string, error) {
+	query := `
+		SELECT
+			$1 <> '' AND EXISTS (SELECT 1 FROM users WHERE LOWER(username) = LOWER($1) AND id <> $5),
+			$2 <> '' AND EXISTS (SELECT 1 FROM users WHERE LOWER(email) = LOWER($2) AND id <> $5),
+			$3 <> '' AND EXISTS (SELECT 1 FROM students WHERE student_id = $3 AND user_id <> $5),
+			$4 <> '' AND EXISTS (SELECT 1 FROM lecturers WHERE lecturer_id = $4 AND user_id <> $5)`
+
+	var username, email, studentID, lecturerID bool
+	err := r.db.QueryRow(ctx, query, fields.Username, fields.Email, fields.StudentID, fields.LecturerID, excludeUserID).
+		Scan(&username, &email, &studentID, &lecturerID)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+
+	duplicates := []string{}
+	if username {
+		duplicates = append(duplicates, "username")
+	}
+	if email {
+		duplicates = append(duplicates, "email")
+	}
+	if studentID {
+		duplicates = append(duplicates, "studentId")
+	}
+	if lecturerID {
+		duplicates = append(duplicates, "lecturerId")
+	}
+	return duplicates, nil
+}
+
 func (r *userRepository) DeleteUser(ctx context.Context, userID uuid.UUID) error {
 	query := `UPDATE users SET is_active = false, updated_at = NOW() WHERE id = $1`
 	_, err := r.db.Exec(ctx, query, userID)
 	return err
 }
 
-func (r *userRepository) ListAllUsers(ctx context.Context) ([]models.User, error) {
-	query := `
-		SELECT 
-			u.id, u.username, u.email, u.password_hash, u.full_name, u.role_id, r.name AS role_name, u.is_active, 
-            ARRAY(
-                SELECT p.name 
-                FROM role_permissions rp 
-                JOIN permissions p ON rp.permission_id = p.id 
-                WHERE rp.role_id = r.id
-            ) AS permissions
+// UpdatePassword mengganti hash password dan mencabut semua sesi (token) yang sudah terbit
+func (r *userRepository) UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error {
+	query := `UPDATE users SET password_hash = $1, session_version = session_version + 1, updated_at = NOW() WHERE id = $2`
+	cmd, err := r.db.Exec(ctx, query, passwordHash, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+// userSortColumns memetakan parameter sort ke kolom SQL (whitelist, mencegah injeksi)
+var userSortColumns = map[string]string{
+	models.UserSortUsername:   "u.username",
+	models.UserSortName:       "u.full_name",
+	models.UserSortEmail:      "LOWER(u.email)",
+	models.UserSortRole:       "r.name",
+	models.UserSortCreatedAt:  "u.created_at",
+	models.UserSortStudentID:  "s.student_id",
+	models.UserSortLecturerID: "l.lecturer_id",
+}
+
+// ListUsers mengembalikan satu halaman user beserta profil mahasiswa/dosen dan total data yang cocok
+func (r *userRepository) ListUsers(ctx context.Context, q models.UserListQuery) ([]models.UserListItem, int, error) {
+	from := `
 		FROM users u
 		JOIN roles r ON u.role_id = r.id
-		ORDER BY u.username`
-	
-	rows, err := r.db.Query(ctx, query)
+		LEFT JOIN students s ON s.user_id = u.id
+		LEFT JOIN lecturers l ON l.user_id = u.id
+		LEFT JOIN lecturers adv ON adv.id = s.advisor_id
+		WHERE 1=1`
+	args := []interface{}{}
+
+	if q.Search != "" {
+		args = append(args, "%"+escapeLike(q.Search)+"%")
+		from += fmt.Sprintf(` AND (u.full_name ILIKE $%[1]d OR u.username ILIKE $%[1]d OR u.email ILIKE $%[1]d
+			OR s.student_id ILIKE $%[1]d OR l.lecturer_id ILIKE $%[1]d)`, len(args))
+	}
+	if q.Role != "" {
+		args = append(args, q.Role)
+		from += fmt.Sprintf(" AND LOWER(r.name) = LOWER($%d)", len(args))
+	}
+	if q.IsActive != nil {
+		args = append(args, *q.IsActive)
+		from += fmt.Sprintf(" AND u.is_active = $%d", len(args))
+	}
+	if q.StudyProgramID != nil {
+		args = append(args, *q.StudyProgramID)
+		from += fmt.Sprintf(" AND s.study_program_id = $%d", len(args))
+	} else if q.ProgramStudy != "" {
+		args = append(args, q.ProgramStudy)
+		from += fmt.Sprintf(" AND LOWER(s.program_study) = LOWER($%d)", len(args))
+	}
+	if q.AdvisorUserID != nil {
+		args = append(args, *q.AdvisorUserID)
+		from += fmt.Sprintf(" AND adv.user_id = $%d", len(args))
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*)`+from, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("database query failed: %w", err)
+	}
+
+	sortColumn, ok := userSortColumns[q.SortBy]
+	if !ok {
+		sortColumn = "u.username"
+	}
+	direction := "ASC"
+	if q.SortDesc {
+		direction = "DESC"
+	}
+
+	args = append(args, q.Limit, (q.Page-1)*q.Limit)
+	query := `
+		SELECT
+			u.id, u.username, u.email, u.full_name, u.role_id, r.name, u.is_active, u.created_at, u.updated_at,
+			s.id, s.student_id, s.program_study, s.study_program_id, s.academic_year, s.advisor_id, s.created_at,
+			l.id, l.lecturer_id, l.department, l.department_id, l.created_at` + from +
+		fmt.Sprintf(" ORDER BY %s %s NULLS LAST, u.id LIMIT $%d OFFSET $%d", sortColumn, direction, len(args)-1, len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-        return nil, fmt.Errorf("database query failed: %w", err)
-    }
-    defer rows.Close()
+		return nil, 0, fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
 
-    var users []models.User
-    for rows.Next() {
-        // Kita tidak bisa pakai scanUserRow karena itu untuk QueryRow (pgx.Row) bukan pgx.Rows
-		user := models.User{}
-        var permissionsPgArray []string
-        var roleID uuid.UUID 
-
-        err := rows.Scan(
-            &user.ID, &user.Username, &user.Email, &user.PasswordHash,
-            &user.FullName, &roleID, &user.Role, &user.IsActive, &permissionsPgArray,
-        )
-        if err != nil {
-            return nil, err
-        }
-        user.RoleID = roleID
-        user.Permissions = permissionsPgArray
-        users = append(users, user)
-    }
-	return users, nil
-}
\ No newline at end of file
+	items := []models.UserListItem{}
+	for rows.Next() {
+		var item models.UserListItem
+		var studentRowID, lecturerRowID, studyProgramID, advisorID, departmentID *uuid.UUID
+		var nim, programStudy, academicYear, nip, department *string
+		var studentCreatedAt, lecturerCreatedAt *time.Time
+
+		err := rows.Scan(
+			&item.ID, &item.Username, &item.Email, &item.FullName, &item.RoleID, &item.Role, &item.IsActive, &item.CreatedAt, &item.UpdatedAt,
+			&studentRowID, &nim, &programStudy, &studyProgramID, &academicYear, &advisorID, &studentCreatedAt,
+			&lecturerRowID, &nip, &department, &departmentID, &lecturerCreatedAt,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// Profil hanya disertakan jika baris LEFT JOIN-nya ada
+		if studentRowID != nil {
+			item.Student = &models.Student{
+				ID: *studentRowID, UserID: item.ID, StudentID: derefString(nim), ProgramStudy: derefString(programStudy),
+				StudyProgramID: studyProgramID, AcademicYear: derefString(academicYear), AdvisorID: advisorID,
+				CreatedAt: derefTime(studentCreatedAt),
+			}
+		}
+		if lecturerRowID != nil {
+			item.Lecturer = &models.Lecturer{
+				ID: *lecturerRowID, UserID: item.ID, LecturerID: derefString(nip), Department: derefString(department),
+				DepartmentID: departmentID, CreatedAt: derefTime(lecturerCreatedAt),
+			}
+		}
+		items = append(items, item)
+	}
+	return items, total, rows.Err()
+}
+
+// escapeLike meloloskan wildcard LIKE agar kata kunci dicocokkan apa adanya
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func derefTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
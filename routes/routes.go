@@ -1,19 +1,26 @@
 package routes
 
 import (
+	"context"
+	"log"
+	"time"
+
 	"prestasi-mahasiswa-api/controllers"
 	"prestasi-mahasiswa-api/middleware"
 	"prestasi-mahasiswa-api/repositories"
 	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/swagger"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-// SetupRoutes: Hanya berisi definisi endpoint (Tidak ada logic handler/func)
-func SetupRoutes(app *fiber.App, pgDB *pgxpool.Pool, mongoClient *mongo.Client) {
+// SetupRoutes: Hanya berisi definisi endpoint (Tidak ada logic handler/func).
+// Mengembalikan fungsi untuk menjalankan background job; job berhenti saat ctx dibatalkan.
+func SetupRoutes(app *fiber.App, pgDB *pgxpool.Pool, mongoClient *mongo.Client) (startJobs func(ctx context.Context)) {
 	// 1. Dependency Injection (Layering: Repo -> Service -> Controller)
 	
 	// Repositories
@@ -21,19 +28,89 @@ func SetupRoutes(app *fiber.App, pgDB *pgxpool.Pool, mongoClient *mongo.Client)
 	achieveRepo := repositories.NewAchievementRepository(pgDB, mongoClient)
 	roleRepo := repositories.NewRoleRepository(pgDB) 
 	profileRepo := repositories.NewProfileRepository(pgDB)
+	resetRepo := repositories.NewPasswordResetRepository(pgDB)
+	mfaRepo := repositories.NewMFARepository(pgDB)
+	permissionRepo := repositories.NewPermissionRepository(pgDB)
+	unitRepo := repositories.NewAcademicUnitRepository(pgDB)
+	importRepo := repositories.NewUserImportRepository(pgDB)
+	advisorRepo := repositories.NewAdvisorRepository(pgDB)
+	profileChangeRepo := repositories.NewProfileChangeRepository(pgDB)
+	transcriptRepo := repositories.NewTranscriptRepository(pgDB)
+	statsRepo := repositories.NewStatsRepository(pgDB, mongoClient)
+
+	var loginAttemptStore repositories.LoginAttemptStore
+	if utils.GetEnv("LOGIN_ATTEMPT_STORE", "postgres") == "memory" {
+		loginAttemptStore = repositories.NewInMemoryLoginAttemptStore()
+	} else {
+		loginAttemptStore = repositories.NewPostgresLoginAttemptStore(pgDB)
+	}
+
+	var statsCache repositories.StatsCache
+	if utils.GetEnv("STATS_CACHE_BACKEND", "memory") == "redis" {
+		redisOptions, err := redis.ParseURL(utils.GetEnv("REDIS_URL", "redis://localhost:6379/0"))
+		if err != nil {
+			log.Fatalf("Invalid REDIS_URL: %v", err)
+		}
+		statsCache = repositories.NewRedisStatsCache(redis.NewClient(redisOptions), utils.GetEnv("STATS_CACHE_PREFIX", "prestasi:stats"))
+	} else {
+		statsCache = repositories.NewInMemoryStatsCache()
+	}
 
 	// Services
-	authService := services.NewAuthService(userRepo)
-	achieveService := services.NewAchievementService(achieveRepo, userRepo)
-	userService := services.NewUserService(userRepo, roleRepo, profileRepo) // NEW: User Service
-	reportService := services.NewReportService(achieveRepo)
+	loginGuard := services.NewLoginGuard(loginAttemptStore, services.LoginGuardConfigFromEnv())
+	mfaService := services.NewMFAService(mfaRepo, userRepo, utils.GetEnv("MFA_ISSUER", "Prestasi Mahasiswa"))
+	authenticator := services.NewLocalAuthenticator()
+	if utils.GetEnv("AUTH_BACKEND", "local") == "ldap" {
+		authenticator = services.NewLDAPAuthenticator(services.LDAPConfigFromEnv(), userRepo, roleRepo, authenticator)
+	}
+	authService := services.NewAuthService(userRepo, resetRepo, profileRepo, loginGuard, mfaService, authenticator)
+	oidcService := services.NewOIDCService(services.OIDCConfigFromEnv(), userRepo, roleRepo, profileRepo, mfaService, loginGuard)
+	accessPolicy := services.NewAccessPolicy(userRepo)
+	statsConfig := services.StatsConfigFromEnv()
+	statsAggregator := services.NewStatsAggregator(statsRepo, statsCache, statsConfig)
+	achieveService := services.NewAchievementService(achieveRepo, userRepo, accessPolicy, statsAggregator)
+	userService := services.NewUserService(userRepo, roleRepo, profileRepo, resetRepo, loginGuard, unitRepo, statsAggregator) // NEW: User Service
+	reportService := services.NewReportService(achieveRepo, profileRepo, accessPolicy, statsAggregator)
+	roleService := services.NewRoleService(roleRepo, permissionRepo)
+	unitService := services.NewAcademicUnitService(unitRepo, profileRepo, statsAggregator)
+	profileService := services.NewProfileService(profileRepo)
+	advisorService := services.NewAdvisorService(advisorRepo, profileRepo, unitRepo, statsAggregator)
+	profileChangeService := services.NewProfileChangeService(profileChangeRepo, userRepo, profileRepo, unitRepo, statsAggregator)
+	exportService := services.NewExportService(achieveRepo, reportService, accessPolicy)
+	accreditationService := services.NewAccreditationService(achieveRepo, unitRepo, accessPolicy, services.AccreditationMappingFromEnv())
+	transcriptService := services.NewTranscriptService(achieveRepo, profileRepo, transcriptRepo, accessPolicy, services.TranscriptConfigFromEnv())
+	importService := services.NewUserImportService(importRepo, roleRepo, unitRepo, resetRepo, services.UserImportConfigFromEnv(), statsAggregator)
+
+	// Token yang sudah dicabut (ganti/reset password) ditolak di AuthRequired
+	middleware.SetSessionValidator(authService)
 
+	// Background Jobs (dijalankan dari main dengan context yang dibatalkan saat shutdown)
+	startJobs = func(ctx context.Context) {
+		services.StartTrashPurgeJob(
+			ctx,
+			achieveService,
+			time.Duration(utils.GetEnvInt("TRASH_RETENTION_DAYS", 30))*24*time.Hour,
+			utils.GetEnvPositiveDuration("TRASH_PURGE_INTERVAL", time.Hour),
+		)
+		services.StartStatsRebuildJob(ctx, statsAggregator, statsConfig.RebuildInterval)
+	}
 
 	// Controllers
 	authController := controllers.NewAuthController(authService)
+	mfaController := controllers.NewMFAController(mfaService)
+	oidcController := controllers.NewOIDCController(oidcService)
+	roleController := controllers.NewRoleController(roleService)
+	unitController := controllers.NewAcademicUnitController(unitService)
+	importController := controllers.NewUserImportController(importService)
+	advisorController := controllers.NewAdvisorController(advisorService)
+	profileController := controllers.NewProfileController(profileService)
+	profileChangeController := controllers.NewProfileChangeController(profileChangeService)
 	achieveController := controllers.NewAchievementController(achieveService)
 	userController := controllers.NewUserController(userService)
 	reportController := controllers.NewReportController(reportService) // NEW: User Controller
+	exportController := controllers.NewExportController(exportService)
+	transcriptController := controllers.NewTranscriptController(transcriptService)
+	accreditationController := controllers.NewAccreditationController(accreditationService)
 
 // --- SWAGGER ROUTE ---
     app.Get("/swagger/*", swagger.HandlerDefault) // Tambahkan ini
@@ -47,6 +124,22 @@ func SetupRoutes(app *fiber.App, pgDB *pgxpool.Pool, mongoClient *mongo.Client)
 	auth.Post("/login", authController.Login)
 	auth.Post("/logout", middleware.AuthRequired, authController.Logout)
 	auth.Get("/profile", middleware.AuthRequired, authController.GetProfile)
+	auth.Put("/profile", middleware.AuthRequired, profileChangeController.UpdateOwnProfile)
+	auth.Get("/profile/change-requests", middleware.AuthRequired, profileChangeController.ListOwnRequests)
+	auth.Put("/password", middleware.AuthRequired, authController.ChangePassword)
+	auth.Post("/password/reset", authController.ResetPassword)
+
+	// SSO (OpenID Connect)
+	auth.Get("/oidc/login", oidcController.Login)
+	auth.Get("/oidc/callback", oidcController.Callback)
+
+	// Two-Factor (TOTP)
+	auth.Post("/login/2fa", authController.VerifyMFALogin)
+	auth.Post("/login/2fa/setup", authController.SetupMFAFromChallenge)
+	auth.Get("/2fa", middleware.AuthRequired, mfaController.Status)
+	auth.Post("/2fa/setup", middleware.AuthRequired, mfaController.Setup)
+	auth.Post("/2fa/enable", middleware.AuthRequired, mfaController.Enable)
+	auth.Post("/2fa/disable", middleware.AuthRequired, mfaController.Disable)
 
 	// --- Achievements Routes ---
 	ach := api.Group("/achievements", middleware.AuthRequired)
@@ -58,26 +151,103 @@ func SetupRoutes(app *fiber.App, pgDB *pgxpool.Pool, mongoClient *mongo.Client)
 	ach.Post("/:id/submit", middleware.RBACRequired("achievement:update"), achieveController.Submit) 
 	ach.Post("/:id/attachments", middleware.RBACRequired("achievement:update"), achieveController.UploadAttachment)
 	
+	// Trash (Pemilik/Admin)
+	ach.Get("/trash", middleware.RBACRequired("achievement:delete"), achieveController.ListTrash)
+	ach.Post("/:id/restore", middleware.RBACRequired("achievement:delete"), achieveController.Restore)
+
 	// Dosen Wali/Admin Actions (Read & Workflow)
 	ach.Get("/", achieveController.List) 
+	ach.Get("/export", exportController.ExportAchievements)
 	ach.Get("/:id", achieveController.Detail)
 	ach.Post("/:id/verify", middleware.RBACRequired("achievement:verify"), achieveController.Verify)
 	ach.Post("/:id/reject", middleware.RBACRequired("achievement:verify"), achieveController.Reject)
+	ach.Post("/:id/revoke", middleware.RBACRequired("achievement:verify"), achieveController.Revoke)
 	ach.Delete("/:id/hard", middleware.RBACRequired("achievement:delete"), achieveController.HardDelete)
 	
 	
 	users := api.Group("/users", middleware.AuthRequired, middleware.RBACRequired("user:manage"))
 	
-	users.Get("/", userController.ListAllUsers)      // GET /api/v1/users
+	users.Get("/", userController.ListUsers)         // GET /api/v1/users?search=&role=&page=
 	users.Post("/", userController.CreateUser)       // POST /api/v1/users
+	users.Post("/import", importController.ImportUsers) // Bulk import CSV/XLSX
 	users.Get("/:id", userController.GetUserByID)  // GET /api/v1/users/:id
 	users.Put("/:id", userController.UpdateUser)   // PUT /api/v1/users/:id
 	users.Delete("/:id", userController.DeleteUser)// DELETE /api/v1/users/:id (Deactivate)
 
+	users.Get("/:id/student-profile", profileController.GetStudentProfile)
+	users.Get("/:id/lecturer-profile", profileController.GetLecturerProfile)
 	users.Post("/:id/student-profile", userController.SetStudentProfile)
 	users.Post("/:id/lecturer-profile", userController.SetLecturerProfile)
-	users.Put("/:id/advisor", userController.AssignAdvisor) // Set Dosen Wali untuk Mahasiswa
+	users.Post("/:id/staff-profile", userController.SetFacultyStaffProfile)
+	users.Post("/advisors/bulk-assign", advisorController.BulkAssignAdvisor)
+	users.Post("/advisors/transfer", advisorController.TransferAdvisees) // Pindahkan semua bimbingan dosen A ke B
+	users.Put("/:id/advisor", advisorController.AssignAdvisor) // Set Dosen Wali untuk Mahasiswa
+	users.Get("/:id/advisor-history", advisorController.GetAssignmentHistory)
+	users.Post("/:id/password-reset", userController.IssuePasswordReset)
+	users.Post("/:id/unlock", userController.UnlockUser)
+	users.Delete("/:id/2fa", mfaController.Reset)
+
+	// --- Pengajuan perubahan profil (review Admin) ---
+	profileChanges := api.Group("/profile-change-requests", middleware.AuthRequired, middleware.RBACRequired("user:manage"))
+	profileChanges.Get("/", profileChangeController.ListRequests)
+	profileChanges.Post("/:id/approve", profileChangeController.Approve)
+	profileChanges.Post("/:id/reject", profileChangeController.Reject)
+
+	// --- Dosen: daftar mahasiswa bimbingan (dosen ybs. atau Admin) ---
+	lecturers := api.Group("/lecturers", middleware.AuthRequired)
+	lecturers.Get("/:id/advisees", profileController.ListAdvisees)
+
+	// --- Role & Permission Management (Admin) ---
+	roles := api.Group("/roles", middleware.AuthRequired, middleware.RBACRequired("role:manage"))
+	roles.Get("/", roleController.ListRoles)
+	roles.Post("/", roleController.CreateRole)
+	roles.Get("/:id", roleController.GetRole)
+	roles.Put("/:id", roleController.UpdateRole)
+	roles.Delete("/:id", roleController.DeleteRole)
+	roles.Put("/:id/permissions", roleController.SetRolePermissions)
+	roles.Post("/:id/permissions/:permissionId", roleController.AddRolePermission)
+	roles.Delete("/:id/permissions/:permissionId", roleController.RemoveRolePermission)
+
+	permissions := api.Group("/permissions", middleware.AuthRequired, middleware.RBACRequired("role:manage"))
+	permissions.Get("/", roleController.ListPermissions)
+	permissions.Post("/", roleController.CreatePermission)
+	permissions.Put("/:id", roleController.UpdatePermission)
+	permissions.Delete("/:id", roleController.DeletePermission)
+
+	// --- Departemen & Program Studi (baca: semua user login, ubah: Admin) ---
+	departments := api.Group("/departments", middleware.AuthRequired)
+	departments.Get("/", unitController.ListDepartments)
+	departments.Get("/:id", unitController.GetDepartment)
+	departments.Post("/", middleware.RBACRequired("user:manage"), unitController.CreateDepartment)
+	departments.Put("/:id", middleware.RBACRequired("user:manage"), unitController.UpdateDepartment)
+	departments.Delete("/:id", middleware.RBACRequired("user:manage"), unitController.DeleteDepartment)
+
+	studyPrograms := api.Group("/study-programs", middleware.AuthRequired)
+	studyPrograms.Get("/", unitController.ListStudyPrograms)
+	studyPrograms.Get("/:id", unitController.GetStudyProgram)
+	studyPrograms.Post("/", middleware.RBACRequired("user:manage"), unitController.CreateStudyProgram)
+	studyPrograms.Put("/:id", middleware.RBACRequired("user:manage"), unitController.UpdateStudyProgram)
+	studyPrograms.Delete("/:id", middleware.RBACRequired("user:manage"), unitController.DeleteStudyProgram)
 
 	reports := api.Group("/reports", middleware.AuthRequired)
 	reports.Get("/statistics", reportController.GetDashboardStats)
+	reports.Get("/statistics/export", exportController.ExportDashboardStats)
+	reports.Get("/advisees", reportController.GetAdviseeStats)
+	reports.Get("/trends", reportController.GetTrends)
+	reports.Get("/leaderboard", reportController.GetLeaderboard)
+	reports.Get("/accreditation", accreditationController.GetReport)
+	reports.Get("/accreditation/export", accreditationController.Export)
+	reports.Get("/students/:id", reportController.GetStudentReport)
+	reports.Get("/students/:id/export", exportController.ExportStudentReport)
+	reports.Get("/students/:id/transcript.pdf", transcriptController.Download)
+
+	// --- Public Routes (tanpa login, dibatasi per IP) ---
+	public := api.Group("/public", middleware.RateLimit(
+		utils.GetEnvInt("PUBLIC_RATE_LIMIT_MAX", 30),
+		utils.GetEnvDuration("PUBLIC_RATE_LIMIT_WINDOW", time.Minute),
+	))
+	public.Get("/verify/:code", achieveController.PublicVerify)
+	public.Get("/transcripts/:code", transcriptController.Verify)
+
+	return startJobs
 }
\ No newline at end of file
@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/repositories"
+
+	"github.com/google/uuid"
+)
+
+type AcademicUnitService interface {
+	ListDepartments(ctx context.Context) ([]models.Department, int, error)
+	GetDepartment(ctx context.Context, departmentID uuid.UUID) (*models.Department, int, error)
+	CreateDepartment(ctx context.Context, req *models.DepartmentRequest) (*models.Department, int, error)
+	UpdateDepartment(ctx context.Context, departmentID uuid.UUID, req *models.DepartmentRequest) (*models.Department, int, error)
+	DeleteDepartment(ctx context.Context, departmentID uuid.UUID) (int, error)
+
+	ListStudyPrograms(ctx context.Context, departmentID *uuid.UUID) ([]models.StudyProgram, int, error)
+	GetStudyProgram(ctx context.Context, programID uuid.UUID) (*models.StudyProgram, int, error)
+	CreateStudyProgram(ctx context.Context, req *models.StudyProgramRequest) (*models.StudyProgram, int, error)
+	UpdateStudyProgram(ctx context.Context, programID uuid.UUID, req *models.StudyProgramRequest) (*models.StudyProgram, int, error)
+	DeleteStudyProgram(ctx context.Context, programID uuid.UUID) (int, error)
+}
+
+type academicUnitService struct {
+	unitRepo    repositories.AcademicUnitRepository
+	profileRepo repositories.ProfileRepository
+	stats       StatsInvalidator
+}
+
+func NewAcademicUnitService(unitRepo repositories.AcademicUnitRepository, profileRepo repositories.ProfileRepository, stats StatsInvalidator) AcademicUnitService {
+	return &academicUnitService{unitRepo: unitRepo, profileRepo: profileRepo, stats: stats}
+}
+
+// --- Departments ---
+
+func (s *academicUnitService) ListDepartments(ctx context.Context) ([]models.Department, int, error) {
+	departments, err := s.unitRepo.ListDepartments(ctx)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return departments, http.StatusOK, nil
+}
+
+func (s *academicUnitService) GetDepartment(ctx context.Context, departmentID uuid.UUID) (*models.Department, int, error) {
+	department, err := s.unitRepo.GetDepartmentByID(ctx, departmentID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if department == nil {
+		return nil, http.StatusNotFound, errors.New("department not found")
+	}
+	return department, http.StatusOK, nil
+}
+
+func (s *academicUnitService) CreateDepartment(ctx context.Context, req *models.DepartmentRequest) (*models.Department, int, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, http.StatusBadRequest, errors.New("department name is required")
+	}
+	if existing, err := s.unitRepo.GetDepartmentByName(ctx, name); err != nil {
+		return nil, http.StatusInternalServerError, err
+	} else if existing != nil {
+		return nil, http.StatusConflict, fmt.Errorf("department %s already exists", name)
+	}
+
+	department := &models.Department{ID: uuid.New(), Code: optionalCode(req.Code), Name: name}
+	if err := s.unitRepo.CreateDepartment(ctx, department); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return department, http.StatusCreated, nil
+}
+
+func (s *academicUnitService) UpdateDepartment(ctx context.Context, departmentID uuid.UUID, req *models.DepartmentRequest) (*models.Department, int, error) {
+	department, status, err := s.GetDepartment(ctx, departmentID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if name := strings.TrimSpace(req.Name); name != "" && name != department.Name {
+		if existing, err := s.unitRepo.GetDepartmentByName(ctx, name); err != nil {
+			return nil, http.StatusInternalServerError, err
+		} else if existing != nil && existing.ID != department.ID {
+			return nil, http.StatusConflict, fmt.Errorf("department %s already exists", name)
+		}
+		department.Name = name
+	}
+	department.Code = optionalCode(req.Code)
+
+	if err := s.unitRepo.UpdateDepartment(ctx, department); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return department, http.StatusOK, nil
+}
+
+func (s *academicUnitService) DeleteDepartment(ctx context.Context, departmentID uuid.UUID) (int, error) {
+	if _, status, err := s.GetDepartment(ctx, departmentID); err != nil {
+		return status, err
+	}
+	if err := s.unitRepo.DeleteDepartment(ctx, departmentID); err != nil {
+		return http.StatusConflict, err
+	}
+	return http.StatusOK, nil
+}
+
+// --- Study Programs ---
+
+func (s *academicUnitService) ListStudyPrograms(ctx context.Context, departmentID *uuid.UUID) ([]models.StudyProgram, int, error) {
+	programs, err := s.unitRepo.ListStudyPrograms(ctx, departmentID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return programs, http.StatusOK, nil
+}
+
+func (s *academicUnitService) GetStudyProgram(ctx context.Context, programID uuid.UUID) (*models.StudyProgram, int, error) {
+	program, err := s.unitRepo.GetStudyProgramByID(ctx, programID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if program == nil {
+		return nil, http.StatusNotFound, errors.New("study program not found")
+	}
+	return program, http.StatusOK, nil
+}
+
+func (s *academicUnitService) CreateStudyProgram(ctx context.Context, req *models.StudyProgramRequest) (*models.StudyProgram, int, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, http.StatusBadRequest, errors.New("study program name is required")
+	}
+	if existing, err := s.unitRepo.GetStudyProgramByName(ctx, name); err != nil {
+		return nil, http.StatusInternalServerError, err
+	} else if existing != nil {
+		return nil, http.StatusConflict, fmt.Errorf("study program %s already exists", name)
+	}
+
+	program := &models.StudyProgram{ID: uuid.New(), Code: optionalCode(req.Code), Name: name}
+	if status, err := s.applyStudyProgramRefs(ctx, program, req); err != nil {
+		return nil, status, err
+	}
+	if err := s.unitRepo.CreateStudyProgram(ctx, program); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return program, http.StatusCreated, nil
+}
+
+func (s *academicUnitService) UpdateStudyProgram(ctx context.Context, programID uuid.UUID, req *models.StudyProgramRequest) (*models.StudyProgram, int, error) {
+	program, status, err := s.GetStudyProgram(ctx, programID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if name := strings.TrimSpace(req.Name); name != "" && name != program.Name {
+		if existing, err := s.unitRepo.GetStudyProgramByName(ctx, name); err != nil {
+			return nil, http.StatusInternalServerError, err
+		} else if existing != nil && existing.ID != program.ID {
+			return nil, http.StatusConflict, fmt.Errorf("study program %s already exists", name)
+		}
+		program.Name = name
+	}
+	program.Code = optionalCode(req.Code)
+	if status, err := s.applyStudyProgramRefs(ctx, program, req); err != nil {
+		return nil, status, err
+	}
+
+	if err := s.unitRepo.UpdateStudyProgram(ctx, program); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	// Nama prodi, departemen, dan Kaprodi menentukan rekap per prodi serta scope program/departemen
+	invalidateStats(ctx, s.stats)
+	return program, http.StatusOK, nil
+}
+
+func (s *academicUnitService) DeleteStudyProgram(ctx context.Context, programID uuid.UUID) (int, error) {
+	if _, status, err := s.GetStudyProgram(ctx, programID); err != nil {
+		return status, err
+	}
+	if err := s.unitRepo.DeleteStudyProgram(ctx, programID); err != nil {
+		return http.StatusConflict, err
+	}
+	invalidateStats(ctx, s.stats)
+	return http.StatusOK, nil
+}
+
+// applyStudyProgramRefs memvalidasi departemen (wajib) dan Kaprodi (opsional, harus punya profil dosen)
+func (s *academicUnitService) applyStudyProgramRefs(ctx context.Context, program *models.StudyProgram, req *models.StudyProgramRequest) (int, error) {
+	departmentID, err := uuid.Parse(req.DepartmentID)
+	if err != nil {
+		return http.StatusBadRequest, errors.New("valid departmentId is required")
+	}
+	if department, err := s.unitRepo.GetDepartmentByID(ctx, departmentID); err != nil {
+		return http.StatusInternalServerError, err
+	} else if department == nil {
+		return http.StatusBadRequest, errors.New("department not found")
+	}
+	program.DepartmentID = &departmentID
+
+	program.HeadLecturerID = nil
+	if req.HeadUserID != "" {
+		headUserID, err := uuid.Parse(req.HeadUserID)
+		if err != nil {
+			return http.StatusBadRequest, errors.New("invalid headUserId")
+		}
+		lecturer, err := s.profileRepo.GetLecturerByUserID(ctx, headUserID)
+		if err != nil {
+			return http.StatusBadRequest, errors.New("head of study program must have a lecturer profile")
+		}
+		program.HeadLecturerID = &lecturer.ID
+	}
+	return http.StatusOK, nil
+}
+
+// resolveStudyProgram mencari prodi berdasarkan ID, atau berdasarkan nama untuk klien lama yang masih mengirim teks
+func resolveStudyProgram(ctx context.Context, unitRepo repositories.AcademicUnitRepository, id string, name string) (*models.StudyProgram, int, error) {
+	var program *models.StudyProgram
+	var err error
+	switch {
+	case id != "":
+		programID, parseErr := uuid.Parse(id)
+		if parseErr != nil {
+			return nil, http.StatusBadRequest, errors.New("invalid studyProgramId")
+		}
+		program, err = unitRepo.GetStudyProgramByID(ctx, programID)
+	case strings.TrimSpace(name) != "":
+		program, err = unitRepo.GetStudyProgramByName(ctx, strings.TrimSpace(name))
+	default:
+		return nil, http.StatusBadRequest, errors.New("studyProgramId is required")
+	}
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if program == nil {
+		return nil, http.StatusBadRequest, errors.New("study program not found")
+	}
+	return program, http.StatusOK, nil
+}
+
+// resolveDepartment mencari departemen berdasarkan ID, atau berdasarkan nama untuk klien lama
+func resolveDepartment(ctx context.Context, unitRepo repositories.AcademicUnitRepository, id string, name string) (*models.Department, int, error) {
+	var department *models.Department
+	var err error
+	switch {
+	case id != "":
+		departmentID, parseErr := uuid.Parse(id)
+		if parseErr != nil {
+			return nil, http.StatusBadRequest, errors.New("invalid departmentId")
+		}
+		department, err = unitRepo.GetDepartmentByID(ctx, departmentID)
+	case strings.TrimSpace(name) != "":
+		department, err = unitRepo.GetDepartmentByName(ctx, strings.TrimSpace(name))
+	default:
+		return nil, http.StatusBadRequest, errors.New("departmentId is required")
+	}
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if department == nil {
+		return nil, http.StatusBadRequest, errors.New("department not found")
+	}
+	return department, http.StatusOK, nil
+}
+
+func optionalCode(code string) *string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return nil
+	}
+	return &code
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/repositories"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+)
+
+// AccessPolicy menerjemahkan permission data-scope (achievement:read:*) menjadi daftar mahasiswa
+// yang datanya boleh dibaca. Semua service memakai komponen ini, bukan nama role.
+type AccessPolicy interface {
+	ResolveAchievementScope(ctx context.Context, claims *utils.JWTCustomClaims) (*models.AchievementScope, error)
+	CanReadStudent(ctx context.Context, claims *utils.JWTCustomClaims, studentID uuid.UUID) (bool, error)
+}
+
+type accessPolicy struct {
+	userRepo repositories.UserRepository
+}
+
+func NewAccessPolicy(userRepo repositories.UserRepository) AccessPolicy {
+	return &accessPolicy{userRepo: userRepo}
+}
+
+// ResolveAchievementScope menggabungkan semua scope yang dimiliki user (own, advisees, program, department, all).
+// Scope kosong (tanpa permission apa pun) berarti user tidak boleh membaca data siapa pun.
+func (p *accessPolicy) ResolveAchievementScope(ctx context.Context, claims *utils.JWTCustomClaims) (*models.AchievementScope, error) {
+	if containsString(claims.Permissions, models.PermissionAchievementReadAll) {
+		return &models.AchievementScope{All: true}, nil
+	}
+
+	scope := &models.AchievementScope{StudentIDs: []uuid.UUID{}}
+	if containsString(claims.Permissions, models.PermissionAchievementReadDepartment) {
+		ids, err := p.userRepo.GetDepartmentStudentUserIDsByUserID(ctx, claims.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve department scope: %w", err)
+		}
+		scope.StudentIDs = append(scope.StudentIDs, ids...)
+	}
+	if containsString(claims.Permissions, models.PermissionAchievementReadProgram) {
+		ids, err := p.userRepo.GetProgramStudentUserIDsByHeadUserID(ctx, claims.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve study program scope: %w", err)
+		}
+		scope.StudentIDs = append(scope.StudentIDs, ids...)
+	}
+	if containsString(claims.Permissions, models.PermissionAchievementReadAdvisees) {
+		ids, err := p.userRepo.GetAdviseeStudentUserIDsByAdvisorUserID(ctx, claims.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve advisee scope: %w", err)
+		}
+		scope.StudentIDs = append(scope.StudentIDs, ids...)
+	}
+	if containsString(claims.Permissions, models.PermissionAchievementReadOwn) {
+		scope.StudentIDs = append(scope.StudentIDs, claims.UserID)
+	}
+
+	scope.StudentIDs = uniqueUUIDs(scope.StudentIDs)
+	return scope, nil
+}
+
+// CanReadStudent mengecek satu mahasiswa tanpa harus memuat seluruh scope bila tidak perlu
+func (p *accessPolicy) CanReadStudent(ctx context.Context, claims *utils.JWTCustomClaims, studentID uuid.UUID) (bool, error) {
+	if containsString(claims.Permissions, models.PermissionAchievementReadAll) {
+		return true, nil
+	}
+	if studentID == claims.UserID && containsString(claims.Permissions, models.PermissionAchievementReadOwn) {
+		return true, nil
+	}
+
+	scope, err := p.ResolveAchievementScope(ctx, claims)
+	if err != nil {
+		return false, err
+	}
+	return scope.Includes(studentID), nil
+}
+
+func uniqueUUIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	result := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
@@ -0,0 +1,337 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/repositories"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+)
+
+// AccreditationMapping memetakan jenis prestasi dan details.level ke kategori tabel akreditasi
+type AccreditationMapping struct {
+	AcademicTypes []string          // jenis prestasi akademik; jenis lain dihitung non-akademik
+	LevelAliases  map[string]string // details.level (huruf kecil) -> tingkat LKPS
+}
+
+// Nilai details.level yang dikenali untuk tiap tingkat LKPS
+var accreditationLevelAliases = map[string][]string{
+	models.AccreditationLevelRegional:      {"local", "lokal", "regional", "wilayah", "provinsi", "provincial", "kabupaten", "kota", "university", "universitas", "kampus"},
+	models.AccreditationLevelNational:      {"national", "nasional"},
+	models.AccreditationLevelInternational: {"international", "internasional", "asean", "asia"},
+}
+
+// AccreditationMappingFromEnv: ACCREDITATION_ACADEMIC_TYPES berisi daftar jenis prestasi akademik dipisah koma
+func AccreditationMappingFromEnv() AccreditationMapping {
+	mapping := AccreditationMapping{LevelAliases: map[string]string{}}
+	for level, aliases := range accreditationLevelAliases {
+		for _, alias := range aliases {
+			mapping.LevelAliases[alias] = level
+		}
+	}
+	for _, t := range strings.Split(utils.GetEnv("ACCREDITATION_ACADEMIC_TYPES", "academic,competition,publication,certification,research"), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			mapping.AcademicTypes = append(mapping.AcademicTypes, t)
+		}
+	}
+	return mapping
+}
+
+// AccreditationService menyusun tabel prestasi mahasiswa per program studi untuk akreditasi (BAN-PT/LAM)
+type AccreditationService interface {
+	GetReport(ctx context.Context, claims *utils.JWTCustomClaims, q models.AccreditationQuery) (*models.AccreditationReport, int, error)
+	ExportReport(ctx context.Context, claims *utils.JWTCustomClaims, q models.AccreditationQuery, format string) (*ExportJob, int, error)
+}
+
+type accreditationService struct {
+	achieveRepo repositories.AchievementRepository
+	unitRepo    repositories.AcademicUnitRepository
+	policy      AccessPolicy
+	mapping     AccreditationMapping
+}
+
+func NewAccreditationService(achieveRepo repositories.AchievementRepository, unitRepo repositories.AcademicUnitRepository, policy AccessPolicy, mapping AccreditationMapping) AccreditationService {
+	return &accreditationService{achieveRepo: achieveRepo, unitRepo: unitRepo, policy: policy, mapping: mapping}
+}
+
+func (s *accreditationService) GetReport(ctx context.Context, claims *utils.JWTCustomClaims, q models.AccreditationQuery) (*models.AccreditationReport, int, error) {
+	if q.ReferenceYear == 0 {
+		q.ReferenceYear = time.Now().Year()
+	}
+	if q.ReferenceYear < 1990 || q.ReferenceYear > time.Now().Year()+1 {
+		return nil, http.StatusBadRequest, errors.New("invalid reference year")
+	}
+
+	scope, err := s.policy.ResolveAchievementScope(ctx, claims)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to resolve access scope")
+	}
+	if !scope.All && len(scope.StudentIDs) == 0 {
+		return nil, http.StatusForbidden, errors.New("user not authorized to view accreditation reports")
+	}
+
+	var program *models.StudyProgram
+	if q.StudyProgramID != nil {
+		program, err = s.unitRepo.GetStudyProgramByID(ctx, *q.StudyProgramID)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		if program == nil {
+			return nil, http.StatusNotFound, errors.New("study program not found")
+		}
+	}
+
+	records, err := s.achieveRepo.ListAccreditationRecords(ctx, *scope, q.StudyProgramID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	report := &models.AccreditationReport{ReferenceYear: q.ReferenceYear, Programs: []models.AccreditationProgramReport{}}
+	for i := models.AccreditationYearSpan - 1; i >= 0; i-- {
+		report.Years = append(report.Years, q.ReferenceYear-i)
+	}
+	firstYear := report.Years[0]
+
+	programs := map[string]*models.AccreditationProgramReport{}
+	programFor := func(id *uuid.UUID, name string) *models.AccreditationProgramReport {
+		key := "name:" + name
+		if id != nil {
+			key = id.String()
+		}
+		if p, ok := programs[key]; ok {
+			return p
+		}
+		p := &models.AccreditationProgramReport{
+			StudyProgramID: id,
+			ProgramStudy:   name,
+			Academic:       newAccreditationTable(models.AccreditationAcademic, report.Years),
+			NonAcademic:    newAccreditationTable(models.AccreditationNonAcademic, report.Years),
+			Unmapped:       []models.AccreditationEntry{},
+		}
+		programs[key] = p
+		return p
+	}
+	if program != nil {
+		programFor(&program.ID, program.Name)
+	}
+
+	for _, rec := range records {
+		year := 0
+		if rec.EventDate != nil {
+			year = rec.EventDate.Year()
+		} else if rec.VerifiedAt != nil {
+			year = rec.VerifiedAt.Year()
+		}
+		if year < firstYear || year > q.ReferenceYear {
+			continue
+		}
+
+		p := programFor(rec.StudyProgramID, rec.ProgramStudy)
+		entry := models.AccreditationEntry{
+			RefID: rec.RefID, StudentNumber: rec.StudentNumber, StudentName: rec.StudentName,
+			Title: rec.Title, AchievementType: rec.AchievementType, Year: year, Level: rec.Level, Rank: rec.Rank,
+		}
+		level := s.normalizeLevel(rec.Level)
+		if level == "" {
+			entry.Reason = "missing details.level"
+			if rec.Level != "" {
+				entry.Reason = fmt.Sprintf("unrecognized level %q", rec.Level)
+			}
+			p.Unmapped = append(p.Unmapped, entry)
+			continue
+		}
+		entry.Level = level
+
+		table := &p.NonAcademic
+		if s.categoryOf(rec) == models.AccreditationAcademic {
+			table = &p.Academic
+		}
+		table.Entries = append(table.Entries, entry)
+		countAccreditationEntry(table, year-firstYear, level)
+	}
+
+	for _, p := range programs {
+		sortAccreditationEntries(p.Academic.Entries)
+		sortAccreditationEntries(p.NonAcademic.Entries)
+		sortAccreditationEntries(p.Unmapped)
+		report.Programs = append(report.Programs, *p)
+	}
+	sort.Slice(report.Programs, func(i, j int) bool { return report.Programs[i].ProgramStudy < report.Programs[j].ProgramStudy })
+	return report, http.StatusOK, nil
+}
+
+func newAccreditationTable(category string, years []int) models.AccreditationTable {
+	table := models.AccreditationTable{Category: category, Entries: []models.AccreditationEntry{}}
+	for i, year := range years {
+		label := "TS"
+		if offset := len(years) - 1 - i; offset > 0 {
+			label = fmt.Sprintf("TS-%d", offset)
+		}
+		table.Summary = append(table.Summary, models.AccreditationYearCount{Year: year, Label: label})
+	}
+	return table
+}
+
+func countAccreditationEntry(table *models.AccreditationTable, yearIndex int, level string) {
+	count := &table.Summary[yearIndex]
+	switch level {
+	case models.AccreditationLevelRegional:
+		count.Regional++
+	case models.AccreditationLevelNational:
+		count.National++
+	case models.AccreditationLevelInternational:
+		count.International++
+	}
+	count.Total++
+}
+
+func sortAccreditationEntries(entries []models.AccreditationEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Year != entries[j].Year {
+			return entries[i].Year < entries[j].Year
+		}
+		if entries[i].StudentNumber != entries[j].StudentNumber {
+			return entries[i].StudentNumber < entries[j].StudentNumber
+		}
+		return entries[i].Title < entries[j].Title
+	})
+}
+
+// normalizeLevel mengembalikan string kosong jika tingkat tidak dikenali
+func (s *accreditationService) normalizeLevel(level string) string {
+	key := strings.ToLower(strings.TrimSpace(level))
+	key = strings.NewReplacer("-", " ", "_", " ").Replace(key)
+	return s.mapping.LevelAliases[key]
+}
+
+// categoryOf: details.category (jika diisi) menimpa pemetaan berdasarkan jenis prestasi
+func (s *accreditationService) categoryOf(rec models.AccreditationRecord) string {
+	category := strings.ToLower(strings.TrimSpace(rec.Category))
+	category = strings.NewReplacer("-", "", "_", "", " ", "").Replace(category)
+	switch category {
+	case "academic", "akademik":
+		return models.AccreditationAcademic
+	case "nonacademic", "nonakademik":
+		return models.AccreditationNonAcademic
+	}
+	if containsString(s.mapping.AcademicTypes, rec.AchievementType) {
+		return models.AccreditationAcademic
+	}
+	return models.AccreditationNonAcademic
+}
+
+// ExportReport menghasilkan paket tabel akreditasi; XLSX (default) berisi satu worksheet per program studi
+func (s *accreditationService) ExportReport(ctx context.Context, claims *utils.JWTCustomClaims, q models.AccreditationQuery, format string) (*ExportJob, int, error) {
+	if strings.TrimSpace(format) == "" {
+		format = utils.ExportXLSX
+	}
+	format, err := utils.ValidateExportFormat(format)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	report, status, err := s.GetReport(ctx, claims, q)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return newExportJob(fmt.Sprintf("laporan-akreditasi-ts%d", report.ReferenceYear), format, func(ctx context.Context, rw utils.ReportWriter) error {
+		for _, program := range report.Programs {
+			if err := writeAccreditationProgram(rw, report, program); err != nil {
+				return err
+			}
+		}
+		return nil
+	}), http.StatusOK, nil
+}
+
+var accreditationCategoryLabels = map[string]string{
+	models.AccreditationAcademic:    "Akademik",
+	models.AccreditationNonAcademic: "Non-akademik",
+}
+
+func writeAccreditationProgram(rw utils.ReportWriter, report *models.AccreditationReport, program models.AccreditationProgramReport) error {
+	name := program.ProgramStudy
+	if name == "" {
+		name = "Tanpa Program Studi"
+	}
+	if sw, ok := rw.(utils.SheetWriter); ok {
+		if err := sw.Sheet(name); err != nil {
+			return err
+		}
+	}
+	if err := rw.Title("Prestasi Mahasiswa untuk Akreditasi",
+		"Program Studi: "+name,
+		fmt.Sprintf("Tahun acuan (TS): %d", report.ReferenceYear),
+		fmt.Sprintf("Periode: %d - %d", report.Years[0], report.ReferenceYear),
+	); err != nil {
+		return err
+	}
+
+	// Tabel rincian ditulis lebih dulu agar lebar kolom XLSX mengikuti tabel terlebar
+	for _, table := range []models.AccreditationTable{program.Academic, program.NonAcademic} {
+		if err := rw.Table("Tabel Prestasi "+accreditationCategoryLabels[table.Category]+" Mahasiswa", []utils.ExportColumn{
+			{Header: "No", Width: 2}, {Header: "Nama Kegiatan", Width: 14}, {Header: "Tahun Perolehan", Width: 4},
+			{Header: "Lokal/Wilayah", Width: 4}, {Header: "Nasional", Width: 4}, {Header: "Internasional", Width: 4},
+			{Header: "Prestasi yang Dicapai", Width: 7}, {Header: "NIM", Width: 5}, {Header: "Nama Mahasiswa", Width: 8},
+		}); err != nil {
+			return err
+		}
+		for i, e := range table.Entries {
+			if err := rw.Row(i+1, e.Title, e.Year,
+				levelMark(e.Level, models.AccreditationLevelRegional),
+				levelMark(e.Level, models.AccreditationLevelNational),
+				levelMark(e.Level, models.AccreditationLevelInternational),
+				e.Rank, e.StudentNumber, e.StudentName); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, table := range []models.AccreditationTable{program.Academic, program.NonAcademic} {
+		label := accreditationCategoryLabels[table.Category]
+		if err := rw.Table("Rekap Prestasi "+label, []utils.ExportColumn{
+			{Header: "Tahun", Width: 4}, {Header: "Keterangan", Width: 4}, {Header: "Lokal/Wilayah", Width: 5},
+			{Header: "Nasional", Width: 5}, {Header: "Internasional", Width: 5}, {Header: "Jumlah", Width: 4},
+		}); err != nil {
+			return err
+		}
+		for _, c := range table.Summary {
+			if err := rw.Row(c.Year, c.Label, c.Regional, c.National, c.International, c.Total); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(program.Unmapped) == 0 {
+		return nil
+	}
+	if err := rw.Table("Prestasi Belum Terpetakan (periksa details.level)", []utils.ExportColumn{
+		{Header: "No", Width: 2}, {Header: "Nama Kegiatan", Width: 14}, {Header: "Jenis Prestasi", Width: 5},
+		{Header: "Tahun", Width: 3}, {Header: "Tingkat (details.level)", Width: 6}, {Header: "NIM", Width: 5},
+		{Header: "Nama Mahasiswa", Width: 8}, {Header: "Keterangan", Width: 8},
+	}); err != nil {
+		return err
+	}
+	for i, e := range program.Unmapped {
+		if err := rw.Row(i+1, e.Title, e.AchievementType, e.Year, e.Level, e.StudentNumber, e.StudentName, e.Reason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// levelMark menghasilkan tanda "V" pada kolom tingkat yang sesuai (format tabel LKPS)
+func levelMark(level, column string) string {
+	if level == column {
+		return "V"
+	}
+	return ""
+}
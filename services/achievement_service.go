@@ -3,7 +3,10 @@ package services
 import (
 	"context"
 	"errors"
+	"log"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 	"fmt"
 
@@ -23,23 +26,41 @@ type AchievementService interface {
 	
 	// Workflow
 	SubmitForVerification(ctx context.Context, studentID uuid.UUID, achievementRefID uuid.UUID) (*models.AchievementReference, int, error)
-	VerifyAchievement(ctx context.Context, advisorUserID uuid.UUID, achievementRefID uuid.UUID) (*models.AchievementReference, int, error)
-	RejectAchievement(ctx context.Context, advisorUserID uuid.UUID, achievementRefID uuid.UUID, rejectionNote string) (*models.AchievementReference, int, error)
+	VerifyAchievement(ctx context.Context, claims *utils.JWTCustomClaims, achievementRefID uuid.UUID) (*models.AchievementReference, int, error)
+	RejectAchievement(ctx context.Context, claims *utils.JWTCustomClaims, achievementRefID uuid.UUID, rejectionNote string) (*models.AchievementReference, int, error)
+	RevokeVerification(ctx context.Context, claims *utils.JWTCustomClaims, achievementRefID uuid.UUID, reason string) (*models.AchievementReference, int, error)
+
+	// Verifikasi publik (tanpa login)
+	VerifyPublicCode(ctx context.Context, code string) (*models.AchievementVerification, int, error)
 	
 	// Read (FR-006, FR-010)
 	ListFilteredAchievements(ctx context.Context, claims *utils.JWTCustomClaims) ([]models.AchievementDetailResponse, int, error)
 	GetDetailWithVerification(ctx context.Context, claims *utils.JWTCustomClaims, refID uuid.UUID) (*models.AchievementDetailResponse, int, error)
 	
 	HardDelete(ctx context.Context, refID uuid.UUID) (int, error)
+
+	// Trash (Soft Delete)
+	ListTrash(ctx context.Context, claims *utils.JWTCustomClaims) ([]models.AchievementDetailResponse, int, error)
+	RestoreFromTrash(ctx context.Context, claims *utils.JWTCustomClaims, refID uuid.UUID) (*models.AchievementReference, int, error)
+	PurgeExpiredTrash(ctx context.Context, retention time.Duration) (int, error)
 }
 
 type achievementService struct {
 	achieveRepo repositories.AchievementRepository
 	userRepo repositories.UserRepository // Diperlukan untuk FR-006 (Dosen Wali)
+	policy   AccessPolicy                // Scope baca berdasarkan permission achievement:read:*
+	stats    StatsMaintainer             // Agregat statistik dashboard (opsional)
 }
 
-func NewAchievementService(achieveRepo repositories.AchievementRepository, userRepo repositories.UserRepository) AchievementService {
-	return &achievementService{achieveRepo: achieveRepo, userRepo: userRepo}
+func NewAchievementService(achieveRepo repositories.AchievementRepository, userRepo repositories.UserRepository, policy AccessPolicy, stats StatsMaintainer) AchievementService {
+	return &achievementService{achieveRepo: achieveRepo, userRepo: userRepo, policy: policy, stats: stats}
+}
+
+// statsChanged memperbarui agregat statistik milik mahasiswa setelah transisi berhasil
+func (s *achievementService) statsChanged(ctx context.Context, studentID uuid.UUID) {
+	if s.stats != nil {
+		s.stats.StudentChanged(ctx, studentID)
+	}
 }
 
 // CreateDraft (FR-003)
@@ -60,6 +81,7 @@ func (s *achievementService) CreateDraft(ctx context.Context, studentID uuid.UUI
 	if err != nil {
 		return nil, http.StatusInternalServerError, errors.New("failed to create achievement: " + err.Error())
 	}
+	s.statsChanged(ctx, studentID)
 	return ref, http.StatusCreated, nil
 }
 
@@ -69,6 +91,7 @@ func (s *achievementService) DeleteDraft(ctx context.Context, studentID uuid.UUI
 	if err != nil {
 		return http.StatusNotFound, err
 	}
+	s.statsChanged(ctx, studentID)
 	return http.StatusOK, nil
 }
 
@@ -104,7 +127,9 @@ func (s *achievementService) UpdateDraft(ctx context.Context, studentID uuid.UUI
 	if err != nil {
 		// Log error, tapi tidak perlu mengembalikan 500 karena data sudah terupdate di Mongo
 	}
-	
+
+	// Jenis/poin bisa berubah
+	s.statsChanged(ctx, studentID)
 	return ref, http.StatusOK, nil
 }
 
@@ -126,8 +151,18 @@ func (s *achievementService) AddAttachment(ctx context.Context, studentUserID uu
 		return http.StatusForbidden, errors.New("access denied: this is not your achievement")
 	}
 
-	// ... sisa kode ...
-    return http.StatusCreated, nil
+	// 3. Simpan metadata lampiran ke MongoDB
+	attachment.UploadedAt = time.Now()
+	update := bson.M{
+		"$push": bson.M{"attachments": attachment},
+		"$set":  bson.M{"updatedAt": time.Now()},
+	}
+	if err := s.achieveRepo.UpdateAchievement(ctx, ref.MongoAchievementID, update); err != nil {
+		return http.StatusInternalServerError, errors.New("failed to save attachment")
+	}
+	_, _ = s.achieveRepo.UpdateReferenceUpdatedAt(ctx, refID)
+
+	return http.StatusCreated, nil
 }
 
 // SubmitForVerification (FR-004)
@@ -146,85 +181,141 @@ func (s *achievementService) SubmitForVerification(ctx context.Context, studentI
 	if err != nil {
 		return nil, http.StatusInternalServerError, errors.New("failed to update status: " + err.Error())
 	}
+	s.statsChanged(ctx, studentID)
 	// TODO: Add notification logic (SRS P. 9, item 3)
 	return ref, http.StatusOK, nil
 }
 
 // VerifyAchievement (FR-007)
-func (s *achievementService) VerifyAchievement(ctx context.Context, advisorUserID uuid.UUID, achievementRefID uuid.UUID) (*models.AchievementReference, int, error) {
-	// Validation: Check if the achievement belongs to an advisee of this advisorUserID (FR-006 prerequisite)
-	
+func (s *achievementService) VerifyAchievement(ctx context.Context, claims *utils.JWTCustomClaims, achievementRefID uuid.UUID) (*models.AchievementReference, int, error) {
 	// 1. Ambil reference
 	ref, err := s.achieveRepo.GetReferenceByID(ctx, achievementRefID)
 	if err != nil {
 		return nil, http.StatusNotFound, errors.New("achievement not found")
 	}
 	
-	// 2. Cek apakah advisorUserID adalah dosen wali dari ref.StudentID
-	// TODO: Get student advisorID based on ref.StudentID and compare with advisorUserID
+	// 2. Hanya dosen wali yang ditugaskan untuk pengajuan ini
+	if status, err := s.ensureReviewer(ctx, claims, achievementRefID); err != nil {
+		return nil, status, err
+	}
 	
 	if ref.Status != "submitted" {
 		return nil, http.StatusConflict, errors.New("achievement status must be 'submitted' to be verified")
 	}
 	
-	ref, err = s.achieveRepo.UpdateReferenceStatus(ctx, achievementRefID, "submitted", "verified", "", advisorUserID)
+	studentID := ref.StudentID
+	ref, err = s.achieveRepo.UpdateReferenceStatus(ctx, achievementRefID, "submitted", "verified", "", claims.UserID)
 	if err != nil {
 		return nil, http.StatusInternalServerError, errors.New("failed to update status: " + err.Error())
 	}
+	s.statsChanged(ctx, studentID)
 	
 	// Set verified_at in PG is handled inside UpdateReferenceStatus
 	return ref, http.StatusOK, nil
 }
 
 // RejectAchievement (FR-008)
-func (s *achievementService) RejectAchievement(ctx context.Context, advisorUserID uuid.UUID, achievementRefID uuid.UUID, rejectionNote string) (*models.AchievementReference, int, error) {
-	// Validation: Check if the achievement belongs to an advisee of this advisorUserID (FR-006 prerequisite)
-	
+func (s *achievementService) RejectAchievement(ctx context.Context, claims *utils.JWTCustomClaims, achievementRefID uuid.UUID, rejectionNote string) (*models.AchievementReference, int, error) {
 	ref, err := s.achieveRepo.GetReferenceByID(ctx, achievementRefID)
 	if err != nil {
 		return nil, http.StatusNotFound, errors.New("achievement not found")
 	}
 	
-	// Cek dosen wali ownership
-	// TODO: Check Dosen Wali ownership here
+	// Cek dosen wali yang ditugaskan
+	if status, err := s.ensureReviewer(ctx, claims, achievementRefID); err != nil {
+		return nil, status, err
+	}
 	
 	if ref.Status != "submitted" {
 		return nil, http.StatusConflict, errors.New("achievement status must be 'submitted' to be rejected")
 	}
 	
-	ref, err = s.achieveRepo.UpdateReferenceStatus(ctx, achievementRefID, "submitted", "rejected", rejectionNote, advisorUserID)
+	studentID := ref.StudentID
+	ref, err = s.achieveRepo.UpdateReferenceStatus(ctx, achievementRefID, "submitted", "rejected", rejectionNote, claims.UserID)
 	if err != nil {
 		return nil, http.StatusInternalServerError, errors.New("failed to update status: " + err.Error())
 	}
+	s.statsChanged(ctx, studentID)
 	
 	// TODO: Add notification logic (SRS P. 10, item 4)
 	return ref, http.StatusOK, nil
 }
 
+// RevokeVerification mencabut verifikasi (mis. sertifikat terbukti tidak sah); kode verifikasi publik ikut hangus
+func (s *achievementService) RevokeVerification(ctx context.Context, claims *utils.JWTCustomClaims, achievementRefID uuid.UUID, reason string) (*models.AchievementReference, int, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, http.StatusBadRequest, errors.New("revocation reason is required")
+	}
+
+	ref, err := s.achieveRepo.GetReferenceByID(ctx, achievementRefID)
+	if err != nil {
+		return nil, http.StatusNotFound, errors.New("achievement not found")
+	}
+	if status, err := s.ensureReviewer(ctx, claims, achievementRefID); err != nil {
+		return nil, status, err
+	}
+	if ref.Status != "verified" {
+		return nil, http.StatusConflict, errors.New("only verified achievements can be revoked")
+	}
+
+	studentID := ref.StudentID
+	ref, err = s.achieveRepo.RevokeVerification(ctx, achievementRefID, claims.UserID, reason)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to revoke verification: " + err.Error())
+	}
+	s.statsChanged(ctx, studentID)
+	return ref, http.StatusOK, nil
+}
+
+// VerifyPublicCode menjawab "valid", "revoked", atau "not_found" tanpa membocorkan data selain yang diperlukan
+func (s *achievementService) VerifyPublicCode(ctx context.Context, code string) (*models.AchievementVerification, int, error) {
+	code = strings.TrimSpace(code)
+	if code == "" || len(code) > maxVerificationCodeLength {
+		return &models.AchievementVerification{Status: models.VerificationNotFound}, http.StatusOK, nil
+	}
+
+	result, err := s.achieveRepo.GetPublicVerification(ctx, code)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to verify achievement")
+	}
+	if result == nil {
+		return &models.AchievementVerification{Status: models.VerificationNotFound}, http.StatusOK, nil
+	}
+	return result, http.StatusOK, nil
+}
+
+const maxVerificationCodeLength = 64
+
+// ensureReviewer: pengajuan hanya boleh diproses dosen wali yang ditugaskan,
+// kecuali pemegang permission achievement:verify:all
+func (s *achievementService) ensureReviewer(ctx context.Context, claims *utils.JWTCustomClaims, achievementRefID uuid.UUID) (int, error) {
+	if containsString(claims.Permissions, models.PermissionAchievementVerifyAll) {
+		return http.StatusOK, nil
+	}
+	advisorUserID, err := s.achieveRepo.GetAssignedAdvisorUserID(ctx, achievementRefID)
+	if err != nil {
+		return http.StatusInternalServerError, errors.New("failed to resolve assigned advisor")
+	}
+	if advisorUserID == nil || *advisorUserID != claims.UserID {
+		return http.StatusForbidden, errors.New("access denied: this submission is assigned to another advisor")
+	}
+	return http.StatusOK, nil
+}
+
 // ListFilteredAchievements (FR-006, FR-010)
 func (s *achievementService) ListFilteredAchievements(ctx context.Context, claims *utils.JWTCustomClaims) ([]models.AchievementDetailResponse, int, error) {
-	var filterStudentIDs []uuid.UUID
-	
-	// Tentukan filter berdasarkan Role
-	switch claims.Role {
-	case "Mahasiswa":
-		filterStudentIDs = append(filterStudentIDs, claims.UserID)
-	case "Dosen Wali":
-		// FR-006: Get list student IDs dari tabel students where advisor id
-		adviseeIDs, err := s.userRepo.GetAdviseeStudentUserIDsByAdvisorUserID(ctx, claims.UserID)
-		if err != nil {
-			return nil, http.StatusInternalServerError, errors.New("failed to retrieve advisees")
-		}
-		filterStudentIDs = adviseeIDs
-	case "Admin":
-		// FR-010: No student ID filter (ambil semua)
-		filterStudentIDs = nil // nil means fetch all
-	default:
-		return nil, http.StatusForbidden, errors.New("user role not authorized to view achievements")
+	// Tentukan filter berdasarkan permission data-scope (FR-006, FR-010)
+	scope, err := s.policy.ResolveAchievementScope(ctx, claims)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to resolve access scope")
+	}
+	if !scope.All && len(scope.StudentIDs) == 0 {
+		return nil, http.StatusForbidden, errors.New("user not authorized to view achievements")
 	}
 	
 	// 1. Get all relevant references from PG
-	references, err := s.achieveRepo.ListAchievementReferences(ctx, filterStudentIDs)
+	references, err := s.achieveRepo.ListAchievementReferences(ctx, *scope)
 	if err != nil {
 		return nil, http.StatusInternalServerError, errors.New("failed to list references: " + err.Error())
 	}
@@ -269,15 +360,10 @@ func (s *achievementService) GetDetailWithVerification(ctx context.Context, clai
 		return nil, http.StatusNotFound, errors.New("achievement not found")
 	}
 	
-	// 2. Authorization Check (Must be owner, advisee, or Admin)
-	isAuthorized := false
-	if claims.Role == "Admin" {
-		isAuthorized = true
-	} else if claims.UserID == ref.StudentID {
-		isAuthorized = true
-	} else if claims.Role == "Dosen Wali" {
-		// TODO: Check if ref.StudentID is an advisee of claims.UserID (FR-006 prerequisite)
-		isAuthorized = true // Assume true for now
+	// 2. Authorization Check (owner, advisee, departemen, atau semua sesuai permission)
+	isAuthorized, err := s.policy.CanReadStudent(ctx, claims, ref.StudentID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to resolve access scope")
 	}
 	
 	if !isAuthorized {
@@ -303,14 +389,120 @@ func (s *achievementService) GetDetailWithVerification(ctx context.Context, clai
 		SubmittedAt:     ref.SubmittedAt,
 		VerifiedAt:      ref.VerifiedAt,
 	}
+	if ref.Status == "verified" {
+		code, err := s.achieveRepo.GetActiveVerificationCode(ctx, ref.ID)
+		if err != nil {
+			return nil, http.StatusInternalServerError, errors.New("failed to retrieve verification code")
+		}
+		response.VerificationCode = code
+	}
 	
 	return response, http.StatusOK, nil
 }
 
 func (s *achievementService) HardDelete(ctx context.Context, refID uuid.UUID) (int, error) {
-	err := s.achieveRepo.HardDeleteAchievement(ctx, refID)
+	// Prestasi di trash sudah tidak dihitung; hanya prestasi aktif yang mengubah agregat
+	var active *models.AchievementReference
+	if s.stats != nil {
+		if ref, err := s.achieveRepo.GetReferenceByID(ctx, refID); err == nil {
+			active = ref
+		}
+	}
+
+	attachments, err := s.achieveRepo.HardDeleteAchievement(ctx, refID)
 	if err != nil {
 		return http.StatusInternalServerError, err
 	}
+	removeAttachmentFiles(attachments)
+	if active != nil {
+		s.statsChanged(ctx, active.StudentID)
+	}
 	return http.StatusOK, nil
-}
\ No newline at end of file
+}
+
+// ListTrash menampilkan trash milik user sendiri, atau seluruh trash bagi pemegang achievement:delete:all.
+// Scope baca tidak dipakai karena restore adalah operasi tulis.
+func (s *achievementService) ListTrash(ctx context.Context, claims *utils.JWTCustomClaims) ([]models.AchievementDetailResponse, int, error) {
+	scope := models.AchievementScope{StudentIDs: []uuid.UUID{claims.UserID}}
+	if containsString(claims.Permissions, models.PermissionAchievementDeleteAll) {
+		scope = models.AchievementScope{All: true}
+	}
+
+	references, err := s.achieveRepo.ListTrashedReferences(ctx, scope)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to list trash: " + err.Error())
+	}
+
+	finalResponse := []models.AchievementDetailResponse{}
+	for _, ref := range references {
+		detail, err := s.achieveRepo.GetAchievementDetailWithDeleted(ctx, ref.MongoAchievementID)
+		if err != nil {
+			continue
+		}
+
+		finalResponse = append(finalResponse, models.AchievementDetailResponse{
+			RefID:           ref.ID,
+			Status:          ref.Status,
+			AchievementType: detail.AchievementType,
+			Title:           detail.Title,
+			Description:     detail.Description,
+			Details:         detail.Details,
+			Points:          detail.Points,
+			RejectionNote:   ref.RejectionNote,
+			SubmittedAt:     ref.SubmittedAt,
+			VerifiedAt:      ref.VerifiedAt,
+			DeletedAt:       ref.DeletedAt,
+		})
+	}
+	return finalResponse, http.StatusOK, nil
+}
+
+// RestoreFromTrash mengembalikan prestasi dari trash milik user sendiri, atau milik siapa pun
+// bagi pemegang achievement:delete:all
+func (s *achievementService) RestoreFromTrash(ctx context.Context, claims *utils.JWTCustomClaims, refID uuid.UUID) (*models.AchievementReference, int, error) {
+	ref, err := s.achieveRepo.GetTrashedReferenceByID(ctx, refID)
+	if err != nil {
+		return nil, http.StatusNotFound, errors.New("achievement not found in trash")
+	}
+
+	if ref.StudentID != claims.UserID && !containsString(claims.Permissions, models.PermissionAchievementDeleteAll) {
+		return nil, http.StatusForbidden, errors.New("not authorized to restore this achievement")
+	}
+
+	restored, err := s.achieveRepo.RestoreAchievementAndReference(ctx, refID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to restore achievement: " + err.Error())
+	}
+	s.statsChanged(ctx, ref.StudentID)
+	return restored, http.StatusOK, nil
+}
+
+// PurgeExpiredTrash menghapus permanen isi trash yang melewati masa retensi
+func (s *achievementService) PurgeExpiredTrash(ctx context.Context, retention time.Duration) (int, error) {
+	ids, err := s.achieveRepo.ListExpiredTrashReferenceIDs(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list expired trash: %w", err)
+	}
+
+	purged := 0
+	for _, id := range ids {
+		if _, err := s.HardDelete(ctx, id); err != nil {
+			log.Printf("failed to purge achievement %s: %v", id, err)
+			continue
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// removeAttachmentFiles menghapus file lampiran yang tersimpan di disk
+func removeAttachmentFiles(attachments []models.AttachmentFile) {
+	for _, attachment := range attachments {
+		if attachment.FileUrl == "" {
+			continue
+		}
+		if err := os.Remove(attachment.FileUrl); err != nil && !os.IsNotExist(err) {
+			log.Printf("failed to remove attachment file %s: %v", attachment.FileUrl, err)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/repositories"
+
+	"github.com/google/uuid"
+)
+
+// AdvisorService mengelola penugasan dosen wali (tunggal, massal, transfer) dan riwayatnya
+type AdvisorService interface {
+	AssignAdvisor(ctx context.Context, adminUserID uuid.UUID, studentUserID uuid.UUID, req *models.AssignAdvisorRequest) (*models.AdvisorAssignmentResult, int, error)
+	BulkAssignAdvisor(ctx context.Context, adminUserID uuid.UUID, req *models.BulkAssignAdvisorRequest) (*models.AdvisorAssignmentResult, int, error)
+	TransferAdvisees(ctx context.Context, adminUserID uuid.UUID, req *models.TransferAdviseesRequest) (*models.AdvisorAssignmentResult, int, error)
+	GetAssignmentHistory(ctx context.Context, studentUserID uuid.UUID) ([]models.AdvisorAssignment, int, error)
+}
+
+type advisorService struct {
+	advisorRepo repositories.AdvisorRepository
+	profileRepo repositories.ProfileRepository
+	unitRepo    repositories.AcademicUnitRepository
+	stats       StatsInvalidator
+}
+
+func NewAdvisorService(advisorRepo repositories.AdvisorRepository, profileRepo repositories.ProfileRepository, unitRepo repositories.AcademicUnitRepository, stats StatsInvalidator) AdvisorService {
+	return &advisorService{advisorRepo: advisorRepo, profileRepo: profileRepo, unitRepo: unitRepo, stats: stats}
+}
+
+// AssignAdvisor menetapkan dosen wali satu mahasiswa (riwayat lama ditutup)
+func (s *advisorService) AssignAdvisor(ctx context.Context, adminUserID uuid.UUID, studentUserID uuid.UUID, req *models.AssignAdvisorRequest) (*models.AdvisorAssignmentResult, int, error) {
+	lecturer, status, err := s.resolveAdvisor(ctx, req.AdvisorUserID, "advisorUserId")
+	if err != nil {
+		return nil, status, err
+	}
+
+	result, err := s.advisorRepo.AssignAdvisor(ctx, []uuid.UUID{studentUserID}, lecturer.ID, adminUserID, strings.TrimSpace(req.Reason))
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	invalidateStats(ctx, s.stats)
+	if len(result.NotFound) > 0 {
+		return nil, http.StatusNotFound, errors.New("student profile not found for this user")
+	}
+	result.AdvisorUserID = lecturer.UserID
+	return result, http.StatusOK, nil
+}
+
+// BulkAssignAdvisor menetapkan dosen wali untuk daftar mahasiswa, atau mahasiswa per prodi/angkatan
+func (s *advisorService) BulkAssignAdvisor(ctx context.Context, adminUserID uuid.UUID, req *models.BulkAssignAdvisorRequest) (*models.AdvisorAssignmentResult, int, error) {
+	lecturer, status, err := s.resolveAdvisor(ctx, req.AdvisorUserID, "advisorUserId")
+	if err != nil {
+		return nil, status, err
+	}
+
+	var studentUserIDs []uuid.UUID
+	if len(req.StudentUserIDs) > 0 {
+		for _, raw := range req.StudentUserIDs {
+			id, err := uuid.Parse(strings.TrimSpace(raw))
+			if err != nil {
+				return nil, http.StatusBadRequest, errors.New("invalid student user ID: " + raw)
+			}
+			studentUserIDs = append(studentUserIDs, id)
+		}
+		studentUserIDs = uniqueUUIDs(studentUserIDs)
+	} else {
+		filter := models.AdviseeFilter{AcademicYear: strings.TrimSpace(req.AcademicYear)}
+		if req.StudyProgramID != "" || strings.TrimSpace(req.ProgramStudy) != "" {
+			program, status, err := resolveStudyProgram(ctx, s.unitRepo, req.StudyProgramID, req.ProgramStudy)
+			if err != nil {
+				return nil, status, err
+			}
+			filter.StudyProgramID = &program.ID
+		}
+		if filter.StudyProgramID == nil && filter.AcademicYear == "" {
+			return nil, http.StatusBadRequest, errors.New("studentUserIds, studyProgramId or academicYear is required")
+		}
+
+		studentUserIDs, err = s.advisorRepo.FindStudentUserIDs(ctx, filter)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+	}
+
+	return s.assign(ctx, studentUserIDs, lecturer, adminUserID, req.Reason)
+}
+
+// TransferAdvisees memindahkan seluruh mahasiswa bimbingan dosen A ke dosen B
+func (s *advisorService) TransferAdvisees(ctx context.Context, adminUserID uuid.UUID, req *models.TransferAdviseesRequest) (*models.AdvisorAssignmentResult, int, error) {
+	from, status, err := s.resolveAdvisor(ctx, req.FromAdvisorUserID, "fromAdvisorUserId")
+	if err != nil {
+		return nil, status, err
+	}
+	to, status, err := s.resolveAdvisor(ctx, req.ToAdvisorUserID, "toAdvisorUserId")
+	if err != nil {
+		return nil, status, err
+	}
+	if from.ID == to.ID {
+		return nil, http.StatusBadRequest, errors.New("source and target advisor must be different")
+	}
+
+	studentUserIDs, err := s.advisorRepo.FindStudentUserIDs(ctx, models.AdviseeFilter{AdvisorID: &from.ID})
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return s.assign(ctx, studentUserIDs, to, adminUserID, req.Reason)
+}
+
+// GetAssignmentHistory
+func (s *advisorService) GetAssignmentHistory(ctx context.Context, studentUserID uuid.UUID) ([]models.AdvisorAssignment, int, error) {
+	history, err := s.advisorRepo.ListAssignmentHistory(ctx, studentUserID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return history, http.StatusOK, nil
+}
+
+func (s *advisorService) assign(ctx context.Context, studentUserIDs []uuid.UUID, lecturer *models.Lecturer, adminUserID uuid.UUID, reason string) (*models.AdvisorAssignmentResult, int, error) {
+	if len(studentUserIDs) == 0 {
+		return &models.AdvisorAssignmentResult{AdvisorUserID: lecturer.UserID, NotFound: []uuid.UUID{}}, http.StatusOK, nil
+	}
+
+	result, err := s.advisorRepo.AssignAdvisor(ctx, studentUserIDs, lecturer.ID, adminUserID, strings.TrimSpace(reason))
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	// Scope mahasiswa bimbingan berubah, statistik dashboard yang di-cache tidak lagi berlaku
+	invalidateStats(ctx, s.stats)
+	result.AdvisorUserID = lecturer.UserID
+	return result, http.StatusOK, nil
+}
+
+// resolveAdvisor mencari profil dosen berdasarkan User ID dosen
+func (s *advisorService) resolveAdvisor(ctx context.Context, rawUserID string, field string) (*models.Lecturer, int, error) {
+	userID, err := uuid.Parse(strings.TrimSpace(rawUserID))
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("invalid " + field)
+	}
+	lecturer, err := s.profileRepo.GetLecturerByUserID(ctx, userID)
+	if err != nil || lecturer == nil {
+		return nil, http.StatusNotFound, errors.New("advisor profile not found (user must have lecturer profile first)")
+	}
+	return lecturer, http.StatusOK, nil
+}
@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
+
 	"prestasi-mahasiswa-api/models"
 	"prestasi-mahasiswa-api/repositories"
 	"prestasi-mahasiswa-api/utils"
@@ -13,49 +16,100 @@ import (
 )
 
 type AuthService interface {
-	PerformLogin(ctx context.Context, username, password string) (*models.LoginResponse, int, error)
+	PerformLogin(ctx context.Context, username, password string, client models.ClientInfo) (*models.LoginResponse, int, error)
 	GetProfile(ctx context.Context, userID uuid.UUID) (*models.UserProfile, int, error)
+
+	// Password
+	ChangePassword(ctx context.Context, userID uuid.UUID, req *models.ChangePasswordRequest) (int, error)
+	ResetPassword(ctx context.Context, req *models.ResetPasswordRequest) (int, error)
+
+	// ValidateSession dipakai middleware untuk menolak token yang sudah dicabut
+	ValidateSession(ctx context.Context, claims *utils.JWTCustomClaims) error
+
+	// Login dua tahap (2FA)
+	CompleteMFALogin(ctx context.Context, req *models.MFAChallengeRequest, client models.ClientInfo) (*models.LoginResponse, int, error)
+	BeginMFAEnrollment(ctx context.Context, challengeToken string) (*models.MFASetupResponse, int, error)
 }
 
 type authService struct {
-	userRepo repositories.UserRepository
+	userRepo      repositories.UserRepository
+	resetRepo     repositories.PasswordResetRepository
+	profileRepo   repositories.ProfileRepository // Profil mahasiswa/dosen untuk GET /auth/profile
+	loginGuard    *LoginGuard
+	mfaService    MFAService
+	authenticator Authenticator
 }
 
-func NewAuthService(userRepo repositories.UserRepository) AuthService {
-	return &authService{userRepo: userRepo}
+// NewAuthService: authenticator menentukan sumber verifikasi password (lokal/bcrypt atau LDAP)
+func NewAuthService(userRepo repositories.UserRepository, resetRepo repositories.PasswordResetRepository, profileRepo repositories.ProfileRepository, loginGuard *LoginGuard, mfaService MFAService, authenticator Authenticator) AuthService {
+	return &authService{userRepo: userRepo, resetRepo: resetRepo, profileRepo: profileRepo, loginGuard: loginGuard, mfaService: mfaService, authenticator: authenticator}
 }
 
 // PerformLogin
-func (s *authService) PerformLogin(ctx context.Context, username, password string) (*models.LoginResponse, int, error) {
+func (s *authService) PerformLogin(ctx context.Context, username, password string, client models.ClientInfo) (*models.LoginResponse, int, error) {
 	// 1. Dapatkan user dari database
 	user, err := s.userRepo.FindUserByUsernameOrEmail(ctx, username)
+
+	// Percobaan login dicatat per username asli (bukan email) agar login via email ikut terhitung
+	attemptKey := strings.ToLower(username)
+	if err == nil {
+		attemptKey = user.Username
+	}
+
+	// 2. Tolak jika akun/IP sedang ditahan karena terlalu banyak gagal
+	if status, guardErr := s.loginGuard.Check(ctx, attemptKey, client); guardErr != nil {
+		return nil, status, guardErr
+	}
+
+	// Akun yang belum ada secara lokal masih bisa dibuat oleh authenticator eksternal (LDAP)
+	loginName := strings.ToLower(username)
 	if err != nil {
-		return nil, http.StatusUnauthorized, errors.New("invalid credentials")
+		user = nil
+	} else {
+		loginName = user.Username
 	}
 
-	// 2. Sistem memvalidasi kredensial
-	if !utils.CheckPasswordHash(password, user.PasswordHash) {
-		return nil, http.StatusUnauthorized, errors.New("invalid credentials")
+	// 3. Sistem memvalidasi kredensial
+	authenticated, err := s.authenticator.Authenticate(ctx, loginName, password, user)
+	if err != nil {
+		if errors.Is(err, ErrAuthBackendUnavailable) {
+			return nil, http.StatusServiceUnavailable, err
+		}
+		reason := "invalid_password"
+		if user == nil {
+			reason = "unknown_user"
+		}
+		s.loginGuard.RecordFailure(ctx, attemptKey, user, client, reason)
+		return nil, http.StatusUnauthorized, ErrInvalidCredentials
 	}
+	user = authenticated
 
-	// 3. Sistem mengecek status aktif user
+	// 4. Sistem mengecek status aktif user
 	if !user.IsActive {
 		return nil, http.StatusForbidden, errors.New("user account is inactive")
 	}
 
-	// 4. Sistem generate JWT token
-	accessToken, refreshToken, err := utils.GenerateAuthTokens(user.ID.String(), user.Role, user.Permissions)
+	// 5. Jika 2FA aktif (atau wajib untuk role ini), kembalikan challenge token, bukan JWT.
+	// Hitungan gagal tidak direset di sini agar tebakan kode 2FA tetap terkena lockout.
+	purpose, err := mfaChallengePurpose(ctx, s.mfaService, user)
 	if err != nil {
-		return nil, http.StatusInternalServerError, fmt.Errorf("failed to generate token: %w", err)
+		return nil, http.StatusInternalServerError, err
+	}
+	if purpose != "" {
+		return buildMFAChallengeResponse(user, purpose)
 	}
 
-	// 5. Return token dan user profile
-	profile := models.UserProfile{
-		ID:          user.ID.String(),
-		Username:    user.Username,
-		FullName:    user.FullName,
-		Role:        user.Role,
-		Permissions: user.Permissions,
+	s.loginGuard.RecordSuccess(ctx, user, client)
+
+	// 6. Sistem generate JWT token dan return user profile
+	return buildLoginResponse(user)
+}
+
+// buildLoginResponse menerbitkan JWT dan menyusun profil user
+func buildLoginResponse(user *models.User) (*models.LoginResponse, int, error) {
+	accessToken, refreshToken, err := utils.GenerateAuthTokens(user.ID.String(), user.Role, user.Permissions, user.SessionVersion)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to generate token: %w", err)
 	}
 
 	resp := &models.LoginResponse{
@@ -63,25 +117,217 @@ func (s *authService) PerformLogin(ctx context.Context, username, password strin
 		Data: models.LoginData{
 			Token:        accessToken,
 			RefreshToken: refreshToken,
-			User:         profile,
+			User:         toUserProfile(user),
 		},
 	}
 	return resp, http.StatusOK, nil
 }
 
-// GetProfile
-func (s *authService) GetProfile(ctx context.Context, userID uuid.UUID) (*models.UserProfile, int, error) {
-	user, err := s.userRepo.GetUserByID(ctx, userID)
+// mfaChallengePurpose menentukan tahap kedua login: verifikasi kode jika 2FA aktif,
+// enrollment jika role wajib 2FA (permission mfa:required), atau "" jika JWT boleh langsung diterbitkan
+func mfaChallengePurpose(ctx context.Context, mfaService MFAService, user *models.User) (string, error) {
+	mfaEnabled, err := mfaService.IsEnabled(ctx, user.ID)
 	if err != nil {
-		return nil, http.StatusNotFound, errors.New("user profile not found")
+		return "", fmt.Errorf("failed to check two-factor status: %w", err)
 	}
+	if mfaEnabled {
+		return utils.MFAChallengeVerify, nil
+	}
+	if mfaService.IsRequired(user.Permissions) {
+		return utils.MFAChallengeEnroll, nil
+	}
+	return "", nil
+}
 
-	profile := models.UserProfile{
+// buildMFAChallengeResponse menerbitkan challenge token untuk tahap kedua login
+func buildMFAChallengeResponse(user *models.User, purpose string) (*models.LoginResponse, int, error) {
+	challengeToken, err := utils.GenerateMFAChallengeToken(user.ID, purpose, utils.GetEnvDuration("MFA_CHALLENGE_TTL", 5*time.Minute))
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to generate challenge token: %w", err)
+	}
+
+	resp := &models.LoginResponse{
+		Status: "success",
+		Data: models.LoginData{
+			User:                  toUserProfile(user),
+			MFARequired:           purpose == utils.MFAChallengeVerify,
+			MFAEnrollmentRequired: purpose == utils.MFAChallengeEnroll,
+			ChallengeToken:        challengeToken,
+		},
+	}
+	return resp, http.StatusOK, nil
+}
+
+func toUserProfile(user *models.User) models.UserProfile {
+	return models.UserProfile{
 		ID:          user.ID.String(),
 		Username:    user.Username,
 		FullName:    user.FullName,
 		Role:        user.Role,
 		Permissions: user.Permissions,
 	}
+}
+
+// CompleteMFALogin menukar challenge token + kode 2FA dengan JWT.
+// Untuk challenge enrollment, kode sekaligus mengonfirmasi setup dan kode pemulihan ikut dikembalikan.
+func (s *authService) CompleteMFALogin(ctx context.Context, req *models.MFAChallengeRequest, client models.ClientInfo) (*models.LoginResponse, int, error) {
+	challenge, err := utils.ValidateMFAChallengeToken(req.ChallengeToken)
+	if err != nil {
+		return nil, http.StatusUnauthorized, errors.New("invalid or expired challenge token")
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, http.StatusUnauthorized, errors.New("invalid or expired challenge token")
+	}
+	if !user.IsActive {
+		return nil, http.StatusForbidden, errors.New("user account is inactive")
+	}
+
+	if status, guardErr := s.loginGuard.Check(ctx, user.Username, client); guardErr != nil {
+		return nil, status, guardErr
+	}
+
+	var recoveryCodes []string
+	switch challenge.Purpose {
+	case utils.MFAChallengeVerify:
+		err = s.mfaService.VerifyCode(ctx, user.ID, req.Code)
+	case utils.MFAChallengeEnroll:
+		var enabled *models.MFAEnableResponse
+		enabled, _, err = s.mfaService.ConfirmSetup(ctx, user.ID, req.Code)
+		if enabled != nil {
+			recoveryCodes = enabled.RecoveryCodes
+		}
+	default:
+		return nil, http.StatusUnauthorized, errors.New("invalid or expired challenge token")
+	}
+
+	if err != nil {
+		s.loginGuard.RecordFailure(ctx, user.Username, user, client, "invalid_mfa_code")
+		return nil, http.StatusUnauthorized, err
+	}
+	s.loginGuard.RecordSuccess(ctx, user, client)
+
+	resp, status, err := buildLoginResponse(user)
+	if err != nil {
+		return nil, status, err
+	}
+	resp.Data.RecoveryCodes = recoveryCodes
+	return resp, status, nil
+}
+
+// BeginMFAEnrollment dipakai user yang wajib 2FA tetapi belum enroll (belum punya access token)
+func (s *authService) BeginMFAEnrollment(ctx context.Context, challengeToken string) (*models.MFASetupResponse, int, error) {
+	challenge, err := utils.ValidateMFAChallengeToken(challengeToken)
+	if err != nil || challenge.Purpose != utils.MFAChallengeEnroll {
+		return nil, http.StatusUnauthorized, errors.New("invalid or expired challenge token")
+	}
+	return s.mfaService.BeginSetup(ctx, challenge.UserID)
+}
+
+// GetProfile
+func (s *authService) GetProfile(ctx context.Context, userID uuid.UUID) (*models.UserProfile, int, error) {
+	return loadUserProfile(ctx, s.userRepo, s.profileRepo, userID)
+}
+
+// loadUserProfile menyusun profil user beserta profil akademiknya (mahasiswa melihat dosen walinya sendiri di sini)
+func loadUserProfile(ctx context.Context, userRepo repositories.UserRepository, profileRepo repositories.ProfileRepository, userID uuid.UUID) (*models.UserProfile, int, error) {
+	user, err := userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, http.StatusNotFound, errors.New("user profile not found")
+	}
+
+	profile := toUserProfile(user)
+	if profile.Student, err = profileRepo.GetStudentProfileByUserID(ctx, userID); err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to load student profile")
+	}
+	if profile.Lecturer, err = profileRepo.GetLecturerProfileByUserID(ctx, userID); err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to load lecturer profile")
+	}
 	return &profile, http.StatusOK, nil
-}
\ No newline at end of file
+}
+
+// ChangePassword (user mengganti password sendiri, wajib menyertakan password lama)
+func (s *authService) ChangePassword(ctx context.Context, userID uuid.UUID, req *models.ChangePasswordRequest) (int, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return http.StatusNotFound, errors.New("user not found")
+	}
+
+	if !utils.CheckPasswordHash(req.CurrentPassword, user.PasswordHash) {
+		return http.StatusUnauthorized, errors.New("current password is incorrect")
+	}
+	if req.CurrentPassword == req.NewPassword {
+		return http.StatusBadRequest, errors.New("new password must be different from the current password")
+	}
+	if err := utils.ValidatePasswordPolicy(req.NewPassword); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	return s.setPassword(ctx, userID, req.NewPassword)
+}
+
+// ResetPassword (menggunakan token sekali pakai yang dibuat Admin)
+func (s *authService) ResetPassword(ctx context.Context, req *models.ResetPasswordRequest) (int, error) {
+	if req.Token == "" {
+		return http.StatusBadRequest, errors.New("reset token is required")
+	}
+
+	token, err := s.resetRepo.FindUnusedTokenByHash(ctx, utils.HashToken(req.Token))
+	if err != nil || time.Now().After(token.ExpiresAt) {
+		return http.StatusBadRequest, errors.New("invalid or expired reset token")
+	}
+
+	if err := utils.ValidatePasswordPolicy(req.NewPassword); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	// Tandai dipakai lebih dulu agar token tidak bisa dipakai dua kali secara bersamaan
+	if err := s.resetRepo.MarkTokenUsed(ctx, token.ID); err != nil {
+		return http.StatusBadRequest, errors.New("invalid or expired reset token")
+	}
+
+	status, err := s.setPassword(ctx, token.UserID, req.NewPassword)
+	if err != nil {
+		return status, err
+	}
+
+	_ = s.resetRepo.InvalidateUserTokens(ctx, token.UserID)
+	return http.StatusOK, nil
+}
+
+// setPassword melakukan hashing lalu menyimpan password baru (sekaligus mencabut sesi lama)
+func (s *authService) setPassword(ctx context.Context, userID uuid.UUID, password string) (int, error) {
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return http.StatusInternalServerError, errors.New("failed to hash password")
+	}
+
+	if err := s.userRepo.UpdatePassword(ctx, userID, hashedPassword); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to update password: %w", err)
+	}
+	return http.StatusOK, nil
+}
+
+// ValidateSession memastikan user masih aktif dan versi sesi token masih berlaku,
+// lalu menyegarkan role & permission pada claims dari database
+func (s *authService) ValidateSession(ctx context.Context, claims *utils.JWTCustomClaims) error {
+	user, err := s.userRepo.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	if !user.IsActive {
+		return errors.New("user account is inactive")
+	}
+
+	// Versi sesi dibandingkan persis (bukan waktu terbit) sehingga tidak bergantung pada jam aplikasi/DB
+	if claims.SessionVersion != user.SessionVersion {
+		return errors.New("session has been revoked")
+	}
+
+	// Role & permission diambil ulang dari database agar perubahan RBAC langsung berlaku
+	// tanpa menunggu token lama kedaluwarsa
+	claims.Role = user.Role
+	claims.Permissions = user.Permissions
+	return nil
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/utils"
+)
+
+var (
+	// ErrInvalidCredentials dikembalikan saat username/password salah atau akun tidak dikenal
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrAuthBackendUnavailable dikembalikan saat direktori eksternal (LDAP) tidak bisa dihubungi
+	ErrAuthBackendUnavailable = errors.New("authentication service is unavailable")
+)
+
+// Authenticator memverifikasi password saat login.
+// user adalah akun lokal hasil lookup (nil jika belum ada). Authenticator boleh
+// mengembalikan user yang diperbarui (misal role disinkronkan dari direktori).
+type Authenticator interface {
+	Authenticate(ctx context.Context, username, password string, user *models.User) (*models.User, error)
+}
+
+type localAuthenticator struct{}
+
+// NewLocalAuthenticator memverifikasi password terhadap hash bcrypt di tabel users
+func NewLocalAuthenticator() Authenticator {
+	return &localAuthenticator{}
+}
+
+func (a *localAuthenticator) Authenticate(ctx context.Context, username, password string, user *models.User) (*models.User, error) {
+	if user == nil || !utils.CheckPasswordHash(password, user.PasswordHash) {
+		return nil, ErrInvalidCredentials
+	}
+	return user, nil
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/repositories"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+)
+
+// ExportJob adalah ekspor yang sudah lolos validasi & otorisasi; Write dijalankan saat respons dialirkan
+type ExportJob struct {
+	FileName    string
+	ContentType string
+	Write       func(ctx context.Context, w io.Writer) error
+}
+
+// ExportService menghasilkan rekap prestasi dalam format CSV, XLSX, atau PDF dengan header berbahasa Indonesia
+type ExportService interface {
+	ExportAchievements(ctx context.Context, claims *utils.JWTCustomClaims, format string) (*ExportJob, int, error)
+	ExportDashboardStats(ctx context.Context, claims *utils.JWTCustomClaims, filter models.StatsFilter, format string) (*ExportJob, int, error)
+	ExportStudentReport(ctx context.Context, claims *utils.JWTCustomClaims, studentUserID uuid.UUID, format string) (*ExportJob, int, error)
+}
+
+type exportService struct {
+	achieveRepo   repositories.AchievementRepository
+	reportService ReportService
+	policy        AccessPolicy
+}
+
+func NewExportService(achieveRepo repositories.AchievementRepository, reportService ReportService, policy AccessPolicy) ExportService {
+	return &exportService{achieveRepo: achieveRepo, reportService: reportService, policy: policy}
+}
+
+// Label status dalam bahasa Indonesia untuk dokumen ekspor
+var achievementStatusLabels = map[string]string{
+	"draft":     "Draf",
+	"submitted": "Diajukan",
+	"verified":  "Terverifikasi",
+	"rejected":  "Ditolak",
+}
+
+func statusLabel(status string) string {
+	if label, ok := achievementStatusLabels[status]; ok {
+		return label
+	}
+	return status
+}
+
+func newExportJob(name string, format string, write func(ctx context.Context, rw utils.ReportWriter) error) *ExportJob {
+	return &ExportJob{
+		FileName:    fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102"), format),
+		ContentType: utils.ExportContentType(format),
+		Write: func(ctx context.Context, w io.Writer) error {
+			rw, err := utils.NewReportWriter(format, w)
+			if err != nil {
+				return err
+			}
+			if err := write(ctx, rw); err != nil {
+				return err
+			}
+			return rw.Close()
+		},
+	}
+}
+
+// ExportAchievements mengekspor daftar prestasi dengan scope yang sama seperti GET /achievements
+func (s *exportService) ExportAchievements(ctx context.Context, claims *utils.JWTCustomClaims, format string) (*ExportJob, int, error) {
+	format, err := utils.ValidateExportFormat(format)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	scope, err := s.policy.ResolveAchievementScope(ctx, claims)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to resolve access scope")
+	}
+	if !scope.All && len(scope.StudentIDs) == 0 {
+		return nil, http.StatusForbidden, errors.New("user not authorized to view achievements")
+	}
+
+	columns := []utils.ExportColumn{
+		{Header: "No", Width: 3}, {Header: "NIM", Width: 8}, {Header: "Nama Mahasiswa", Width: 14},
+		{Header: "Program Studi", Width: 10}, {Header: "Jenis Prestasi", Width: 8}, {Header: "Judul Prestasi", Width: 22},
+		{Header: "Poin", Width: 4}, {Header: "Status", Width: 7}, {Header: "Tanggal Diajukan", Width: 7},
+		{Header: "Tanggal Diverifikasi", Width: 7},
+	}
+	return newExportJob("rekap-prestasi", format, func(ctx context.Context, rw utils.ReportWriter) error {
+		if err := rw.Title("Rekap Prestasi Mahasiswa", "Tanggal cetak: "+time.Now().Format("02-01-2006")); err != nil {
+			return err
+		}
+		if err := rw.Table("", columns); err != nil {
+			return err
+		}
+		no := 0
+		return s.achieveRepo.EachAchievementRecord(ctx, *scope, func(rec models.AchievementRecord) error {
+			no++
+			return rw.Row(no, rec.StudentNumber, rec.StudentName, rec.ProgramStudy, rec.AchievementType, rec.Title,
+				rec.Points, statusLabel(rec.Status), rec.SubmittedAt, rec.VerifiedAt)
+		})
+	}), http.StatusOK, nil
+}
+
+// ExportDashboardStats mengekspor ringkasan statistik sesuai scope baca user
+func (s *exportService) ExportDashboardStats(ctx context.Context, claims *utils.JWTCustomClaims, filter models.StatsFilter, format string) (*ExportJob, int, error) {
+	format, err := utils.ValidateExportFormat(format)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	stats, status, err := s.reportService.GetDashboardStats(ctx, claims, filter)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return newExportJob("statistik-prestasi", format, func(ctx context.Context, rw utils.ReportWriter) error {
+		if err := rw.Title("Statistik Prestasi Mahasiswa", "Tanggal cetak: "+time.Now().Format("02-01-2006"),
+			"Data per: "+stats.AsOf.Format("02-01-2006 15:04"),
+			fmt.Sprintf("Total prestasi: %d", stats.TotalAchievements)); err != nil {
+			return err
+		}
+		if err := writeCountTable(rw, "Berdasarkan Status", "Status", stats.ByStatus, statusLabel); err != nil {
+			return err
+		}
+		if err := writeCountTable(rw, "Berdasarkan Jenis Prestasi", "Jenis Prestasi", stats.ByType, nil); err != nil {
+			return err
+		}
+		return writeCountTable(rw, "Berdasarkan Program Studi", "Program Studi", stats.ByProgramStudy, nil)
+	}), http.StatusOK, nil
+}
+
+// ExportStudentReport mengekspor laporan per mahasiswa (aturan akses sama dengan GET /reports/students/:id)
+func (s *exportService) ExportStudentReport(ctx context.Context, claims *utils.JWTCustomClaims, studentUserID uuid.UUID, format string) (*ExportJob, int, error) {
+	format, err := utils.ValidateExportFormat(format)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	report, status, err := s.reportService.GetStudentReport(ctx, claims, studentUserID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return newExportJob("laporan-prestasi-"+report.StudentID, format, func(ctx context.Context, rw utils.ReportWriter) error {
+		if err := rw.Title("Laporan Prestasi Mahasiswa",
+			"NIM: "+report.StudentID,
+			"Nama: "+report.FullName,
+			"Program Studi: "+report.ProgramStudy,
+			"Angkatan: "+report.AcademicYear,
+			fmt.Sprintf("Total poin terverifikasi: %d", report.TotalPoints),
+		); err != nil {
+			return err
+		}
+
+		if err := rw.Table("Perkembangan per Semester", []utils.ExportColumn{
+			{Header: "Semester", Width: 10}, {Header: "Jumlah Prestasi", Width: 6},
+			{Header: "Prestasi Terverifikasi", Width: 6}, {Header: "Poin Terverifikasi", Width: 6},
+		}); err != nil {
+			return err
+		}
+		for _, t := range report.Timeline {
+			if err := rw.Row(t.Semester, t.TotalAchievements, t.VerifiedAchievements, t.VerifiedPoints); err != nil {
+				return err
+			}
+		}
+
+		if err := rw.Table("Prestasi Teratas", []utils.ExportColumn{
+			{Header: "No", Width: 2}, {Header: "Judul Prestasi", Width: 16}, {Header: "Jenis Prestasi", Width: 6},
+			{Header: "Poin", Width: 3}, {Header: "Tanggal Diverifikasi", Width: 5},
+		}); err != nil {
+			return err
+		}
+		for i, rec := range report.TopAchievements {
+			if err := rw.Row(i+1, rec.Title, rec.AchievementType, rec.Points, rec.VerifiedAt); err != nil {
+				return err
+			}
+		}
+
+		if err := writeCountTable(rw, "Berdasarkan Status", "Status", report.ByStatus, statusLabel); err != nil {
+			return err
+		}
+		return writeCountTable(rw, "Berdasarkan Jenis Prestasi", "Jenis Prestasi", report.ByType, nil)
+	}), http.StatusOK, nil
+}
+
+// writeCountTable menulis tabel dua kolom (kategori, jumlah) dengan urutan kunci yang stabil
+func writeCountTable(rw utils.ReportWriter, caption string, header string, counts map[string]int, label func(string) string) error {
+	if err := rw.Table(caption, []utils.ExportColumn{{Header: header, Width: 10}, {Header: "Jumlah", Width: 5}}); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		name := key
+		if label != nil {
+			name = label(key)
+		}
+		if err := rw.Row(name, counts[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/repositories"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/google/uuid"
+)
+
+// LDAPRoleMapping memetakan satu nilai atribut (misal DN grup pada memberOf) ke nama role
+type LDAPRoleMapping struct {
+	Value    string
+	RoleName string
+}
+
+// LDAPConfig konfigurasi autentikasi staf melalui LDAP/Active Directory
+type LDAPConfig struct {
+	URL                string // ldap://host:389 atau ldaps://host:636
+	StartTLS           bool
+	InsecureSkipVerify bool
+	Timeout            time.Duration
+
+	// Akun service untuk mencari DN user (kosongkan jika direktori mengizinkan anonymous search)
+	BindDN       string
+	BindPassword string
+
+	BaseDN     string
+	UserFilter string // %s diganti username (sudah di-escape), contoh: (uid=%s) atau (sAMAccountName=%s)
+
+	EmailAttribute string
+	NameAttribute  string
+	RoleAttribute  string
+	RoleMappings   []LDAPRoleMapping // urutan menentukan prioritas
+
+	// AutoProvision membuat akun lokal untuk staf yang berhasil login tetapi belum terdaftar
+	AutoProvision bool
+	// FallbackLocal: jika direktori tidak bisa dihubungi, akun LDAP boleh login dengan password lokal
+	FallbackLocal bool
+}
+
+// LDAPConfigFromEnv membaca konfigurasi dari environment (dengan nilai default)
+func LDAPConfigFromEnv() LDAPConfig {
+	return LDAPConfig{
+		URL:                utils.GetEnv("LDAP_URL", ""),
+		StartTLS:           utils.GetEnv("LDAP_START_TLS", "false") == "true",
+		InsecureSkipVerify: utils.GetEnv("LDAP_INSECURE_SKIP_VERIFY", "false") == "true",
+		Timeout:            utils.GetEnvDuration("LDAP_TIMEOUT", 5*time.Second),
+		BindDN:             utils.GetEnv("LDAP_BIND_DN", ""),
+		BindPassword:       utils.GetEnv("LDAP_BIND_PASSWORD", ""),
+		BaseDN:             utils.GetEnv("LDAP_BASE_DN", ""),
+		UserFilter:         utils.GetEnv("LDAP_USER_FILTER", "(uid=%s)"),
+		EmailAttribute:     utils.GetEnv("LDAP_EMAIL_ATTRIBUTE", "mail"),
+		NameAttribute:      utils.GetEnv("LDAP_NAME_ATTRIBUTE", "displayName"),
+		RoleAttribute:      utils.GetEnv("LDAP_ROLE_ATTRIBUTE", "memberOf"),
+		RoleMappings:       ParseLDAPRoleMappings(utils.GetEnv("LDAP_ROLE_MAPPING", "")),
+		AutoProvision:      utils.GetEnv("LDAP_AUTO_PROVISION", "false") == "true",
+		FallbackLocal:      utils.GetEnv("LDAP_FALLBACK_LOCAL", "false") == "true",
+	}
+}
+
+// ParseLDAPRoleMappings membaca format "nilai=>Role;nilai=>Role".
+// Pemisah "=>" dipakai karena DN grup sendiri mengandung "=" dan ",".
+func ParseLDAPRoleMappings(raw string) []LDAPRoleMapping {
+	var mappings []LDAPRoleMapping
+	for _, entry := range strings.Split(raw, ";") {
+		value, role, ok := strings.Cut(entry, "=>")
+		value, role = strings.TrimSpace(value), strings.TrimSpace(role)
+		if ok && value != "" && role != "" {
+			mappings = append(mappings, LDAPRoleMapping{Value: value, RoleName: role})
+		}
+	}
+	return mappings
+}
+
+type ldapAuthenticator struct {
+	config   LDAPConfig
+	userRepo repositories.UserRepository
+	roleRepo repositories.RoleRepository
+	local    Authenticator
+}
+
+// NewLDAPAuthenticator: hanya akun dengan auth_source ldap (atau akun baru yang di-provision)
+// yang diverifikasi ke direktori. Akun lain diverifikasi oleh local (nil = bcrypt lokal).
+func NewLDAPAuthenticator(config LDAPConfig, userRepo repositories.UserRepository, roleRepo repositories.RoleRepository, local Authenticator) Authenticator {
+	if local == nil {
+		local = NewLocalAuthenticator()
+	}
+	return &ldapAuthenticator{config: config, userRepo: userRepo, roleRepo: roleRepo, local: local}
+}
+
+type ldapEntry struct {
+	DN       string
+	Email    string
+	FullName string
+	RoleName string
+}
+
+func (a *ldapAuthenticator) Authenticate(ctx context.Context, username, password string, user *models.User) (*models.User, error) {
+	// Entry direktori dengan uid yang sama tidak boleh mengambil alih akun lokal
+	if user != nil && user.AuthSource != models.AuthSourceLDAP {
+		return a.local.Authenticate(ctx, username, password, user)
+	}
+
+	// Bind dengan password kosong = unauthenticated bind yang selalu sukses di banyak server
+	if password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := a.dial()
+	if err != nil {
+		log.Printf("ldap: failed to connect: %v", err)
+		return a.fallbackLocal(ctx, username, password, user)
+	}
+	defer conn.Close()
+
+	entry, err := a.findEntry(conn, username)
+	if err != nil {
+		if errors.Is(err, ErrInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+		log.Printf("ldap: user search failed: %v", err)
+		return a.fallbackLocal(ctx, username, password, user)
+	}
+
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+		log.Printf("ldap: user bind failed: %v", err)
+		return nil, ErrAuthBackendUnavailable
+	}
+
+	return a.syncLocalUser(ctx, username, entry, user)
+}
+
+// fallbackLocal dipakai hanya saat direktori tidak bisa dihubungi dan LDAP_FALLBACK_LOCAL aktif
+func (a *ldapAuthenticator) fallbackLocal(ctx context.Context, username, password string, user *models.User) (*models.User, error) {
+	if !a.config.FallbackLocal || user == nil {
+		return nil, ErrAuthBackendUnavailable
+	}
+	log.Printf("ldap: directory unavailable, falling back to local password for %s", user.Username)
+	return a.local.Authenticate(ctx, username, password, user)
+}
+
+func (a *ldapAuthenticator) dial() (*ldap.Conn, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: a.config.InsecureSkipVerify}
+	conn, err := ldap.DialURL(a.config.URL, ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, err
+	}
+	conn.SetTimeout(a.config.Timeout)
+
+	if a.config.StartTLS {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// findEntry mencari DN user dengan akun service lalu memetakan atributnya
+func (a *ldapAuthenticator) findEntry(conn *ldap.Conn, username string) (*ldapEntry, error) {
+	if a.config.BindDN != "" {
+		if err := conn.Bind(a.config.BindDN, a.config.BindPassword); err != nil {
+			return nil, fmt.Errorf("service account bind failed: %w", err)
+		}
+	}
+
+	request := ldap.NewSearchRequest(
+		a.config.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, int(a.config.Timeout.Seconds()), false,
+		fmt.Sprintf(a.config.UserFilter, ldap.EscapeFilter(username)),
+		[]string{"dn", a.config.EmailAttribute, a.config.NameAttribute, a.config.RoleAttribute},
+		nil,
+	)
+	result, err := conn.Search(request)
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+	// Tidak ditemukan atau ambigu (lebih dari satu entry) diperlakukan sebagai kredensial salah
+	if len(result.Entries) != 1 {
+		return nil, ErrInvalidCredentials
+	}
+
+	e := result.Entries[0]
+	return &ldapEntry{
+		DN:       e.DN,
+		Email:    strings.ToLower(e.GetAttributeValue(a.config.EmailAttribute)),
+		FullName: e.GetAttributeValue(a.config.NameAttribute),
+		RoleName: a.mapRole(e.GetAttributeValues(a.config.RoleAttribute)),
+	}, nil
+}
+
+// mapRole mengembalikan role dari mapping pertama yang cocok (tidak peka huruf besar/kecil)
+func (a *ldapAuthenticator) mapRole(values []string) string {
+	for _, mapping := range a.config.RoleMappings {
+		for _, value := range values {
+			if strings.EqualFold(strings.TrimSpace(value), mapping.Value) {
+				return mapping.RoleName
+			}
+		}
+	}
+	return ""
+}
+
+// syncLocalUser menyamakan role akun LDAP dengan direktori, atau membuat akun baru.
+// Role akun yang bukan berasal dari direktori tidak pernah diubah.
+func (a *ldapAuthenticator) syncLocalUser(ctx context.Context, username string, entry *ldapEntry, user *models.User) (*models.User, error) {
+	if user == nil {
+		if !a.config.AutoProvision || entry.RoleName == "" {
+			return nil, ErrInvalidCredentials
+		}
+		return a.provisionUser(ctx, username, entry)
+	}
+
+	if user.AuthSource != models.AuthSourceLDAP || entry.RoleName == "" || entry.RoleName == user.Role {
+		return user, nil
+	}
+	role, err := a.roleRepo.GetRoleByName(ctx, entry.RoleName)
+	if err != nil || role == nil {
+		log.Printf("ldap: mapped role %q not found, keeping role %q for %s", entry.RoleName, user.Role, user.Username)
+		return user, nil
+	}
+	updated, err := a.userRepo.UpdateUser(ctx, user.ID, &models.UpdateUserRequest{}, &role.ID)
+	if err != nil {
+		log.Printf("ldap: failed to sync role for %s: %v", user.Username, err)
+		return user, nil
+	}
+	return updated, nil
+}
+
+// provisionUser: password lokal diisi acak sehingga akun hanya bisa login lewat LDAP
+func (a *ldapAuthenticator) provisionUser(ctx context.Context, username string, entry *ldapEntry) (*models.User, error) {
+	role, err := a.roleRepo.GetRoleByName(ctx, entry.RoleName)
+	if err != nil || role == nil {
+		return nil, fmt.Errorf("role %s not found", entry.RoleName)
+	}
+
+	randomPassword, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := utils.HashPassword(randomPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	fullName := entry.FullName
+	if fullName == "" {
+		fullName = username
+	}
+	return a.userRepo.CreateUser(ctx, &models.User{
+		ID:           uuid.New(),
+		Username:     strings.ToLower(username),
+		Email:        entry.Email,
+		PasswordHash: hashedPassword,
+		FullName:     fullName,
+		RoleID:       role.ID,
+		IsActive:     true,
+		AuthSource:   models.AuthSourceLDAP,
+	})
+}
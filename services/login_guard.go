@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"time"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/repositories"
+	"prestasi-mahasiswa-api/utils"
+)
+
+// LoginGuardConfig mengatur ambang batas proteksi brute-force login
+type LoginGuardConfig struct {
+	FailureWindow    time.Duration // jangka waktu kegagalan dihitung
+	DelayAfter       int           // delay progresif mulai setelah N kali gagal
+	BaseDelay        time.Duration
+	MaxDelay         time.Duration
+	LockoutThreshold int // akun dikunci sementara setelah N kali gagal
+	LockoutDuration  time.Duration
+	IPThreshold      int // IP diblokir sementara setelah N kali gagal (semua username)
+}
+
+// LoginGuardConfigFromEnv membaca konfigurasi dari environment (dengan nilai default)
+func LoginGuardConfigFromEnv() LoginGuardConfig {
+	return LoginGuardConfig{
+		FailureWindow:    utils.GetEnvDuration("LOGIN_FAILURE_WINDOW", 15*time.Minute),
+		DelayAfter:       utils.GetEnvInt("LOGIN_DELAY_AFTER", 3),
+		BaseDelay:        utils.GetEnvDuration("LOGIN_BASE_DELAY", time.Second),
+		MaxDelay:         utils.GetEnvDuration("LOGIN_MAX_DELAY", 30*time.Second),
+		LockoutThreshold: utils.GetEnvInt("LOGIN_LOCKOUT_THRESHOLD", 5),
+		LockoutDuration:  utils.GetEnvDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+		IPThreshold:      utils.GetEnvInt("LOGIN_IP_THRESHOLD", 20),
+	}
+}
+
+// LoginThrottleError dikembalikan saat login ditahan sementara (delay/lockout)
+type LoginThrottleError struct {
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottleError) Error() string {
+	return fmt.Sprintf("%s, try again in %d seconds", e.Message, int(math.Ceil(e.RetryAfter.Seconds())))
+}
+
+// LoginGuard menerapkan delay progresif, lockout akun, dan pembatasan per-IP
+type LoginGuard struct {
+	store  repositories.LoginAttemptStore
+	config LoginGuardConfig
+}
+
+func NewLoginGuard(store repositories.LoginAttemptStore, config LoginGuardConfig) *LoginGuard {
+	return &LoginGuard{store: store, config: config}
+}
+
+// Check memeriksa apakah username/IP boleh mencoba login saat ini
+func (g *LoginGuard) Check(ctx context.Context, username string, client models.ClientInfo) (int, error) {
+	now := time.Now()
+	since := now.Add(-g.config.FailureWindow)
+
+	if client.IPAddress != "" && g.config.IPThreshold > 0 {
+		ipFailures, err := g.store.GetFailuresByIP(ctx, client.IPAddress, since)
+		if err != nil {
+			log.Printf("login guard: failed to read IP failures: %v", err)
+		} else if ipFailures.Count >= g.config.IPThreshold {
+			if wait := ipFailures.LastFailureAt.Add(g.config.LockoutDuration).Sub(now); wait > 0 {
+				return http.StatusTooManyRequests, &LoginThrottleError{Message: "too many failed login attempts from this IP address", RetryAfter: wait}
+			}
+		}
+	}
+
+	failures, err := g.store.GetFailuresByUsername(ctx, username, since)
+	if err != nil {
+		// Fail-open: gangguan store tidak boleh membuat semua user tidak bisa login
+		log.Printf("login guard: failed to read account failures: %v", err)
+		return http.StatusOK, nil
+	}
+	if failures.Count == 0 || failures.LastFailureAt == nil {
+		return http.StatusOK, nil
+	}
+
+	if g.config.LockoutThreshold > 0 && failures.Count >= g.config.LockoutThreshold {
+		if wait := failures.LastFailureAt.Add(g.config.LockoutDuration).Sub(now); wait > 0 {
+			return http.StatusLocked, &LoginThrottleError{Message: "account is temporarily locked due to too many failed login attempts", RetryAfter: wait}
+		}
+	}
+
+	if g.config.DelayAfter > 0 && failures.Count >= g.config.DelayAfter {
+		if wait := failures.LastFailureAt.Add(g.progressiveDelay(failures.Count)).Sub(now); wait > 0 {
+			return http.StatusTooManyRequests, &LoginThrottleError{Message: "too many failed login attempts", RetryAfter: wait}
+		}
+	}
+	return http.StatusOK, nil
+}
+
+// progressiveDelay: BaseDelay * 2^(gagal - DelayAfter), maksimal MaxDelay
+func (g *LoginGuard) progressiveDelay(failureCount int) time.Duration {
+	exponent := failureCount - g.config.DelayAfter
+	if exponent > 30 {
+		return g.config.MaxDelay
+	}
+	delay := g.config.BaseDelay * time.Duration(1<<uint(exponent))
+	if delay > g.config.MaxDelay {
+		return g.config.MaxDelay
+	}
+	return delay
+}
+
+// RecordFailure mencatat login gagal
+func (g *LoginGuard) RecordFailure(ctx context.Context, username string, user *models.User, client models.ClientInfo, reason string) {
+	attempt := &models.LoginAttempt{
+		Username:      username,
+		IPAddress:     client.IPAddress,
+		UserAgent:     client.UserAgent,
+		Success:       false,
+		FailureReason: reason,
+	}
+	if user != nil {
+		attempt.UserID = &user.ID
+	}
+	if err := g.store.RecordAttempt(ctx, attempt); err != nil {
+		log.Printf("login guard: failed to record attempt: %v", err)
+	}
+}
+
+// RecordSuccess mencatat login sukses dan mereset hitungan gagal akun
+func (g *LoginGuard) RecordSuccess(ctx context.Context, user *models.User, client models.ClientInfo) {
+	attempt := &models.LoginAttempt{
+		Username:  user.Username,
+		UserID:    &user.ID,
+		IPAddress: client.IPAddress,
+		UserAgent: client.UserAgent,
+		Success:   true,
+	}
+	if err := g.store.RecordAttempt(ctx, attempt); err != nil {
+		log.Printf("login guard: failed to record attempt: %v", err)
+	}
+	if err := g.store.ClearFailures(ctx, user.Username); err != nil {
+		log.Printf("login guard: failed to clear failures: %v", err)
+	}
+}
+
+// Unlock membuka lockout akun (dipakai Admin)
+func (g *LoginGuard) Unlock(ctx context.Context, username string) error {
+	return g.store.ClearFailures(ctx, username)
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/repositories"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+)
+
+const recoveryCodeCount = 10
+
+type MFAService interface {
+	GetStatus(ctx context.Context, userID uuid.UUID, permissions []string) (*models.MFAStatus, int, error)
+	IsEnabled(ctx context.Context, userID uuid.UUID) (bool, error)
+	// IsRequired: 2FA wajib jika role user memiliki permission mfa:required
+	IsRequired(permissions []string) bool
+
+	// Enrollment
+	BeginSetup(ctx context.Context, userID uuid.UUID) (*models.MFASetupResponse, int, error)
+	ConfirmSetup(ctx context.Context, userID uuid.UUID, code string) (*models.MFAEnableResponse, int, error)
+
+	// VerifyCode menerima kode TOTP atau kode pemulihan (sekali pakai)
+	VerifyCode(ctx context.Context, userID uuid.UUID, code string) error
+	Disable(ctx context.Context, userID uuid.UUID, permissions []string, req *models.MFADisableRequest) (int, error)
+	Reset(ctx context.Context, userID uuid.UUID) (int, error) // Admin
+}
+
+type mfaService struct {
+	mfaRepo  repositories.MFARepository
+	userRepo repositories.UserRepository
+	issuer   string
+}
+
+func NewMFAService(mfaRepo repositories.MFARepository, userRepo repositories.UserRepository, issuer string) MFAService {
+	return &mfaService{mfaRepo: mfaRepo, userRepo: userRepo, issuer: issuer}
+}
+
+func (s *mfaService) IsRequired(permissions []string) bool {
+	return containsString(permissions, models.PermissionMFARequired)
+}
+
+func (s *mfaService) IsEnabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	mfa, err := s.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return mfa != nil && mfa.Enabled, nil
+}
+
+// GetStatus
+func (s *mfaService) GetStatus(ctx context.Context, userID uuid.UUID, permissions []string) (*models.MFAStatus, int, error) {
+	enabled, err := s.IsEnabled(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return &models.MFAStatus{Enabled: enabled, Required: s.IsRequired(permissions)}, http.StatusOK, nil
+}
+
+// BeginSetup membuat secret baru (belum aktif sampai dikonfirmasi dengan kode)
+func (s *mfaService) BeginSetup(ctx context.Context, userID uuid.UUID) (*models.MFASetupResponse, int, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, http.StatusNotFound, errors.New("user not found")
+	}
+
+	enrollment, err := utils.GenerateTOTPEnrollment(s.issuer, user.Username)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to generate two-factor secret")
+	}
+
+	if err := s.mfaRepo.SavePendingSecret(ctx, userID, enrollment.Secret); err != nil {
+		return nil, http.StatusConflict, err
+	}
+
+	return &models.MFASetupResponse{
+		Secret:          enrollment.Secret,
+		ProvisioningURI: enrollment.ProvisioningURI,
+		QRCode:          enrollment.QRCodeDataURI,
+	}, http.StatusOK, nil
+}
+
+// ConfirmSetup mengaktifkan 2FA setelah user membuktikan authenticator sudah terpasang
+func (s *mfaService) ConfirmSetup(ctx context.Context, userID uuid.UUID, code string) (*models.MFAEnableResponse, int, error) {
+	mfa, err := s.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if mfa == nil {
+		return nil, http.StatusBadRequest, errors.New("two-factor setup has not been started")
+	}
+	if mfa.Enabled {
+		return nil, http.StatusConflict, errors.New("two-factor authentication is already enabled")
+	}
+
+	step, ok := utils.MatchTOTPCode(mfa.Secret, code, time.Now())
+	if !ok {
+		return nil, http.StatusUnauthorized, errors.New("invalid two-factor code")
+	}
+
+	recoveryCodes, err := utils.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to generate recovery codes")
+	}
+	hashes := make([]string, 0, len(recoveryCodes))
+	for _, rc := range recoveryCodes {
+		hashes = append(hashes, utils.HashToken(utils.NormalizeRecoveryCode(rc)))
+	}
+
+	if err := s.mfaRepo.Enable(ctx, userID, hashes, step); err != nil {
+		return nil, http.StatusConflict, err
+	}
+	return &models.MFAEnableResponse{RecoveryCodes: recoveryCodes}, http.StatusOK, nil
+}
+
+// VerifyCode
+func (s *mfaService) VerifyCode(ctx context.Context, userID uuid.UUID, code string) error {
+	mfa, err := s.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if mfa == nil || !mfa.Enabled {
+		return errors.New("two-factor authentication is not enabled")
+	}
+
+	if step, ok := utils.MatchTOTPCode(mfa.Secret, code, time.Now()); ok {
+		fresh, err := s.mfaRepo.MarkStepUsed(ctx, userID, step)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return errors.New("two-factor code has already been used")
+		}
+		return nil
+	}
+
+	consumed, err := s.mfaRepo.ConsumeRecoveryCode(ctx, userID, utils.HashToken(utils.NormalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		return errors.New("invalid two-factor code")
+	}
+	return nil
+}
+
+// Disable (oleh user sendiri). Re-autentikasi memakai kode TOTP/pemulihan, bukan password lokal,
+// karena akun LDAP/SSO tidak memiliki password lokal.
+func (s *mfaService) Disable(ctx context.Context, userID uuid.UUID, permissions []string, req *models.MFADisableRequest) (int, error) {
+	if s.IsRequired(permissions) {
+		return http.StatusForbidden, errors.New("two-factor authentication is mandatory for your role")
+	}
+	if req.Code == "" {
+		return http.StatusBadRequest, errors.New("two-factor code is required")
+	}
+	if err := s.VerifyCode(ctx, userID, req.Code); err != nil {
+		return http.StatusUnauthorized, err
+	}
+
+	if err := s.mfaRepo.Delete(ctx, userID); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to disable two-factor authentication: %w", err)
+	}
+	return http.StatusOK, nil
+}
+
+// Reset (Admin) menghapus konfigurasi 2FA, misalnya saat user kehilangan perangkat
+func (s *mfaService) Reset(ctx context.Context, userID uuid.UUID) (int, error) {
+	if _, err := s.userRepo.GetUserByID(ctx, userID); err != nil {
+		return http.StatusNotFound, errors.New("user not found")
+	}
+	if err := s.mfaRepo.Delete(ctx, userID); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to reset two-factor authentication: %w", err)
+	}
+	return http.StatusOK, nil
+}
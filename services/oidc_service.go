@@ -0,0 +1,358 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/repositories"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+// OIDCConfig konfigurasi login SSO melalui identity provider kampus
+type OIDCConfig struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+
+	// Nama klaim pada ID token (berbeda-beda antar IdP)
+	EmailClaim        string
+	NameClaim         string
+	StudentIDClaim    string // NIM
+	LecturerIDClaim   string // NIP
+	ProgramStudyClaim string
+
+	// AutoProvisionStudents membuat akun Mahasiswa baru jika NIM belum terdaftar
+	AutoProvisionStudents bool
+	StudentRoleName       string
+
+	// Nilai klaim amr/acr yang dianggap bukti MFA di IdP; selain itu 2FA aplikasi tetap diminta
+	MFAAMRValues []string
+	MFAACRValues []string
+}
+
+// OIDCConfigFromEnv membaca konfigurasi dari environment (dengan nilai default)
+func OIDCConfigFromEnv() OIDCConfig {
+	return OIDCConfig{
+		IssuerURL:             utils.GetEnv("OIDC_ISSUER_URL", ""),
+		ClientID:              utils.GetEnv("OIDC_CLIENT_ID", ""),
+		ClientSecret:          utils.GetEnv("OIDC_CLIENT_SECRET", ""),
+		RedirectURL:           utils.GetEnv("OIDC_REDIRECT_URL", ""),
+		Scopes:                splitEnvList("OIDC_SCOPES", "openid,profile,email"),
+		EmailClaim:            utils.GetEnv("OIDC_EMAIL_CLAIM", "email"),
+		NameClaim:             utils.GetEnv("OIDC_NAME_CLAIM", "name"),
+		StudentIDClaim:        utils.GetEnv("OIDC_STUDENT_ID_CLAIM", "nim"),
+		LecturerIDClaim:       utils.GetEnv("OIDC_LECTURER_ID_CLAIM", "nip"),
+		ProgramStudyClaim:     utils.GetEnv("OIDC_PROGRAM_STUDY_CLAIM", ""),
+		AutoProvisionStudents: utils.GetEnv("OIDC_AUTO_PROVISION_STUDENTS", "false") == "true",
+		StudentRoleName:       utils.GetEnv("OIDC_STUDENT_ROLE", "Mahasiswa"),
+		MFAAMRValues:          splitEnvList("OIDC_MFA_AMR_VALUES", "mfa,otp,hwk,swk,sms"),
+		MFAACRValues:          splitEnvList("OIDC_MFA_ACR_VALUES", ""),
+	}
+}
+
+func splitEnvList(key, fallback string) []string {
+	var values []string
+	for _, value := range strings.Split(utils.GetEnv(key, fallback), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// Enabled bernilai true jika SSO sudah dikonfigurasi
+func (c OIDCConfig) Enabled() bool {
+	return c.IssuerURL != "" && c.ClientID != ""
+}
+
+type OIDCService interface {
+	// BeginLogin membuat URL otorisasi IdP beserta state, nonce, dan PKCE verifier
+	BeginLogin(ctx context.Context) (*models.OIDCAuthRequest, int, error)
+	// CompleteLogin menukar authorization code, memverifikasi ID token, lalu menerbitkan JWT aplikasi
+	CompleteLogin(ctx context.Context, authReq *models.OIDCAuthRequest, code, state string, client models.ClientInfo) (*models.LoginResponse, int, error)
+}
+
+type oidcService struct {
+	config      OIDCConfig
+	userRepo    repositories.UserRepository
+	roleRepo    repositories.RoleRepository
+	profileRepo repositories.ProfileRepository
+	mfaService  MFAService
+	loginGuard  *LoginGuard
+
+	// Discovery dilakukan saat pertama dipakai agar API tetap bisa start walau IdP sedang down
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+func NewOIDCService(config OIDCConfig, userRepo repositories.UserRepository, roleRepo repositories.RoleRepository, profileRepo repositories.ProfileRepository, mfaService MFAService, loginGuard *LoginGuard) OIDCService {
+	return &oidcService{config: config, userRepo: userRepo, roleRepo: roleRepo, profileRepo: profileRepo, mfaService: mfaService, loginGuard: loginGuard}
+}
+
+func (s *oidcService) getProvider() (*oidc.Provider, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.provider != nil {
+		return s.provider, nil
+	}
+	// Context background: provider menyimpan context ini untuk mengambil ulang JWKS
+	provider, err := oidc.NewProvider(context.Background(), s.config.IssuerURL)
+	if err != nil {
+		return nil, err
+	}
+	s.provider = provider
+	return provider, nil
+}
+
+func (s *oidcService) oauth2Config(provider *oidc.Provider) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     s.config.ClientID,
+		ClientSecret: s.config.ClientSecret,
+		RedirectURL:  s.config.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       s.config.Scopes,
+	}
+}
+
+// BeginLogin
+func (s *oidcService) BeginLogin(ctx context.Context) (*models.OIDCAuthRequest, int, error) {
+	if !s.config.Enabled() {
+		return nil, http.StatusNotFound, errors.New("SSO login is not enabled")
+	}
+
+	provider, err := s.getProvider()
+	if err != nil {
+		return nil, http.StatusBadGateway, fmt.Errorf("identity provider is unavailable: %w", err)
+	}
+
+	state, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to generate SSO state")
+	}
+	nonce, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to generate SSO nonce")
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	authURL := s.oauth2Config(provider).AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier))
+	return &models.OIDCAuthRequest{AuthURL: authURL, State: state, Nonce: nonce, CodeVerifier: verifier}, http.StatusOK, nil
+}
+
+// CompleteLogin
+func (s *oidcService) CompleteLogin(ctx context.Context, authReq *models.OIDCAuthRequest, code, state string, client models.ClientInfo) (*models.LoginResponse, int, error) {
+	if !s.config.Enabled() {
+		return nil, http.StatusNotFound, errors.New("SSO login is not enabled")
+	}
+	if authReq == nil || authReq.State == "" || state != authReq.State {
+		return nil, http.StatusBadRequest, errors.New("invalid or expired SSO state")
+	}
+	if code == "" {
+		return nil, http.StatusBadRequest, errors.New("authorization code is required")
+	}
+
+	provider, err := s.getProvider()
+	if err != nil {
+		return nil, http.StatusBadGateway, fmt.Errorf("identity provider is unavailable: %w", err)
+	}
+
+	// 1. Tukar authorization code dengan token (PKCE)
+	token, err := s.oauth2Config(provider).Exchange(ctx, code, oauth2.VerifierOption(authReq.CodeVerifier))
+	if err != nil {
+		return nil, http.StatusUnauthorized, errors.New("failed to exchange authorization code")
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, http.StatusUnauthorized, errors.New("identity provider did not return an ID token")
+	}
+
+	// 2. Verifikasi tanda tangan, issuer, audience, masa berlaku, dan nonce
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: s.config.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, http.StatusUnauthorized, errors.New("invalid ID token")
+	}
+	if idToken.Nonce != authReq.Nonce {
+		return nil, http.StatusUnauthorized, errors.New("invalid ID token nonce")
+	}
+
+	identity, err := s.extractIdentity(idToken)
+	if err != nil {
+		return nil, http.StatusUnauthorized, err
+	}
+
+	// 3. Petakan identitas ke akun lokal (atau buat akun Mahasiswa baru)
+	user, status, err := s.resolveUser(ctx, identity)
+	if err != nil {
+		return nil, status, err
+	}
+
+	// 4. Pemeriksaan yang sama dengan login password: lockout akun/IP lalu status aktif
+	if status, guardErr := s.loginGuard.Check(ctx, user.Username, client); guardErr != nil {
+		return nil, status, guardErr
+	}
+	if !user.IsActive {
+		return nil, http.StatusForbidden, errors.New("user account is inactive")
+	}
+
+	// 5. 2FA aplikasi dilewati hanya jika IdP menyatakan sudah melakukan MFA (klaim amr/acr)
+	if !identity.MFAByIdP {
+		purpose, err := mfaChallengePurpose(ctx, s.mfaService, user)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		if purpose != "" {
+			return buildMFAChallengeResponse(user, purpose)
+		}
+	}
+
+	s.loginGuard.RecordSuccess(ctx, user, client)
+	return buildLoginResponse(user)
+}
+
+// extractIdentity membaca klaim sesuai nama yang dikonfigurasi.
+// NIM/NIP bisa dikirim IdP sebagai string maupun angka JSON.
+func (s *oidcService) extractIdentity(idToken *oidc.IDToken) (*models.OIDCIdentity, error) {
+	var claims map[string]json.RawMessage
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, errors.New("invalid ID token claims")
+	}
+
+	identity := &models.OIDCIdentity{
+		Subject:      idToken.Subject,
+		Email:        strings.ToLower(claimString(claims, s.config.EmailClaim)),
+		FullName:     claimString(claims, s.config.NameClaim),
+		StudentID:    claimString(claims, s.config.StudentIDClaim),
+		LecturerID:   claimString(claims, s.config.LecturerIDClaim),
+		ProgramStudy: claimString(claims, s.config.ProgramStudyClaim),
+	}
+	if raw, ok := claims["email_verified"]; ok {
+		var verified bool
+		if json.Unmarshal(raw, &verified) == nil {
+			identity.EmailVerified = &verified
+		}
+	}
+	identity.MFAByIdP = s.mfaPerformedByIdP(claims)
+	return identity, nil
+}
+
+// mfaPerformedByIdP mencocokkan klaim amr (array) dan acr (string) dengan nilai yang dikonfigurasi
+func (s *oidcService) mfaPerformedByIdP(claims map[string]json.RawMessage) bool {
+	var amr []string
+	if raw, ok := claims["amr"]; ok && json.Unmarshal(raw, &amr) == nil {
+		for _, method := range amr {
+			if containsFold(s.config.MFAAMRValues, method) {
+				return true
+			}
+		}
+	}
+	acr := claimString(claims, "acr")
+	return acr != "" && containsFold(s.config.MFAACRValues, acr)
+}
+
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func claimString(claims map[string]json.RawMessage, name string) string {
+	raw, ok := claims[name]
+	if name == "" || !ok {
+		return ""
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return strings.TrimSpace(value)
+	}
+	var number json.Number
+	if err := json.Unmarshal(raw, &number); err == nil {
+		return number.String()
+	}
+	return ""
+}
+
+// resolveUser: NIM -> NIP -> email (hanya jika IdP menyatakan email_verified=true) -> auto-provision Mahasiswa
+func (s *oidcService) resolveUser(ctx context.Context, identity *models.OIDCIdentity) (*models.User, int, error) {
+	if identity.StudentID != "" {
+		if user, err := s.userRepo.FindUserByStudentNumber(ctx, identity.StudentID); err == nil {
+			return user, http.StatusOK, nil
+		}
+	}
+	if identity.LecturerID != "" {
+		if user, err := s.userRepo.FindUserByLecturerNumber(ctx, identity.LecturerID); err == nil {
+			return user, http.StatusOK, nil
+		}
+	}
+	if identity.Email != "" && identity.EmailVerified != nil && *identity.EmailVerified {
+		if user, err := s.userRepo.FindUserByEmail(ctx, identity.Email); err == nil {
+			return user, http.StatusOK, nil
+		}
+	}
+
+	if s.config.AutoProvisionStudents && identity.StudentID != "" && identity.Email != "" {
+		return s.provisionStudent(ctx, identity)
+	}
+	return nil, http.StatusForbidden, errors.New("no account is linked to this SSO identity")
+}
+
+// provisionStudent membuat akun Mahasiswa baru (username = NIM) beserta profilnya.
+// Password acak tidak pernah dibagikan, login selanjutnya tetap melalui SSO.
+func (s *oidcService) provisionStudent(ctx context.Context, identity *models.OIDCIdentity) (*models.User, int, error) {
+	role, err := s.roleRepo.GetRoleByName(ctx, s.config.StudentRoleName)
+	if err != nil || role == nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("role %s not found", s.config.StudentRoleName)
+	}
+
+	randomPassword, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to generate password")
+	}
+	hashedPassword, err := utils.HashPassword(randomPassword)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to hash password")
+	}
+
+	fullName := identity.FullName
+	if fullName == "" {
+		fullName = identity.StudentID
+	}
+
+	user, err := s.userRepo.CreateUser(ctx, &models.User{
+		ID:           uuid.New(),
+		Username:     strings.ToLower(identity.StudentID),
+		Email:        identity.Email,
+		PasswordHash: hashedPassword,
+		FullName:     fullName,
+		RoleID:       role.ID,
+		IsActive:     true,
+	})
+	if err != nil {
+		return nil, http.StatusConflict, fmt.Errorf("failed to provision SSO account: %w", err)
+	}
+
+	if _, err := s.profileRepo.UpsertStudent(ctx, &models.Student{
+		ID:           uuid.New(),
+		UserID:       user.ID,
+		StudentID:    identity.StudentID,
+		ProgramStudy: identity.ProgramStudy,
+	}); err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to create student profile: %w", err)
+	}
+	return user, http.StatusOK, nil
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// runPeriodic menjalankan fn segera lalu setiap interval di background sampai ctx dibatalkan
+func runPeriodic(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context)) {
+	if interval <= 0 {
+		log.Printf("%s: invalid interval %v, job not started", name, interval)
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			fn(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
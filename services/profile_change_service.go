@@ -0,0 +1,388 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/repositories"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+)
+
+// ProfileChangeService menangani edit profil mandiri: nama/email langsung diterapkan,
+// sedangkan NIM/NIP, prodi, angkatan, dan departemen diajukan dulu ke Admin
+type ProfileChangeService interface {
+	UpdateOwnProfile(ctx context.Context, userID uuid.UUID, req *models.SelfProfileUpdateRequest) (*models.SelfProfileUpdateResult, int, error)
+	ListOwnRequests(ctx context.Context, userID uuid.UUID) ([]models.ProfileChangeRequest, int, error)
+	ListRequests(ctx context.Context, filter models.ProfileChangeFilter) ([]models.ProfileChangeRequest, int, error)
+	Approve(ctx context.Context, reviewerID uuid.UUID, requestID uuid.UUID, req *models.ReviewProfileChangeRequest) (*models.ProfileChangeRequest, int, error)
+	Reject(ctx context.Context, reviewerID uuid.UUID, requestID uuid.UUID, req *models.ReviewProfileChangeRequest) (*models.ProfileChangeRequest, int, error)
+}
+
+type profileChangeService struct {
+	changeRepo  repositories.ProfileChangeRepository
+	userRepo    repositories.UserRepository
+	profileRepo repositories.ProfileRepository
+	unitRepo    repositories.AcademicUnitRepository
+	stats       StatsInvalidator
+}
+
+func NewProfileChangeService(changeRepo repositories.ProfileChangeRepository, userRepo repositories.UserRepository, profileRepo repositories.ProfileRepository, unitRepo repositories.AcademicUnitRepository, stats StatsInvalidator) ProfileChangeService {
+	return &profileChangeService{changeRepo: changeRepo, userRepo: userRepo, profileRepo: profileRepo, unitRepo: unitRepo, stats: stats}
+}
+
+func hasStudentChanges(req *models.SelfProfileUpdateRequest) bool {
+	return req.StudentID != nil || req.StudyProgramID != nil || req.AcademicYear != nil
+}
+
+func hasLecturerChanges(req *models.SelfProfileUpdateRequest) bool {
+	return req.LecturerID != nil || req.DepartmentID != nil
+}
+
+func (s *profileChangeService) UpdateOwnProfile(ctx context.Context, userID uuid.UUID, req *models.SelfProfileUpdateRequest) (*models.SelfProfileUpdateResult, int, error) {
+	if req.FullName == nil && req.Email == nil && !hasStudentChanges(req) && !hasLecturerChanges(req) {
+		return nil, http.StatusBadRequest, errors.New("no changes submitted")
+	}
+	if hasStudentChanges(req) && hasLecturerChanges(req) {
+		return nil, http.StatusBadRequest, errors.New("student and lecturer fields cannot be changed together")
+	}
+	if _, err := s.userRepo.GetUserByID(ctx, userID); err != nil {
+		return nil, http.StatusNotFound, errors.New("user not found")
+	}
+
+	// 1. Susun pengajuan data sensitif lebih dulu agar tidak ada perubahan parsial jika validasinya gagal
+	var change *models.ProfileChangeRequest
+	var status int
+	var err error
+	switch {
+	case hasStudentChanges(req):
+		change, status, err = s.buildStudentChange(ctx, userID, req)
+	case hasLecturerChanges(req):
+		change, status, err = s.buildLecturerChange(ctx, userID, req)
+	}
+	if err != nil {
+		return nil, status, err
+	}
+	if change != nil {
+		pending, err := s.changeRepo.HasPending(ctx, userID)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		if pending {
+			return nil, http.StatusConflict, repositories.ErrPendingChangeExists
+		}
+	}
+
+	// 2. Nama & email langsung diterapkan
+	if req.FullName != nil || req.Email != nil {
+		update, status, err := s.validateDirectUpdate(ctx, userID, req)
+		if err != nil {
+			return nil, status, err
+		}
+		if _, err := s.userRepo.UpdateUser(ctx, userID, update, nil); err != nil {
+			status, err := repositoryWriteError(err)
+			return nil, status, err
+		}
+	}
+
+	// 3. Simpan pengajuan untuk direview Admin
+	if change != nil {
+		if err := s.changeRepo.Create(ctx, change); err != nil {
+			if errors.Is(err, repositories.ErrPendingChangeExists) {
+				return nil, http.StatusConflict, err
+			}
+			return nil, http.StatusInternalServerError, err
+		}
+	}
+
+	profile, status, err := loadUserProfile(ctx, s.userRepo, s.profileRepo, userID)
+	if err != nil {
+		return nil, status, err
+	}
+	return &models.SelfProfileUpdateResult{Profile: profile, ChangeRequest: change}, http.StatusOK, nil
+}
+
+func (s *profileChangeService) validateDirectUpdate(ctx context.Context, userID uuid.UUID, req *models.SelfProfileUpdateRequest) (*models.UpdateUserRequest, int, error) {
+	update := &models.UpdateUserRequest{}
+	var fields []utils.FieldError
+	if req.FullName != nil {
+		fullName := strings.TrimSpace(*req.FullName)
+		if fullName == "" {
+			fields = append(fields, utils.FieldError{Field: "fullName", Message: "full name is required"})
+		}
+		update.FullName = &fullName
+	}
+	if req.Email != nil {
+		email := strings.TrimSpace(*req.Email)
+		if !isValidEmail(email) {
+			fields = append(fields, utils.FieldError{Field: "email", Message: "email format is invalid"})
+		}
+		update.Email = &email
+	}
+	if len(fields) > 0 {
+		return nil, http.StatusUnprocessableEntity, newValidationError(fields)
+	}
+
+	if update.Email != nil {
+		if status, err := checkDuplicates(ctx, s.userRepo, models.UserUniqueFields{Email: *update.Email}, userID); err != nil {
+			return nil, status, err
+		}
+	}
+	return update, http.StatusOK, nil
+}
+
+// buildStudentChange hanya mencatat field yang benar-benar berubah; nil jika tidak ada yang berbeda
+func (s *profileChangeService) buildStudentChange(ctx context.Context, userID uuid.UUID, req *models.SelfProfileUpdateRequest) (*models.ProfileChangeRequest, int, error) {
+	current, err := s.profileRepo.GetStudentProfileByUserID(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if current == nil {
+		return nil, http.StatusBadRequest, errors.New("student profile not found, contact an administrator")
+	}
+
+	changes, previous := map[string]string{}, map[string]string{}
+	if req.StudentID != nil {
+		studentID := strings.TrimSpace(*req.StudentID)
+		if studentID == "" {
+			return nil, http.StatusUnprocessableEntity, newValidationError([]utils.FieldError{{Field: "studentId", Message: "student ID is required"}})
+		}
+		if studentID != current.StudentID {
+			if status, err := checkDuplicates(ctx, s.userRepo, models.UserUniqueFields{StudentID: studentID}, userID); err != nil {
+				return nil, status, err
+			}
+			changes[models.ProfileFieldStudentID] = studentID
+			previous[models.ProfileFieldStudentID] = current.StudentID
+		}
+	}
+	if req.StudyProgramID != nil {
+		program, status, err := resolveStudyProgram(ctx, s.unitRepo, strings.TrimSpace(*req.StudyProgramID), "")
+		if err != nil {
+			return nil, status, err
+		}
+		if current.StudyProgramID == nil || *current.StudyProgramID != program.ID {
+			changes[models.ProfileFieldStudyProgramID] = program.ID.String()
+			changes[models.ProfileFieldProgramStudy] = program.Name
+			previous[models.ProfileFieldStudyProgramID] = uuidString(current.StudyProgramID)
+			previous[models.ProfileFieldProgramStudy] = current.ProgramStudy
+		}
+	}
+	if req.AcademicYear != nil {
+		academicYear := strings.TrimSpace(*req.AcademicYear)
+		if academicYear != current.AcademicYear {
+			changes[models.ProfileFieldAcademicYear] = academicYear
+			previous[models.ProfileFieldAcademicYear] = current.AcademicYear
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil, http.StatusOK, nil
+	}
+	return &models.ProfileChangeRequest{
+		UserID: userID, ProfileType: models.ProfileTypeStudent, Changes: changes, Previous: previous, Reason: strings.TrimSpace(req.Reason),
+	}, http.StatusOK, nil
+}
+
+func (s *profileChangeService) buildLecturerChange(ctx context.Context, userID uuid.UUID, req *models.SelfProfileUpdateRequest) (*models.ProfileChangeRequest, int, error) {
+	current, err := s.profileRepo.GetLecturerProfileByUserID(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if current == nil {
+		return nil, http.StatusBadRequest, errors.New("lecturer profile not found, contact an administrator")
+	}
+
+	changes, previous := map[string]string{}, map[string]string{}
+	if req.LecturerID != nil {
+		lecturerID := strings.TrimSpace(*req.LecturerID)
+		if lecturerID == "" {
+			return nil, http.StatusUnprocessableEntity, newValidationError([]utils.FieldError{{Field: "lecturerId", Message: "lecturer ID is required"}})
+		}
+		if lecturerID != current.LecturerID {
+			if status, err := checkDuplicates(ctx, s.userRepo, models.UserUniqueFields{LecturerID: lecturerID}, userID); err != nil {
+				return nil, status, err
+			}
+			changes[models.ProfileFieldLecturerID] = lecturerID
+			previous[models.ProfileFieldLecturerID] = current.LecturerID
+		}
+	}
+	if req.DepartmentID != nil {
+		department, status, err := resolveDepartment(ctx, s.unitRepo, strings.TrimSpace(*req.DepartmentID), "")
+		if err != nil {
+			return nil, status, err
+		}
+		if current.DepartmentID == nil || *current.DepartmentID != department.ID {
+			changes[models.ProfileFieldDepartmentID] = department.ID.String()
+			changes[models.ProfileFieldDepartment] = department.Name
+			previous[models.ProfileFieldDepartmentID] = uuidString(current.DepartmentID)
+			previous[models.ProfileFieldDepartment] = current.Department
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil, http.StatusOK, nil
+	}
+	return &models.ProfileChangeRequest{
+		UserID: userID, ProfileType: models.ProfileTypeLecturer, Changes: changes, Previous: previous, Reason: strings.TrimSpace(req.Reason),
+	}, http.StatusOK, nil
+}
+
+func (s *profileChangeService) ListOwnRequests(ctx context.Context, userID uuid.UUID) ([]models.ProfileChangeRequest, int, error) {
+	return s.ListRequests(ctx, models.ProfileChangeFilter{UserID: &userID})
+}
+
+func (s *profileChangeService) ListRequests(ctx context.Context, filter models.ProfileChangeFilter) ([]models.ProfileChangeRequest, int, error) {
+	switch filter.Status {
+	case "", models.ProfileChangePending, models.ProfileChangeApproved, models.ProfileChangeRejected:
+	default:
+		return nil, http.StatusBadRequest, errors.New("invalid status filter")
+	}
+
+	requests, err := s.changeRepo.List(ctx, filter)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return requests, http.StatusOK, nil
+}
+
+func (s *profileChangeService) getPending(ctx context.Context, requestID uuid.UUID) (*models.ProfileChangeRequest, int, error) {
+	change, err := s.changeRepo.GetByID(ctx, requestID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if change == nil {
+		return nil, http.StatusNotFound, errors.New("profile change request not found")
+	}
+	if change.Status != models.ProfileChangePending {
+		return nil, http.StatusConflict, repositories.ErrChangeRequestNotPending
+	}
+	return change, http.StatusOK, nil
+}
+
+// Approve memvalidasi ulang (NIM/NIP bisa sudah dipakai user lain sejak diajukan) lalu menerapkan perubahan
+func (s *profileChangeService) Approve(ctx context.Context, reviewerID uuid.UUID, requestID uuid.UUID, req *models.ReviewProfileChangeRequest) (*models.ProfileChangeRequest, int, error) {
+	change, status, err := s.getPending(ctx, requestID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	switch change.ProfileType {
+	case models.ProfileTypeStudent:
+		status, err = s.applyStudentChange(ctx, reviewerID, change, req.Note)
+	case models.ProfileTypeLecturer:
+		status, err = s.applyLecturerChange(ctx, reviewerID, change, req.Note)
+	default:
+		return nil, http.StatusInternalServerError, errors.New("unknown profile type")
+	}
+	if errors.Is(err, repositories.ErrChangeRequestNotPending) {
+		return nil, http.StatusConflict, err
+	}
+	if err != nil {
+		return nil, status, err
+	}
+	// Prodi mahasiswa atau departemen dosen bisa berubah, begitu pula scope statistiknya
+	invalidateStats(ctx, s.stats)
+	return s.reload(ctx, requestID)
+}
+
+func (s *profileChangeService) applyStudentChange(ctx context.Context, reviewerID uuid.UUID, change *models.ProfileChangeRequest, note string) (int, error) {
+	current, err := s.profileRepo.GetStudentProfileByUserID(ctx, change.UserID)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if current == nil {
+		return http.StatusConflict, errors.New("student profile no longer exists")
+	}
+
+	student := current.Student
+	if studentID, ok := change.Changes[models.ProfileFieldStudentID]; ok {
+		if status, err := checkDuplicates(ctx, s.userRepo, models.UserUniqueFields{StudentID: studentID}, change.UserID); err != nil {
+			return status, err
+		}
+		student.StudentID = studentID
+	}
+	if programID, ok := change.Changes[models.ProfileFieldStudyProgramID]; ok {
+		program, status, err := resolveStudyProgram(ctx, s.unitRepo, programID, "")
+		if err != nil {
+			return status, err
+		}
+		student.StudyProgramID = &program.ID
+		student.ProgramStudy = program.Name
+	}
+	if academicYear, ok := change.Changes[models.ProfileFieldAcademicYear]; ok {
+		student.AcademicYear = academicYear
+	}
+
+	if err := s.changeRepo.ApproveStudentChange(ctx, change.ID, reviewerID, strings.TrimSpace(note), &student); err != nil {
+		return repositoryWriteError(err)
+	}
+	return http.StatusOK, nil
+}
+
+func (s *profileChangeService) applyLecturerChange(ctx context.Context, reviewerID uuid.UUID, change *models.ProfileChangeRequest, note string) (int, error) {
+	current, err := s.profileRepo.GetLecturerProfileByUserID(ctx, change.UserID)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if current == nil {
+		return http.StatusConflict, errors.New("lecturer profile no longer exists")
+	}
+
+	lecturer := current.Lecturer
+	if lecturerID, ok := change.Changes[models.ProfileFieldLecturerID]; ok {
+		if status, err := checkDuplicates(ctx, s.userRepo, models.UserUniqueFields{LecturerID: lecturerID}, change.UserID); err != nil {
+			return status, err
+		}
+		lecturer.LecturerID = lecturerID
+	}
+	if departmentID, ok := change.Changes[models.ProfileFieldDepartmentID]; ok {
+		department, status, err := resolveDepartment(ctx, s.unitRepo, departmentID, "")
+		if err != nil {
+			return status, err
+		}
+		lecturer.DepartmentID = &department.ID
+		lecturer.Department = department.Name
+	}
+
+	if err := s.changeRepo.ApproveLecturerChange(ctx, change.ID, reviewerID, strings.TrimSpace(note), &lecturer); err != nil {
+		return repositoryWriteError(err)
+	}
+	return http.StatusOK, nil
+}
+
+func (s *profileChangeService) Reject(ctx context.Context, reviewerID uuid.UUID, requestID uuid.UUID, req *models.ReviewProfileChangeRequest) (*models.ProfileChangeRequest, int, error) {
+	note := strings.TrimSpace(req.Note)
+	if note == "" {
+		return nil, http.StatusBadRequest, errors.New("rejection note is required")
+	}
+	if _, status, err := s.getPending(ctx, requestID); err != nil {
+		return nil, status, err
+	}
+
+	if err := s.changeRepo.Reject(ctx, requestID, reviewerID, note); err != nil {
+		if errors.Is(err, repositories.ErrChangeRequestNotPending) {
+			return nil, http.StatusConflict, err
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+	return s.reload(ctx, requestID)
+}
+
+func (s *profileChangeService) reload(ctx context.Context, requestID uuid.UUID) (*models.ProfileChangeRequest, int, error) {
+	change, err := s.changeRepo.GetByID(ctx, requestID)
+	if err != nil || change == nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to load profile change request")
+	}
+	return change, http.StatusOK, nil
+}
+
+func uuidString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
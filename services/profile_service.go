@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/repositories"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+)
+
+// ProfileService menyediakan endpoint baca profil mahasiswa/dosen
+type ProfileService interface {
+	GetStudentProfile(ctx context.Context, userID uuid.UUID) (*models.StudentProfileDetail, int, error)
+	GetLecturerProfile(ctx context.Context, userID uuid.UUID) (*models.LecturerProfileDetail, int, error)
+	ListAdvisees(ctx context.Context, claims *utils.JWTCustomClaims, lecturerUserID uuid.UUID) ([]models.AdviseeSummary, int, error)
+}
+
+type profileService struct {
+	profileRepo repositories.ProfileRepository
+}
+
+func NewProfileService(profileRepo repositories.ProfileRepository) ProfileService {
+	return &profileService{profileRepo: profileRepo}
+}
+
+// GetStudentProfile (NIM, prodi, angkatan, dan dosen wali)
+func (s *profileService) GetStudentProfile(ctx context.Context, userID uuid.UUID) (*models.StudentProfileDetail, int, error) {
+	profile, err := s.profileRepo.GetStudentProfileByUserID(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if profile == nil {
+		return nil, http.StatusNotFound, errors.New("student profile not found")
+	}
+	return profile, http.StatusOK, nil
+}
+
+// GetLecturerProfile (NIP, departemen, dan jumlah mahasiswa bimbingan)
+func (s *profileService) GetLecturerProfile(ctx context.Context, userID uuid.UUID) (*models.LecturerProfileDetail, int, error) {
+	profile, err := s.profileRepo.GetLecturerProfileByUserID(ctx, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if profile == nil {
+		return nil, http.StatusNotFound, errors.New("lecturer profile not found")
+	}
+	return profile, http.StatusOK, nil
+}
+
+// ListAdvisees: dosen hanya boleh melihat bimbingannya sendiri, Admin (user:manage) boleh semua
+func (s *profileService) ListAdvisees(ctx context.Context, claims *utils.JWTCustomClaims, lecturerUserID uuid.UUID) ([]models.AdviseeSummary, int, error) {
+	if claims.UserID != lecturerUserID && !containsString(claims.Permissions, PermissionUserManage) {
+		return nil, http.StatusForbidden, errors.New("access denied: you can only view your own advisees")
+	}
+
+	lecturer, err := s.profileRepo.GetLecturerProfileByUserID(ctx, lecturerUserID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if lecturer == nil {
+		return nil, http.StatusNotFound, errors.New("lecturer profile not found")
+	}
+
+	advisees, err := s.profileRepo.ListAdviseesByLecturerUserID(ctx, lecturerUserID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return advisees, http.StatusOK, nil
+}
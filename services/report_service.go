@@ -2,59 +2,489 @@ package services
 
 import (
 	"context"
+	"errors"
+	"math"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
+
 	"prestasi-mahasiswa-api/models"
 	"prestasi-mahasiswa-api/repositories"
+	"prestasi-mahasiswa-api/utils"
 
 	"github.com/google/uuid"
 )
 
 type ReportService interface {
-	GetDashboardStats(ctx context.Context, userRole string, userID uuid.UUID) (*models.DashboardStats, int, error)
+	GetDashboardStats(ctx context.Context, claims *utils.JWTCustomClaims, filter models.StatsFilter) (*models.DashboardStats, int, error)
+	GetAdviseeStats(ctx context.Context, claims *utils.JWTCustomClaims, advisorUserID uuid.UUID) (*models.AdviseeStats, int, error)
+	GetStudentReport(ctx context.Context, claims *utils.JWTCustomClaims, studentUserID uuid.UUID) (*models.StudentStats, int, error)
+	GetTrends(ctx context.Context, claims *utils.JWTCustomClaims, query models.TrendQuery) (*models.TrendReport, int, error)
+	GetLeaderboard(ctx context.Context, claims *utils.JWTCustomClaims, query models.LeaderboardQuery) (*models.LeaderboardResult, int, error)
 }
 
+const (
+	// Jumlah prestasi teratas yang ditampilkan di laporan mahasiswa
+	studentReportTopAchievements = 5
+	// Batas jumlah titik deret waktu (mis. 20 tahun bulanan)
+	maxTrendPeriods = 240
+)
+
 type reportService struct {
 	achieveRepo repositories.AchievementRepository
+	profileRepo repositories.ProfileRepository
+	policy      AccessPolicy
+	stats       StatsAggregator
 }
 
-func NewReportService(achieveRepo repositories.AchievementRepository) ReportService {
-	return &reportService{achieveRepo: achieveRepo}
+func NewReportService(achieveRepo repositories.AchievementRepository, profileRepo repositories.ProfileRepository, policy AccessPolicy, stats StatsAggregator) ReportService {
+	return &reportService{achieveRepo: achieveRepo, profileRepo: profileRepo, policy: policy, stats: stats}
 }
 
-func (s *reportService) GetDashboardStats(ctx context.Context, userRole string, userID uuid.UUID) (*models.DashboardStats, int, error) {
-	var filterStudentID *uuid.UUID
+func (s *reportService) GetDashboardStats(ctx context.Context, claims *utils.JWTCustomClaims, filter models.StatsFilter) (*models.DashboardStats, int, error) {
+	for _, status := range filter.Statuses {
+		if _, ok := achievementStatusLabels[status]; !ok {
+			return nil, http.StatusBadRequest, errors.New("status must be one of: draft, submitted, verified, rejected")
+		}
+	}
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return nil, http.StatusBadRequest, errors.New("from must not be after to")
+	}
 
-	// Jika Mahasiswa, hanya lihat data sendiri
-	if userRole == "Mahasiswa" {
-		filterStudentID = &userID
+	// Statistik dihitung hanya atas data dalam scope baca user (own/advisees/department/all)
+	scope, err := s.policy.ResolveAchievementScope(ctx, claims)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if !scope.All && len(scope.StudentIDs) == 0 {
+		return nil, http.StatusForbidden, errors.New("user not authorized to view statistics")
 	}
-	// Jika Admin/Dosen Wali, lihat semua (untuk penyederhanaan FR-011 saat ini)
-	// Note: Dosen Wali idealnya difilter by advisee, tapi logic grouping-nya kompleks,
-	// kita implementasi global stats dulu untuk Dosen/Admin.
 
-	// 1. Ambil Stats Status (PostgreSQL)
-	statusStats, err := s.achieveRepo.GetStatsByStatus(ctx, filterStudentID)
+	// Tanpa filter dilayani dari agregat materialized (lewat cache); dengan filter dihitung langsung
+	var stats *models.DashboardStats
+	if filter.IsZero() {
+		stats, err = s.stats.DashboardStats(ctx, *scope)
+	} else {
+		stats, err = s.stats.QueryStats(ctx, *scope, filter)
+	}
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
+	return stats, http.StatusOK, nil
+}
+
+// GetAdviseeStats menghitung statistik khusus mahasiswa bimbingan seorang dosen wali.
+// Dosen hanya boleh melihat bimbingannya sendiri, Admin (user:manage) boleh semua.
+func (s *reportService) GetAdviseeStats(ctx context.Context, claims *utils.JWTCustomClaims, advisorUserID uuid.UUID) (*models.AdviseeStats, int, error) {
+	if claims.UserID != advisorUserID && !containsString(claims.Permissions, PermissionUserManage) {
+		return nil, http.StatusForbidden, errors.New("access denied: you can only view statistics of your own advisees")
+	}
 
-	// 2. Ambil Stats Tipe (MongoDB)
-	typeStats, err := s.achieveRepo.GetStatsByType(ctx, filterStudentID)
+	lecturer, err := s.profileRepo.GetLecturerProfileByUserID(ctx, advisorUserID)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
+	if lecturer == nil {
+		return nil, http.StatusNotFound, errors.New("lecturer profile not found")
+	}
 
-	// 3. Hitung Total
-	total := 0
-	for _, count := range statusStats {
-		total += count
+	advisees, err := s.profileRepo.ListAdviseesByLecturerUserID(ctx, advisorUserID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	studentIDs := make([]uuid.UUID, 0, len(advisees))
+	for _, a := range advisees {
+		studentIDs = append(studentIDs, a.UserID)
 	}
+	scope := models.AchievementScope{StudentIDs: studentIDs}
 
-	stats := &models.DashboardStats{
-		TotalAchievements: total,
-		ByStatus:          statusStats,
-		ByType:            typeStats,
+	counts, err := s.stats.DashboardStats(ctx, scope)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	points, err := s.achieveRepo.GetVerifiedPointsByStudent(ctx, studentIDs)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	pending, err := s.achieveRepo.CountPendingVerification(ctx, advisorUserID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
 	}
 
+	stats := &models.AdviseeStats{
+		AdvisorUserID:       advisorUserID,
+		AdviseeCount:        len(advisees),
+		TotalAchievements:   counts.TotalAchievements,
+		ByStatus:            counts.ByStatus,
+		ByType:              counts.ByType,
+		PendingVerification: pending,
+		Students:            make([]models.AdviseePoints, 0, len(advisees)),
+	}
+
+	// Mahasiswa tanpa prestasi terverifikasi tetap ditampilkan dengan poin 0
+	for _, a := range advisees {
+		total := points[a.UserID]
+		stats.Students = append(stats.Students, models.AdviseePoints{
+			UserID:               a.UserID,
+			StudentID:            a.StudentID,
+			FullName:             a.FullName,
+			TotalPoints:          total.Points,
+			VerifiedAchievements: total.Verified,
+		})
+	}
+	sort.SliceStable(stats.Students, func(i, j int) bool {
+		return stats.Students[i].TotalPoints > stats.Students[j].TotalPoints
+	})
+
 	return stats, http.StatusOK, nil
-}
\ No newline at end of file
+}
+
+// GetStudentReport menyusun laporan prestasi satu mahasiswa (pemilik, dosen wali, atau Admin sesuai scope baca)
+func (s *reportService) GetStudentReport(ctx context.Context, claims *utils.JWTCustomClaims, studentUserID uuid.UUID) (*models.StudentStats, int, error) {
+	allowed, err := s.policy.CanReadStudent(ctx, claims, studentUserID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if !allowed {
+		return nil, http.StatusForbidden, errors.New("access denied: you cannot view this student's report")
+	}
+
+	profile, err := s.profileRepo.GetStudentProfileByUserID(ctx, studentUserID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if profile == nil {
+		return nil, http.StatusNotFound, errors.New("student profile not found")
+	}
+
+	records, err := s.achieveRepo.ListAchievementRecords(ctx, models.AchievementScope{StudentIDs: []uuid.UUID{studentUserID}})
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	report := &models.StudentStats{
+		UserID:            studentUserID,
+		StudentID:         profile.StudentID,
+		FullName:          profile.FullName,
+		ProgramStudy:      profile.ProgramStudy,
+		AcademicYear:      profile.AcademicYear,
+		TotalAchievements: len(records),
+		ByStatus:          map[string]int{},
+		ByType:            map[string]int{},
+		Timeline:          []models.SemesterStats{},
+		TopAchievements:   []models.AchievementRecord{},
+	}
+
+	timeline := map[utils.Semester]*models.SemesterStats{}
+	semesters := []utils.Semester{}
+	pointAt := func(t time.Time) *models.SemesterStats {
+		semester := utils.SemesterOf(t)
+		point, ok := timeline[semester]
+		if !ok {
+			point = &models.SemesterStats{Semester: semester.Label(), AcademicYear: semester.AcademicYear, Term: semester.Term}
+			timeline[semester] = point
+			semesters = append(semesters, semester)
+		}
+		return point
+	}
+	for _, rec := range records {
+		report.ByStatus[rec.Status]++
+		report.ByType[rec.AchievementType]++
+
+		// Total dihitung pada semester pengajuan (draft yang belum diajukan: semester dibuat),
+		// sedangkan jumlah & poin terverifikasi pada semester verifikasi
+		submittedAt := rec.CreatedAt
+		if rec.SubmittedAt != nil {
+			submittedAt = *rec.SubmittedAt
+		}
+		pointAt(submittedAt).TotalAchievements++
+
+		if rec.Status == "verified" {
+			verifiedAt := submittedAt
+			if rec.VerifiedAt != nil {
+				verifiedAt = *rec.VerifiedAt
+			}
+			point := pointAt(verifiedAt)
+			report.TotalPoints += rec.Points
+			point.VerifiedAchievements++
+			point.VerifiedPoints += rec.Points
+			report.TopAchievements = append(report.TopAchievements, rec)
+		}
+	}
+
+	sort.Slice(semesters, func(i, j int) bool { return semesters[i].Before(semesters[j]) })
+	for _, semester := range semesters {
+		report.Timeline = append(report.Timeline, *timeline[semester])
+	}
+
+	sort.SliceStable(report.TopAchievements, func(i, j int) bool {
+		return report.TopAchievements[i].Points > report.TopAchievements[j].Points
+	})
+	if len(report.TopAchievements) > studentReportTopAchievements {
+		report.TopAchievements = report.TopAchievements[:studentReportTopAchievements]
+	}
+
+	return report, http.StatusOK, nil
+}
+
+// GetTrends menyusun deret waktu jumlah & poin prestasi dalam scope baca user, termasuk pertumbuhan antar periode
+func (s *reportService) GetTrends(ctx context.Context, claims *utils.JWTCustomClaims, q models.TrendQuery) (*models.TrendReport, int, error) {
+	if q.Interval == "" {
+		q.Interval = models.TrendIntervalMonth
+	}
+	if q.DateField == "" {
+		q.DateField = models.TrendDateSubmitted
+	}
+	switch q.Interval {
+	case models.TrendIntervalMonth, models.TrendIntervalSemester, models.TrendIntervalYear:
+	default:
+		return nil, http.StatusBadRequest, errors.New("interval must be one of: month, semester, year")
+	}
+	switch q.GroupBy {
+	case "", models.TrendGroupType, models.TrendGroupStatus, models.TrendGroupProgramStudy:
+	default:
+		return nil, http.StatusBadRequest, errors.New("groupBy must be one of: type, status, programStudy")
+	}
+	if q.DateField != models.TrendDateSubmitted && q.DateField != models.TrendDateVerified {
+		return nil, http.StatusBadRequest, errors.New("dateField must be one of: submittedAt, verifiedAt")
+	}
+	if q.From != nil && q.To != nil && q.From.After(*q.To) {
+		return nil, http.StatusBadRequest, errors.New("from must not be after to")
+	}
+
+	scope, err := s.policy.ResolveAchievementScope(ctx, claims)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if !scope.All && len(scope.StudentIDs) == 0 {
+		return nil, http.StatusForbidden, errors.New("user not authorized to view statistics")
+	}
+
+	rows, err := s.achieveRepo.ListTrendRows(ctx, *scope, q)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	report := &models.TrendReport{Interval: q.Interval, GroupBy: q.GroupBy, DateField: q.DateField, Periods: []models.TrendPeriod{}}
+	if len(rows) == 0 && (q.From == nil || q.To == nil) {
+		return report, http.StatusOK, nil
+	}
+
+	buckets := map[time.Time]*models.TrendPeriod{}
+	for _, row := range rows {
+		period, ok := buckets[row.PeriodStart]
+		if !ok {
+			period = &models.TrendPeriod{Start: row.PeriodStart}
+			buckets[row.PeriodStart] = period
+		}
+		points := 0
+		if row.Status == "verified" {
+			points = row.Points
+		}
+		period.Count += row.Count
+		period.Points += points
+
+		if q.GroupBy != "" {
+			key := trendGroupKey(q.GroupBy, row)
+			if period.Series == nil {
+				period.Series = map[string]models.TrendValue{}
+			}
+			value := period.Series[key]
+			value.Count += row.Count
+			value.Points += points
+			period.Series[key] = value
+		}
+	}
+
+	// Periode kosong di antara data tetap ditampilkan agar pertumbuhan dihitung terhadap periode tepat sebelumnya
+	var start, end time.Time
+	if len(rows) > 0 {
+		start, end = rows[0].PeriodStart, rows[len(rows)-1].PeriodStart
+	}
+	if q.From != nil {
+		start = trendPeriodStart(q.Interval, *q.From)
+	}
+	if q.To != nil {
+		end = trendPeriodStart(q.Interval, *q.To)
+	}
+
+	if countTrendPeriods(q.Interval, start, end) > maxTrendPeriods {
+		return nil, http.StatusBadRequest, errors.New("date range is too large for the selected interval")
+	}
+
+	var previous *models.TrendPeriod
+	for current := start; !current.After(end); current = nextTrendPeriod(q.Interval, current) {
+		period := models.TrendPeriod{Start: current}
+		if bucket, ok := buckets[current]; ok {
+			period = *bucket
+		}
+		period.Period = trendPeriodLabel(q.Interval, current)
+		if previous != nil {
+			period.CountGrowth = growthPercent(previous.Count, period.Count)
+			period.PointsGrowth = growthPercent(previous.Points, period.Points)
+		}
+		report.Periods = append(report.Periods, period)
+		previous = &report.Periods[len(report.Periods)-1]
+	}
+
+	return report, http.StatusOK, nil
+}
+
+func trendGroupKey(groupBy string, row models.TrendRow) string {
+	switch groupBy {
+	case models.TrendGroupType:
+		return row.AchievementType
+	case models.TrendGroupStatus:
+		return row.Status
+	case models.TrendGroupProgramStudy:
+		return row.ProgramStudy
+	}
+	return ""
+}
+
+// trendPeriodStart harus sejalan dengan bucketing di repository (trendBucketExpr)
+func trendPeriodStart(interval string, t time.Time) time.Time {
+	year, month := t.Year(), t.Month()
+	switch interval {
+	case models.TrendIntervalSemester:
+		switch {
+		case month >= time.August:
+			return time.Date(year, time.August, 1, 0, 0, 0, 0, time.UTC)
+		case month == time.January:
+			return time.Date(year-1, time.August, 1, 0, 0, 0, 0, time.UTC)
+		default:
+			return time.Date(year, time.February, 1, 0, 0, 0, 0, time.UTC)
+		}
+	case models.TrendIntervalYear:
+		if month >= time.August {
+			return time.Date(year, time.August, 1, 0, 0, 0, 0, time.UTC)
+		}
+		return time.Date(year-1, time.August, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+func countTrendPeriods(interval string, start, end time.Time) int {
+	months := (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month())
+	switch interval {
+	case models.TrendIntervalSemester:
+		return months/6 + 1
+	case models.TrendIntervalYear:
+		return months/12 + 1
+	default:
+		return months + 1
+	}
+}
+
+func nextTrendPeriod(interval string, start time.Time) time.Time {
+	switch interval {
+	case models.TrendIntervalSemester:
+		return start.AddDate(0, 6, 0)
+	case models.TrendIntervalYear:
+		return start.AddDate(1, 0, 0)
+	default:
+		return start.AddDate(0, 1, 0)
+	}
+}
+
+func trendPeriodLabel(interval string, start time.Time) string {
+	switch interval {
+	case models.TrendIntervalSemester:
+		return utils.SemesterOf(start).Label()
+	case models.TrendIntervalYear:
+		return utils.SemesterOf(start).AcademicYear
+	default:
+		return start.Format("2006-01")
+	}
+}
+
+// growthPercent dibulatkan 2 desimal; nil jika periode sebelumnya nol (pertumbuhan tak terdefinisi)
+func growthPercent(previous, current int) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	growth := math.Round(float64(current-previous)/float64(previous)*10000) / 100
+	return &growth
+}
+
+// GetLeaderboard memeringkat mahasiswa berdasarkan poin terverifikasi.
+// Nama & NIM hanya ditampilkan untuk mahasiswa dalam scope baca pemanggil; sisanya disamarkan.
+func (s *reportService) GetLeaderboard(ctx context.Context, claims *utils.JWTCustomClaims, q models.LeaderboardQuery) (*models.LeaderboardResult, int, error) {
+	q.Page, q.Limit = models.NormalizePage(q.Page, q.Limit)
+	q.AcademicYear = strings.TrimSpace(q.AcademicYear)
+	q.AchievementType = strings.TrimSpace(q.AchievementType)
+
+	scope, err := s.policy.ResolveAchievementScope(ctx, claims)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	entries, err := s.achieveRepo.ListLeaderboardEntries(ctx, q)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	// Urutan tampil: poin, lalu jumlah prestasi, lalu NIM agar stabil antar halaman
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.TotalPoints != b.TotalPoints {
+			return a.TotalPoints > b.TotalPoints
+		}
+		if a.VerifiedAchievements != b.VerifiedAchievements {
+			return a.VerifiedAchievements > b.VerifiedAchievements
+		}
+		return a.StudentID < b.StudentID
+	})
+	// Peringkat kompetisi: poin sama = peringkat sama, peringkat berikutnya dilewati (1, 1, 3)
+	for i := range entries {
+		if i > 0 && entries[i].TotalPoints == entries[i-1].TotalPoints {
+			entries[i].Rank = entries[i-1].Rank
+		} else {
+			entries[i].Rank = i + 1
+		}
+	}
+
+	result := &models.LeaderboardResult{
+		Items:      []models.LeaderboardEntry{},
+		Pagination: models.NewPagination(q.Page, q.Limit, len(entries)),
+	}
+	offset := (q.Page - 1) * q.Limit
+	for i := offset; i < len(entries) && i < offset+q.Limit; i++ {
+		entry := entries[i]
+		if entry.UserID != nil && *entry.UserID == claims.UserID {
+			entry.IsCurrentUser = true
+		} else if q.Anonymize || entry.UserID == nil || !scope.Includes(*entry.UserID) {
+			anonymizeLeaderboardEntry(&entry)
+		}
+		result.Items = append(result.Items, entry)
+	}
+	return result, http.StatusOK, nil
+}
+
+func anonymizeLeaderboardEntry(entry *models.LeaderboardEntry) {
+	entry.UserID = nil
+	entry.FullName = maskName(entry.FullName)
+	entry.StudentID = maskStudentID(entry.StudentID)
+	entry.Anonymized = true
+}
+
+// maskName hanya menyisakan huruf pertama tiap kata, mis. "Budi Santoso" -> "B*** S******"
+func maskName(name string) string {
+	words := strings.Fields(name)
+	for i, word := range words {
+		runes := []rune(word)
+		words[i] = string(runes[0]) + strings.Repeat("*", len(runes)-1)
+	}
+	return strings.Join(words, " ")
+}
+
+// maskStudentID menyisakan 4 digit awal (angkatan/prodi) dan 2 digit akhir NIM
+func maskStudentID(nim string) string {
+	runes := []rune(nim)
+	if len(runes) <= 6 {
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[:4]) + strings.Repeat("*", len(runes)-6) + string(runes[len(runes)-2:])
+}
@@ -0,0 +1,360 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/repositories"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RoleAdmin    = "Admin"
+	RoleLecturer = "Dosen Wali"
+	RoleStudent  = "Mahasiswa"
+
+	PermissionRoleManage = "role:manage"
+	PermissionUserManage = "user:manage"
+)
+
+// builtInRoles dirujuk berdasarkan nama oleh migrasi seed permission dan konfigurasi
+// (OIDC_STUDENT_ROLE, LDAP_ROLE_MAPPING), serta Admin dijaga dari lockout,
+// sehingga tidak boleh diganti nama atau dihapus
+var builtInRoles = map[string]bool{RoleAdmin: true, RoleLecturer: true, RoleStudent: true}
+
+// adminLockoutPermissions harus selalu dimiliki Admin agar akses manajemen tidak terkunci
+var adminLockoutPermissions = []string{PermissionRoleManage, PermissionUserManage}
+
+// Nama permission: resource:action atau resource:action:scope (huruf kecil, angka, underscore)
+var permissionNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*(:[a-z][a-z0-9_]*){1,2}$`)
+
+type RoleService interface {
+	ListRoles(ctx context.Context) ([]models.Role, int, error)
+	GetRole(ctx context.Context, roleID uuid.UUID) (*models.Role, int, error)
+	CreateRole(ctx context.Context, req *models.RoleRequest) (*models.Role, int, error)
+	UpdateRole(ctx context.Context, roleID uuid.UUID, req *models.RoleRequest) (*models.Role, int, error)
+	DeleteRole(ctx context.Context, roleID uuid.UUID) (int, error)
+
+	SetRolePermissions(ctx context.Context, roleID uuid.UUID, req *models.RolePermissionsRequest) (*models.Role, int, error)
+	AddRolePermission(ctx context.Context, roleID uuid.UUID, permissionID uuid.UUID) (*models.Role, int, error)
+	RemoveRolePermission(ctx context.Context, roleID uuid.UUID, permissionID uuid.UUID) (*models.Role, int, error)
+
+	ListPermissions(ctx context.Context) ([]models.Permission, int, error)
+	CreatePermission(ctx context.Context, req *models.PermissionRequest) (*models.Permission, int, error)
+	UpdatePermission(ctx context.Context, permissionID uuid.UUID, req *models.PermissionRequest) (*models.Permission, int, error)
+	DeletePermission(ctx context.Context, permissionID uuid.UUID) (int, error)
+}
+
+type roleService struct {
+	roleRepo       repositories.RoleRepository
+	permissionRepo repositories.PermissionRepository
+}
+
+func NewRoleService(roleRepo repositories.RoleRepository, permissionRepo repositories.PermissionRepository) RoleService {
+	return &roleService{roleRepo: roleRepo, permissionRepo: permissionRepo}
+}
+
+// --- Roles ---
+
+func (s *roleService) ListRoles(ctx context.Context) ([]models.Role, int, error) {
+	roles, err := s.roleRepo.GetAllRoles(ctx)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return roles, http.StatusOK, nil
+}
+
+func (s *roleService) GetRole(ctx context.Context, roleID uuid.UUID) (*models.Role, int, error) {
+	role, err := s.roleRepo.GetRoleByID(ctx, roleID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if role == nil {
+		return nil, http.StatusNotFound, errors.New("role not found")
+	}
+	return role, http.StatusOK, nil
+}
+
+func (s *roleService) CreateRole(ctx context.Context, req *models.RoleRequest) (*models.Role, int, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, http.StatusBadRequest, errors.New("role name is required")
+	}
+	if status, err := s.ensureRoleNameAvailable(ctx, name, uuid.Nil); err != nil {
+		return nil, status, err
+	}
+
+	role := &models.Role{ID: uuid.New(), Name: name, Description: strings.TrimSpace(req.Description)}
+	if err := s.roleRepo.CreateRole(ctx, role); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	role.Permissions = []string{}
+	return role, http.StatusCreated, nil
+}
+
+func (s *roleService) UpdateRole(ctx context.Context, roleID uuid.UUID, req *models.RoleRequest) (*models.Role, int, error) {
+	role, status, err := s.GetRole(ctx, roleID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = role.Name
+	}
+	if name != role.Name {
+		if builtInRoles[role.Name] {
+			return nil, http.StatusForbidden, fmt.Errorf("built-in role %s cannot be renamed", role.Name)
+		}
+		if status, err := s.ensureRoleNameAvailable(ctx, name, role.ID); err != nil {
+			return nil, status, err
+		}
+	}
+
+	role.Name = name
+	role.Description = strings.TrimSpace(req.Description)
+	if err := s.roleRepo.UpdateRole(ctx, role); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return role, http.StatusOK, nil
+}
+
+// DeleteRole menolak role bawaan dan role yang masih dipakai user
+func (s *roleService) DeleteRole(ctx context.Context, roleID uuid.UUID) (int, error) {
+	role, status, err := s.GetRole(ctx, roleID)
+	if err != nil {
+		return status, err
+	}
+	if builtInRoles[role.Name] {
+		return http.StatusForbidden, fmt.Errorf("built-in role %s cannot be deleted", role.Name)
+	}
+
+	count, err := s.roleRepo.CountUsersWithRole(ctx, roleID)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if count > 0 {
+		return http.StatusConflict, fmt.Errorf("role %s is still assigned to %d user(s)", role.Name, count)
+	}
+
+	if err := s.roleRepo.DeleteRole(ctx, roleID); err != nil {
+		return http.StatusConflict, err
+	}
+	return http.StatusOK, nil
+}
+
+func (s *roleService) ensureRoleNameAvailable(ctx context.Context, name string, currentID uuid.UUID) (int, error) {
+	existing, err := s.roleRepo.GetRoleByName(ctx, name)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if existing != nil && existing.ID != currentID {
+		return http.StatusConflict, fmt.Errorf("role %s already exists", name)
+	}
+	return http.StatusOK, nil
+}
+
+// --- Role Permissions ---
+
+func (s *roleService) SetRolePermissions(ctx context.Context, roleID uuid.UUID, req *models.RolePermissionsRequest) (*models.Role, int, error) {
+	role, status, err := s.GetRole(ctx, roleID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	names := uniqueStrings(req.Permissions)
+	permissions, err := s.permissionRepo.GetPermissionsByNames(ctx, names)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if len(permissions) != len(names) {
+		return nil, http.StatusBadRequest, fmt.Errorf("unknown permission(s): %s", strings.Join(missingPermissionNames(names, permissions), ", "))
+	}
+	if role.Name == RoleAdmin {
+		for _, required := range adminLockoutPermissions {
+			if !containsString(names, required) {
+				return nil, http.StatusForbidden, fmt.Errorf("permission %s cannot be removed from role %s", required, RoleAdmin)
+			}
+		}
+	}
+
+	ids := make([]uuid.UUID, 0, len(permissions))
+	for _, p := range permissions {
+		ids = append(ids, p.ID)
+	}
+	if err := s.roleRepo.SetRolePermissions(ctx, roleID, ids); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return s.GetRole(ctx, roleID)
+}
+
+func (s *roleService) AddRolePermission(ctx context.Context, roleID uuid.UUID, permissionID uuid.UUID) (*models.Role, int, error) {
+	if _, status, err := s.GetRole(ctx, roleID); err != nil {
+		return nil, status, err
+	}
+	if _, status, err := s.getPermission(ctx, permissionID); err != nil {
+		return nil, status, err
+	}
+
+	if err := s.roleRepo.AddRolePermission(ctx, roleID, permissionID); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return s.GetRole(ctx, roleID)
+}
+
+func (s *roleService) RemoveRolePermission(ctx context.Context, roleID uuid.UUID, permissionID uuid.UUID) (*models.Role, int, error) {
+	role, status, err := s.GetRole(ctx, roleID)
+	if err != nil {
+		return nil, status, err
+	}
+	permission, status, err := s.getPermission(ctx, permissionID)
+	if err != nil {
+		return nil, status, err
+	}
+	if role.Name == RoleAdmin && containsString(adminLockoutPermissions, permission.Name) {
+		return nil, http.StatusForbidden, fmt.Errorf("permission %s cannot be removed from role %s", permission.Name, RoleAdmin)
+	}
+
+	if err := s.roleRepo.RemoveRolePermission(ctx, roleID, permissionID); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return s.GetRole(ctx, roleID)
+}
+
+// --- Permissions ---
+
+func (s *roleService) ListPermissions(ctx context.Context) ([]models.Permission, int, error) {
+	permissions, err := s.permissionRepo.ListPermissions(ctx)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return permissions, http.StatusOK, nil
+}
+
+func (s *roleService) getPermission(ctx context.Context, permissionID uuid.UUID) (*models.Permission, int, error) {
+	permission, err := s.permissionRepo.GetPermissionByID(ctx, permissionID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if permission == nil {
+		return nil, http.StatusNotFound, errors.New("permission not found")
+	}
+	return permission, http.StatusOK, nil
+}
+
+func (s *roleService) CreatePermission(ctx context.Context, req *models.PermissionRequest) (*models.Permission, int, error) {
+	name := strings.TrimSpace(req.Name)
+	if !permissionNamePattern.MatchString(name) {
+		return nil, http.StatusBadRequest, errors.New("permission name must use the format resource:action (lowercase letters, digits, underscore)")
+	}
+	if status, err := s.ensurePermissionNameAvailable(ctx, name, uuid.Nil); err != nil {
+		return nil, status, err
+	}
+
+	resource, action, _ := strings.Cut(name, ":")
+	permission := &models.Permission{ID: uuid.New(), Name: name, Resource: resource, Action: action, Description: strings.TrimSpace(req.Description)}
+	if err := s.permissionRepo.CreatePermission(ctx, permission); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return permission, http.StatusCreated, nil
+}
+
+func (s *roleService) UpdatePermission(ctx context.Context, permissionID uuid.UUID, req *models.PermissionRequest) (*models.Permission, int, error) {
+	permission, status, err := s.getPermission(ctx, permissionID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = permission.Name
+	}
+	if name != permission.Name {
+		// Nama permission dipakai langsung oleh RBACRequired di routes
+		if containsString(adminLockoutPermissions, permission.Name) {
+			return nil, http.StatusForbidden, fmt.Errorf("permission %s cannot be renamed", permission.Name)
+		}
+		if !permissionNamePattern.MatchString(name) {
+			return nil, http.StatusBadRequest, errors.New("permission name must use the format resource:action (lowercase letters, digits, underscore)")
+		}
+		if status, err := s.ensurePermissionNameAvailable(ctx, name, permission.ID); err != nil {
+			return nil, status, err
+		}
+	}
+
+	permission.Name = name
+	permission.Resource, permission.Action, _ = strings.Cut(name, ":")
+	permission.Description = strings.TrimSpace(req.Description)
+	if err := s.permissionRepo.UpdatePermission(ctx, permission); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return permission, http.StatusOK, nil
+}
+
+func (s *roleService) DeletePermission(ctx context.Context, permissionID uuid.UUID) (int, error) {
+	permission, status, err := s.getPermission(ctx, permissionID)
+	if err != nil {
+		return status, err
+	}
+	if containsString(adminLockoutPermissions, permission.Name) {
+		return http.StatusForbidden, fmt.Errorf("permission %s cannot be deleted", permission.Name)
+	}
+
+	if err := s.permissionRepo.DeletePermission(ctx, permissionID); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusOK, nil
+}
+
+func (s *roleService) ensurePermissionNameAvailable(ctx context.Context, name string, currentID uuid.UUID) (int, error) {
+	existing, err := s.permissionRepo.GetPermissionsByNames(ctx, []string{name})
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if len(existing) > 0 && existing[0].ID != currentID {
+		return http.StatusConflict, fmt.Errorf("permission %s already exists", name)
+	}
+	return http.StatusOK, nil
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v != "" && !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+func missingPermissionNames(names []string, found []models.Permission) []string {
+	foundNames := make(map[string]bool, len(found))
+	for _, p := range found {
+		foundNames[p.Name] = true
+	}
+	var missing []string
+	for _, name := range names {
+		if !foundNames[name] {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"sort"
+	"time"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/repositories"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+)
+
+// StatsMaintainer memperbarui agregat statistik setelah transisi workflow prestasi
+type StatsMaintainer interface {
+	StudentChanged(ctx context.Context, studentID uuid.UUID)
+}
+
+// StatsInvalidator membuang cache statistik ketika keanggotaan scope berubah (dosen wali, prodi, departemen)
+// tanpa ada prestasi yang berubah, sehingga agregat materialized tidak perlu dihitung ulang
+type StatsInvalidator interface {
+	InvalidateStats(ctx context.Context)
+}
+
+// StatsAggregator membaca statistik dashboard dari agregat materialized lewat cache
+type StatsAggregator interface {
+	StatsMaintainer
+	StatsInvalidator
+	DashboardStats(ctx context.Context, scope models.AchievementScope) (*models.DashboardStats, error)
+	// QueryStats menghitung langsung dengan filter (tanpa cache); rekapnya sama dengan DashboardStats
+	QueryStats(ctx context.Context, scope models.AchievementScope, filter models.StatsFilter) (*models.DashboardStats, error)
+	// Rebuild menghitung ulang seluruh agregat (startup & berkala) untuk membersihkan drift
+	Rebuild(ctx context.Context) error
+}
+
+type StatsConfig struct {
+	CacheTTL        time.Duration
+	RebuildInterval time.Duration
+}
+
+func StatsConfigFromEnv() StatsConfig {
+	return StatsConfig{
+		CacheTTL:        utils.GetEnvDuration("STATS_CACHE_TTL", 5*time.Minute),
+		RebuildInterval: utils.GetEnvPositiveDuration("STATS_REBUILD_INTERVAL", 6*time.Hour),
+	}
+}
+
+type statsAggregator struct {
+	repo   repositories.StatsRepository
+	cache  repositories.StatsCache
+	config StatsConfig
+}
+
+func NewStatsAggregator(repo repositories.StatsRepository, cache repositories.StatsCache, config StatsConfig) StatsAggregator {
+	return &statsAggregator{repo: repo, cache: cache, config: config}
+}
+
+// StudentChanged tidak menggagalkan transisi: agregat yang gagal diperbarui dibereskan oleh rebuild berikutnya
+func (a *statsAggregator) StudentChanged(ctx context.Context, studentID uuid.UUID) {
+	if err := a.repo.RefreshStudent(ctx, studentID); err != nil {
+		log.Printf("failed to refresh achievement stats for student %s: %v", studentID, err)
+	}
+	if err := a.cache.Invalidate(ctx); err != nil {
+		log.Printf("failed to invalidate stats cache: %v", err)
+	}
+}
+
+func (a *statsAggregator) InvalidateStats(ctx context.Context) {
+	if err := a.cache.Invalidate(ctx); err != nil {
+		log.Printf("failed to invalidate stats cache: %v", err)
+	}
+}
+
+// invalidateStats dipanggil service setelah penulisan yang mengubah scope; stats boleh nil
+func invalidateStats(ctx context.Context, stats StatsInvalidator) {
+	if stats != nil {
+		stats.InvalidateStats(ctx)
+	}
+}
+
+func (a *statsAggregator) Rebuild(ctx context.Context) error {
+	if err := a.repo.RebuildAll(ctx); err != nil {
+		return err
+	}
+	if err := a.cache.Invalidate(ctx); err != nil {
+		log.Printf("failed to invalidate stats cache: %v", err)
+	}
+	return nil
+}
+
+func (a *statsAggregator) DashboardStats(ctx context.Context, scope models.AchievementScope) (*models.DashboardStats, error) {
+	value, err := a.cache.GetOrCompute(ctx, dashboardCacheKey(scope), a.config.CacheTTL, func() ([]byte, error) {
+		rows, err := a.repo.ListAggregates(ctx, scope)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(rollUpDashboardStats(rows, time.Now()))
+	})
+	if value == nil {
+		return nil, err
+	}
+	if err != nil {
+		log.Printf("stats cache unavailable, serving uncached statistics: %v", err)
+	}
+
+	stats := &models.DashboardStats{}
+	if err := json.Unmarshal(value, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (a *statsAggregator) QueryStats(ctx context.Context, scope models.AchievementScope, filter models.StatsFilter) (*models.DashboardStats, error) {
+	rows, err := a.repo.QueryStats(ctx, scope, filter)
+	if err != nil {
+		return nil, err
+	}
+	return rollUpDashboardStats(rows, time.Now()), nil
+}
+
+// dashboardCacheKey: satu entri per scope baca; daftar mahasiswa di-hash agar key tetap pendek
+func dashboardCacheKey(scope models.AchievementScope) string {
+	if scope.All {
+		return "dashboard:all"
+	}
+	ids := make([]string, len(scope.StudentIDs))
+	for i, id := range scope.StudentIDs {
+		ids[i] = id.String()
+	}
+	sort.Strings(ids)
+	hash := sha256.New()
+	for _, id := range ids {
+		hash.Write([]byte(id))
+	}
+	return "dashboard:" + hex.EncodeToString(hash.Sum(nil))
+}
+
+func rollUpDashboardStats(rows []models.StatsAggregateRow, asOf time.Time) *models.DashboardStats {
+	stats := &models.DashboardStats{
+		ByStatus:       map[string]int{},
+		ByType:         map[string]int{},
+		ByProgramStudy: map[string]int{},
+		AsOf:           asOf,
+	}
+	for _, row := range rows {
+		stats.TotalAchievements += row.Total
+		stats.ByStatus[row.Status] += row.Total
+		// Referensi tanpa dokumen MongoDB tetap dihitung agar jumlah per jenis = total
+		achievementType := row.AchievementType
+		if achievementType == "" {
+			achievementType = models.StatsUnknownType
+		}
+		stats.ByType[achievementType] += row.Total
+		if row.ProgramStudy != "" {
+			stats.ByProgramStudy[row.ProgramStudy] += row.Total
+		}
+	}
+	return stats
+}
@@ -0,0 +1,17 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// StartStatsRebuildJob membangun ulang agregat statistik saat startup lalu secara berkala,
+// untuk membereskan drift dari refresh per mahasiswa yang gagal atau perubahan di luar aplikasi.
+func StartStatsRebuildJob(ctx context.Context, aggregator StatsAggregator, interval time.Duration) {
+	runPeriodic(ctx, "Stats rebuild", interval, func(ctx context.Context) {
+		if err := aggregator.Rebuild(ctx); err != nil {
+			log.Printf("Stats rebuild failed: %v", err)
+		}
+	})
+}
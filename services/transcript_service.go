@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/repositories"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+)
+
+// TranscriptConfig mengatur URL verifikasi yang dicetak sebagai QR code (kode dokumen ditambahkan di akhir)
+type TranscriptConfig struct {
+	VerifyURL string
+}
+
+func TranscriptConfigFromEnv() TranscriptConfig {
+	return TranscriptConfig{
+		VerifyURL: utils.GetEnv("TRANSCRIPT_VERIFY_URL", "http://localhost:3000/api/v1/public/transcripts"),
+	}
+}
+
+// TranscriptService menerbitkan SKPI (Surat Keterangan Pendamping Ijazah) dan memverifikasinya secara publik
+type TranscriptService interface {
+	GenerateTranscript(ctx context.Context, claims *utils.JWTCustomClaims, studentUserID uuid.UUID) (*ExportJob, int, error)
+	VerifyTranscript(ctx context.Context, code string) (*models.TranscriptVerification, int, error)
+}
+
+type transcriptService struct {
+	achieveRepo    repositories.AchievementRepository
+	profileRepo    repositories.ProfileRepository
+	transcriptRepo repositories.TranscriptRepository
+	policy         AccessPolicy
+	config         TranscriptConfig
+}
+
+func NewTranscriptService(achieveRepo repositories.AchievementRepository, profileRepo repositories.ProfileRepository, transcriptRepo repositories.TranscriptRepository, policy AccessPolicy, config TranscriptConfig) TranscriptService {
+	return &transcriptService{achieveRepo: achieveRepo, profileRepo: profileRepo, transcriptRepo: transcriptRepo, policy: policy, config: config}
+}
+
+// Label jenis prestasi pada SKPI; jenis lain dicetak apa adanya
+var achievementTypeLabels = map[string]string{
+	"academic":      "Akademik",
+	"competition":   "Kompetisi",
+	"organization":  "Organisasi",
+	"publication":   "Publikasi",
+	"certification": "Sertifikasi",
+	"other":         "Lainnya",
+}
+
+func achievementTypeLabel(achievementType string) string {
+	if label, ok := achievementTypeLabels[achievementType]; ok {
+		return label
+	}
+	return achievementType
+}
+
+// GenerateTranscript mencatat penerbitan SKPI lalu menyiapkan PDF berisi prestasi terverifikasi per jenis
+func (s *transcriptService) GenerateTranscript(ctx context.Context, claims *utils.JWTCustomClaims, studentUserID uuid.UUID) (*ExportJob, int, error) {
+	allowed, err := s.policy.CanReadStudent(ctx, claims, studentUserID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if !allowed {
+		return nil, http.StatusForbidden, errors.New("access denied: you cannot generate this student's transcript")
+	}
+
+	profile, err := s.profileRepo.GetStudentProfileByUserID(ctx, studentUserID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if profile == nil {
+		return nil, http.StatusNotFound, errors.New("student profile not found")
+	}
+
+	records, err := s.achieveRepo.ListAchievementRecords(ctx, models.AchievementScope{StudentIDs: []uuid.UUID{studentUserID}})
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	// Kelompokkan prestasi terverifikasi per jenis, urut tanggal verifikasi
+	byType := map[string][]models.AchievementRecord{}
+	verifiedCount, totalPoints := 0, 0
+	for _, rec := range records {
+		if rec.Status != "verified" {
+			continue
+		}
+		byType[rec.AchievementType] = append(byType[rec.AchievementType], rec)
+		verifiedCount++
+		totalPoints += rec.Points
+	}
+	groups := make([]utils.TranscriptGroup, 0, len(byType))
+	for achievementType, recs := range byType {
+		sort.SliceStable(recs, func(i, j int) bool {
+			a, b := recs[i].VerifiedAt, recs[j].VerifiedAt
+			if a == nil || b == nil {
+				return b == nil && a != nil
+			}
+			return a.Before(*b)
+		})
+		group := utils.TranscriptGroup{Label: achievementTypeLabel(achievementType)}
+		for _, rec := range recs {
+			group.Items = append(group.Items, utils.TranscriptItem{
+				Title: rec.Title, Points: rec.Points, VerifiedAt: rec.VerifiedAt, Verifier: rec.VerifierName,
+			})
+		}
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Label < groups[j].Label })
+
+	code, err := utils.GenerateRandomToken(18)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to generate verification code")
+	}
+	issuedBy := claims.UserID
+	transcript := &models.StudentTranscript{
+		Code: code, StudentUserID: studentUserID, IssuedBy: &issuedBy,
+		TotalAchievements: verifiedCount, TotalPoints: totalPoints,
+	}
+	if err := s.transcriptRepo.Create(ctx, transcript); err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to record transcript")
+	}
+
+	doc := utils.TranscriptDocument{
+		Code:          code,
+		VerifyURL:     strings.TrimRight(s.config.VerifyURL, "/") + "/" + code,
+		StudentName:   profile.FullName,
+		StudentNumber: profile.StudentID,
+		ProgramStudy:  profile.ProgramStudy,
+		AcademicYear:  profile.AcademicYear,
+		IssuedAt:      transcript.IssuedAt,
+		Groups:        groups,
+		TotalPoints:   totalPoints,
+	}
+	return &ExportJob{
+		FileName:    "skpi-" + profile.StudentID + ".pdf",
+		ContentType: utils.ExportContentType(utils.ExportPDF),
+		Write: func(ctx context.Context, w io.Writer) error {
+			return utils.WriteTranscriptPDF(w, doc)
+		},
+	}, http.StatusOK, nil
+}
+
+// VerifyTranscript dipakai tanpa login; kode yang tidak terdaftar menghasilkan valid=false
+func (s *transcriptService) VerifyTranscript(ctx context.Context, code string) (*models.TranscriptVerification, int, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, http.StatusBadRequest, errors.New("verification code is required")
+	}
+
+	verification, err := s.transcriptRepo.GetVerificationByCode(ctx, code)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to verify transcript")
+	}
+	if verification == nil {
+		return &models.TranscriptVerification{Valid: false, Code: code}, http.StatusOK, nil
+	}
+	return verification, http.StatusOK, nil
+}
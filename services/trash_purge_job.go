@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// StartTrashPurgeJob menjalankan pembersihan trash secara berkala di background.
+// Prestasi yang berada di trash lebih lama dari retention akan dihapus permanen.
+func StartTrashPurgeJob(ctx context.Context, service AchievementService, retention time.Duration, interval time.Duration) {
+	runPeriodic(ctx, "Trash purge", interval, func(ctx context.Context) {
+		purged, err := service.PurgeExpiredTrash(ctx, retention)
+		if err != nil {
+			log.Printf("Trash purge failed: %v", err)
+		} else if purged > 0 {
+			log.Printf("Trash purge: %d achievement(s) permanently deleted", purged)
+		}
+	})
+}
@@ -0,0 +1,481 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/repositories"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+)
+
+// invitePendingPasswordHash bukan hash bcrypt yang valid sehingga tidak pernah cocok dengan password apa pun;
+// user undangan harus menyetel password lewat link reset terlebih dahulu
+const invitePendingPasswordHash = "!invite-pending"
+
+// userImportColumns memetakan alias header (sudah dinormalisasi) ke nama kolom baku
+var userImportColumns = map[string]string{
+	"username": "username", "email": "email",
+	"full_name": "full_name", "fullname": "full_name", "nama": "full_name", "nama_lengkap": "full_name",
+	"role": "role", "role_name": "role",
+	"nim_nip": "nim_nip", "nim": "nim_nip", "nip": "nim_nip", "identity_number": "nim_nip",
+	"program_study": "program_study", "study_program": "program_study", "prodi": "program_study",
+	"academic_year": "academic_year", "angkatan": "academic_year",
+	"advisor_nip": "advisor_nip", "nip_dosen_wali": "advisor_nip",
+	"department": "department", "departemen": "department",
+}
+
+var userImportRequiredColumns = []string{"username", "email", "full_name", "role"}
+
+// UserImportConfig adalah konfigurasi bulk import user
+type UserImportConfig struct {
+	MaxRows   int
+	InviteURL string        // halaman frontend untuk menyetel password; token ditambahkan sebagai query ?token=
+	InviteTTL time.Duration // masa berlaku link undangan
+}
+
+func UserImportConfigFromEnv() UserImportConfig {
+	return UserImportConfig{
+		MaxRows:   utils.GetEnvInt("USER_IMPORT_MAX_ROWS", 1000),
+		InviteURL: utils.GetEnv("USER_INVITE_URL", "http://localhost:5173/reset-password"),
+		InviteTTL: utils.GetEnvDuration("USER_INVITE_TTL", 72*time.Hour),
+	}
+}
+
+type UserImportService interface {
+	ImportUsers(ctx context.Context, adminUserID uuid.UUID, filename string, file io.Reader, opts models.UserImportOptions) (*models.UserImportReport, int, error)
+}
+
+type userImportService struct {
+	importRepo repositories.UserImportRepository
+	roleRepo   repositories.RoleRepository
+	unitRepo   repositories.AcademicUnitRepository
+	resetRepo  repositories.PasswordResetRepository
+	cfg        UserImportConfig
+	stats      StatsInvalidator
+}
+
+func NewUserImportService(importRepo repositories.UserImportRepository, roleRepo repositories.RoleRepository, unitRepo repositories.AcademicUnitRepository, resetRepo repositories.PasswordResetRepository, cfg UserImportConfig, stats StatsInvalidator) UserImportService {
+	return &userImportService{importRepo: importRepo, roleRepo: roleRepo, unitRepo: unitRepo, resetRepo: resetRepo, cfg: cfg, stats: stats}
+}
+
+// ImportUsers memvalidasi seluruh file lalu (jika bukan dry-run) menyimpan user beserta profil mahasiswa/dosen
+func (s *userImportService) ImportUsers(ctx context.Context, adminUserID uuid.UUID, filename string, file io.Reader, opts models.UserImportOptions) (*models.UserImportReport, int, error) {
+	if opts.Mode == "" {
+		opts.Mode = models.UserImportModeTransactional
+	}
+	if opts.Credential == "" {
+		opts.Credential = models.UserImportCredentialInvite
+	}
+	if opts.Mode != models.UserImportModeTransactional && opts.Mode != models.UserImportModePartial {
+		return nil, http.StatusBadRequest, errors.New("mode must be 'transactional' or 'partial'")
+	}
+	if opts.Credential != models.UserImportCredentialPassword && opts.Credential != models.UserImportCredentialInvite {
+		return nil, http.StatusBadRequest, errors.New("credential must be 'password' or 'invite'")
+	}
+
+	table, err := utils.ReadSpreadsheet(filename, file)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	rows, err := parseUserImportRows(table)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if len(rows) == 0 {
+		return nil, http.StatusBadRequest, errors.New("file contains no data rows")
+	}
+	if len(rows) > s.cfg.MaxRows {
+		return nil, http.StatusBadRequest, fmt.Errorf("file contains %d rows, maximum is %d", len(rows), s.cfg.MaxRows)
+	}
+
+	report := &models.UserImportReport{DryRun: opts.DryRun, Mode: opts.Mode, Credential: opts.Credential, TotalRows: len(rows)}
+	results, records, err := s.validateRows(ctx, rows)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	report.Rows = results
+	report.ValidRows = len(records)
+
+	if opts.DryRun {
+		finalizeImportReport(report)
+		return report, http.StatusOK, nil
+	}
+	if opts.Mode == models.UserImportModeTransactional && len(records) != len(rows) {
+		markValidRows(report, models.UserImportStatusSkipped)
+		finalizeImportReport(report)
+		return report, http.StatusUnprocessableEntity, nil
+	}
+	if len(records) == 0 {
+		finalizeImportReport(report)
+		return report, http.StatusUnprocessableEntity, nil
+	}
+
+	resultByRow := map[int]*models.UserImportRowResult{}
+	for i := range report.Rows {
+		resultByRow[report.Rows[i].Row] = &report.Rows[i]
+	}
+
+	// Kredensial awal dibuat sebelum disimpan agar hash ikut masuk dalam transaksi yang sama
+	for _, record := range records {
+		if opts.Credential == models.UserImportCredentialInvite {
+			record.User.PasswordHash = invitePendingPasswordHash
+			continue
+		}
+		password, err := utils.GenerateInitialPassword()
+		if err != nil {
+			return nil, http.StatusInternalServerError, errors.New("failed to generate initial password")
+		}
+		hash, err := utils.HashPassword(password)
+		if err != nil {
+			return nil, http.StatusInternalServerError, errors.New("failed to hash initial password")
+		}
+		record.User.PasswordHash = hash
+		resultByRow[record.Row].InitialPassword = password
+	}
+
+	rowErrors, err := s.importRepo.ImportUsers(ctx, records, opts.Mode == models.UserImportModeTransactional)
+	if err != nil && !errors.Is(err, repositories.ErrImportRolledBack) {
+		return nil, http.StatusInternalServerError, err
+	}
+	rolledBack := errors.Is(err, repositories.ErrImportRolledBack)
+
+	for i, record := range records {
+		result := resultByRow[record.Row]
+		switch {
+		case rowErrors[i] != nil:
+			result.Status = models.UserImportStatusFailed
+			result.Errors = append(result.Errors, rowErrors[i].Error())
+			result.InitialPassword = ""
+		case rolledBack:
+			result.Status = models.UserImportStatusSkipped
+			result.InitialPassword = ""
+		default:
+			result.Status = models.UserImportStatusImported
+			userID := record.User.ID
+			result.UserID = &userID
+			if opts.Credential == models.UserImportCredentialInvite {
+				s.issueInvite(ctx, adminUserID, result)
+			}
+		}
+	}
+
+	finalizeImportReport(report)
+	if report.ImportedRows == 0 {
+		return report, http.StatusUnprocessableEntity, nil
+	}
+	// Mahasiswa baru menambah hitungan per prodi/departemen di dashboard
+	invalidateStats(ctx, s.stats)
+	return report, http.StatusCreated, nil
+}
+
+// issueInvite membuat token reset password sebagai link undangan. Kegagalan hanya dicatat di baris terkait;
+// user tetap tersimpan dan Admin bisa menerbitkan token ulang lewat /users/:id/password-reset.
+func (s *userImportService) issueInvite(ctx context.Context, adminUserID uuid.UUID, result *models.UserImportRowResult) {
+	plainToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		result.Errors = append(result.Errors, "failed to generate invite token")
+		return
+	}
+
+	expiresAt := time.Now().Add(s.cfg.InviteTTL)
+	token := &models.PasswordResetToken{
+		UserID:    *result.UserID,
+		TokenHash: utils.HashToken(plainToken),
+		ExpiresAt: expiresAt,
+		CreatedBy: &adminUserID,
+	}
+	if err := s.resetRepo.CreateToken(ctx, token); err != nil {
+		result.Errors = append(result.Errors, "failed to create invite token: "+err.Error())
+		return
+	}
+
+	result.InviteLink = buildInviteLink(s.cfg.InviteURL, plainToken)
+	result.InviteExpiresAt = &expiresAt
+}
+
+// validateRows mengecek setiap baris dan mengembalikan hasil per baris serta record siap simpan (dosen lebih dulu)
+func (s *userImportService) validateRows(ctx context.Context, rows []models.UserImportRow) ([]models.UserImportRowResult, []models.UserImportRecord, error) {
+	var usernames, emails, identities, advisorNIPs []string
+	for _, row := range rows {
+		usernames = append(usernames, row.Username)
+		emails = append(emails, strings.ToLower(strings.TrimSpace(row.Email)))
+		if row.IdentityNumber != "" {
+			identities = append(identities, row.IdentityNumber)
+		}
+		if row.AdvisorNIP != "" {
+			advisorNIPs = append(advisorNIPs, row.AdvisorNIP)
+		}
+	}
+
+	existing, err := s.importRepo.FindExisting(ctx, usernames, emails, identities, identities)
+	if err != nil {
+		return nil, nil, err
+	}
+	advisorIDs, err := s.importRepo.FindLecturerIDsByNIP(ctx, uniqueStrings(advisorNIPs))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	roles := map[string]*models.Role{}
+	programs := map[string]*models.StudyProgram{}
+	departments := map[string]*models.Department{}
+	seenUsernames, seenEmails, seenIdentities := map[string]int{}, map[string]int{}, map[string]int{}
+	fileLecturers := map[string]*models.Lecturer{} // NIP dosen di file yang sama -> profil yang akan dibuat
+
+	results := make([]models.UserImportRowResult, len(rows))
+	candidates := make([]*models.UserImportRecord, len(rows))
+
+	for i, row := range rows {
+		// Username & email disimpan lowercase karena login dan cek duplikat memakai nilai lowercase
+		username := strings.ToLower(row.Username)
+		email := strings.ToLower(strings.TrimSpace(row.Email))
+		result := models.UserImportRowResult{Row: row.Row, Username: username, Status: models.UserImportStatusValid}
+		fail := func(format string, args ...interface{}) {
+			result.Errors = append(result.Errors, fmt.Sprintf(format, args...))
+		}
+
+		if row.Username == "" {
+			fail("username is required")
+		} else if prev, dup := seenUsernames[username]; dup {
+			fail("username %s duplicates row %d", row.Username, prev)
+		} else if existing.Usernames[username] {
+			fail("username %s already exists", row.Username)
+		}
+		if email == "" {
+			fail("email is required")
+		} else if !isValidEmail(email) {
+			fail("email %s is not valid", row.Email)
+		} else if prev, dup := seenEmails[email]; dup {
+			fail("email %s duplicates row %d", row.Email, prev)
+		} else if existing.Emails[email] {
+			fail("email %s already exists", row.Email)
+		}
+		if row.FullName == "" {
+			fail("full_name is required")
+		}
+		if row.Username != "" {
+			seenUsernames[username] = row.Row
+		}
+		if email != "" {
+			seenEmails[email] = row.Row
+		}
+
+		var role *models.Role
+		if row.RoleName == "" {
+			fail("role is required")
+		} else {
+			if _, cached := roles[row.RoleName]; !cached {
+				found, err := s.roleRepo.GetRoleByName(ctx, row.RoleName)
+				if err != nil {
+					return nil, nil, err
+				}
+				roles[row.RoleName] = found
+			}
+			if role = roles[row.RoleName]; role == nil {
+				fail("role %s does not exist", row.RoleName)
+			}
+		}
+
+		if row.IdentityNumber != "" {
+			if prev, dup := seenIdentities[row.IdentityNumber]; dup {
+				fail("NIM/NIP %s duplicates row %d", row.IdentityNumber, prev)
+			} else if existing.NIMs[row.IdentityNumber] || existing.NIPs[row.IdentityNumber] {
+				fail("NIM/NIP %s already exists", row.IdentityNumber)
+			}
+			seenIdentities[row.IdentityNumber] = row.Row
+		}
+
+		user := &models.User{
+			ID: uuid.New(), Username: username, Email: email, FullName: row.FullName, IsActive: true,
+		}
+		record := &models.UserImportRecord{Row: row.Row, User: user}
+		if role != nil {
+			user.RoleID = role.ID
+			user.Role = role.Name
+		}
+
+		// Profil ditentukan dari permission role: role dengan scope achievement:read:own adalah mahasiswa
+		// (wajib NIM + prodi), role lain dengan NIP mendapat profil dosen
+		if role != nil && containsString(role.Permissions, models.PermissionAchievementReadOwn) {
+			if row.IdentityNumber == "" {
+				fail("nim_nip (NIM) is required for role %s", role.Name)
+			}
+			if row.AcademicYear == "" {
+				fail("academic_year is required for role %s", role.Name)
+			}
+			var program *models.StudyProgram
+			if row.ProgramStudy == "" {
+				fail("program_study is required for role %s", role.Name)
+			} else {
+				key := strings.ToLower(row.ProgramStudy)
+				if _, cached := programs[key]; !cached {
+					found, err := s.unitRepo.GetStudyProgramByName(ctx, row.ProgramStudy)
+					if err != nil {
+						return nil, nil, err
+					}
+					programs[key] = found
+				}
+				if program = programs[key]; program == nil {
+					fail("program_study %s does not exist", row.ProgramStudy)
+				}
+			}
+			if program != nil {
+				record.Student = &models.Student{
+					ID: uuid.New(), UserID: user.ID, StudentID: row.IdentityNumber,
+					ProgramStudy: program.Name, StudyProgramID: &program.ID, AcademicYear: row.AcademicYear,
+				}
+			}
+		} else if role != nil && row.IdentityNumber != "" {
+			lecturer := &models.Lecturer{ID: uuid.New(), UserID: user.ID, LecturerID: row.IdentityNumber}
+			if row.Department != "" {
+				key := strings.ToLower(row.Department)
+				if _, cached := departments[key]; !cached {
+					found, err := s.unitRepo.GetDepartmentByName(ctx, row.Department)
+					if err != nil {
+						return nil, nil, err
+					}
+					departments[key] = found
+				}
+				if department := departments[key]; department == nil {
+					fail("department %s does not exist", row.Department)
+				} else {
+					lecturer.Department = department.Name
+					lecturer.DepartmentID = &department.ID
+				}
+			}
+			record.Lecturer = lecturer
+		}
+
+		if len(result.Errors) > 0 {
+			result.Status = models.UserImportStatusFailed
+		} else if record.Lecturer != nil {
+			fileLecturers[record.Lecturer.LecturerID] = record.Lecturer
+		}
+		results[i] = result
+		candidates[i] = record
+	}
+
+	// Dosen wali boleh sudah terdaftar atau berupa baris dosen yang valid di file yang sama
+	for i, row := range rows {
+		record := candidates[i]
+		if row.AdvisorNIP == "" || record.Student == nil || results[i].Status == models.UserImportStatusFailed {
+			continue
+		}
+		if id, ok := advisorIDs[row.AdvisorNIP]; ok {
+			record.Student.AdvisorID = &id
+		} else if lecturer, ok := fileLecturers[row.AdvisorNIP]; ok {
+			record.Student.AdvisorID = &lecturer.ID
+		} else {
+			results[i].Errors = append(results[i].Errors, fmt.Sprintf("advisor with NIP %s not found", row.AdvisorNIP))
+			results[i].Status = models.UserImportStatusFailed
+		}
+	}
+
+	records := []models.UserImportRecord{}
+	for i, record := range candidates {
+		if results[i].Status != models.UserImportStatusFailed {
+			records = append(records, *record)
+		}
+	}
+	// Dosen disimpan lebih dulu agar advisor_id mahasiswa di file yang sama sudah ada
+	sort.SliceStable(records, func(a, b int) bool {
+		return records[a].Lecturer != nil && records[b].Lecturer == nil
+	})
+	return results, records, nil
+}
+
+// parseUserImportRows memetakan header (case-insensitive, alias didukung) lalu membaca baris data
+func parseUserImportRows(table [][]string) ([]models.UserImportRow, error) {
+	if len(table) == 0 {
+		return nil, errors.New("file is empty")
+	}
+
+	columns := map[string]int{}
+	for i, header := range table[0] {
+		key := strings.ToLower(strings.TrimSpace(header))
+		key = strings.NewReplacer(" ", "_", "-", "_").Replace(key)
+		if name, ok := userImportColumns[key]; ok {
+			if _, dup := columns[name]; !dup {
+				columns[name] = i
+			}
+		}
+	}
+	var missing []string
+	for _, name := range userImportRequiredColumns {
+		if _, ok := columns[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required column(s): %s", strings.Join(missing, ", "))
+	}
+
+	cell := func(record []string, name string) string {
+		idx, ok := columns[name]
+		if !ok || idx >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[idx])
+	}
+
+	var rows []models.UserImportRow
+	for i, record := range table[1:] {
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue // baris kosong dilewati
+		}
+		rows = append(rows, models.UserImportRow{
+			Row:            i + 2,
+			Username:       cell(record, "username"),
+			Email:          cell(record, "email"),
+			FullName:       cell(record, "full_name"),
+			RoleName:       cell(record, "role"),
+			IdentityNumber: cell(record, "nim_nip"),
+			ProgramStudy:   cell(record, "program_study"),
+			AcademicYear:   cell(record, "academic_year"),
+			AdvisorNIP:     cell(record, "advisor_nip"),
+			Department:     cell(record, "department"),
+		})
+	}
+	return rows, nil
+}
+
+func markValidRows(report *models.UserImportReport, status string) {
+	for i := range report.Rows {
+		if report.Rows[i].Status == models.UserImportStatusValid {
+			report.Rows[i].Status = status
+		}
+	}
+}
+
+func finalizeImportReport(report *models.UserImportReport) {
+	report.ImportedRows, report.FailedRows = 0, 0
+	for _, row := range report.Rows {
+		switch row.Status {
+		case models.UserImportStatusImported:
+			report.ImportedRows++
+		case models.UserImportStatusFailed:
+			report.FailedRows++
+		}
+	}
+}
+
+func buildInviteLink(base string, token string) string {
+	u, err := url.Parse(base)
+	if err != nil {
+		return base + "?token=" + url.QueryEscape(token)
+	}
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
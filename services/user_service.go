@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
+
 	"prestasi-mahasiswa-api/models"
 	"prestasi-mahasiswa-api/repositories"
 	"prestasi-mahasiswa-api/utils"
@@ -13,7 +16,7 @@ import (
 )
 
 type UserService interface {
-	ListAllUsers(ctx context.Context) ([]models.User, int, error)
+	ListUsers(ctx context.Context, query models.UserListQuery) (*models.UserListResult, int, error)
 	GetUserByID(ctx context.Context, userID uuid.UUID) (*models.User, int, error)
 	CreateUser(ctx context.Context, req *models.CreateUserRequest) (*models.User, int, error)
 	UpdateUser(ctx context.Context, userID uuid.UUID, req *models.UpdateUserRequest) (*models.User, int, error)
@@ -21,31 +24,61 @@ type UserService interface {
 
 	SetStudentProfile(ctx context.Context, userID uuid.UUID, req *models.StudentProfileRequest) (*models.Student, int, error)
 	SetLecturerProfile(ctx context.Context, userID uuid.UUID, req *models.LecturerProfileRequest) (*models.Lecturer, int, error)
-	AssignAdvisor(ctx context.Context, studentUserID uuid.UUID, advisorUserID uuid.UUID) (int, error)
+	SetFacultyStaffProfile(ctx context.Context, userID uuid.UUID, req *models.FacultyStaffProfileRequest) (*models.FacultyStaff, int, error)
 
+	IssuePasswordResetToken(ctx context.Context, adminUserID uuid.UUID, userID uuid.UUID) (*models.PasswordResetTokenResponse, int, error)
+	UnlockUser(ctx context.Context, userID uuid.UUID) (int, error)
 }
 
 type userService struct {
 	userRepo repositories.UserRepository
 	roleRepo repositories.RoleRepository
 	profileRepo repositories.ProfileRepository
-    // ... (opsional: student/lecturer repo jika logic set profile ada di sini)
+	resetRepo repositories.PasswordResetRepository
+	loginGuard *LoginGuard
+	unitRepo repositories.AcademicUnitRepository // Departemen & prodi untuk profil
+	stats StatsInvalidator // Profil menentukan prodi/departemen, yaitu scope statistik
 }
 
-func NewUserService(userRepo repositories.UserRepository, roleRepo repositories.RoleRepository, profileRepo repositories.ProfileRepository) UserService {
+func NewUserService(userRepo repositories.UserRepository, roleRepo repositories.RoleRepository, profileRepo repositories.ProfileRepository, resetRepo repositories.PasswordResetRepository, loginGuard *LoginGuard, unitRepo repositories.AcademicUnitRepository, stats StatsInvalidator) UserService {
 	return &userService{
 			userRepo: userRepo, 
 			roleRepo: roleRepo, 
-			profileRepo: profileRepo,}
+			profileRepo: profileRepo,
+			resetRepo: resetRepo,
+			loginGuard: loginGuard,
+			unitRepo: unitRepo,
+			stats: stats,}
 }
 
-// ListAllUsers
-func (s *userService) ListAllUsers(ctx context.Context) ([]models.User, int, error) {
-	users, err := s.userRepo.ListAllUsers(ctx)
+// ListUsers (pencarian, filter, sort, dan pagination untuk GET /users)
+func (s *userService) ListUsers(ctx context.Context, query models.UserListQuery) (*models.UserListResult, int, error) {
+	if query.SortBy == "" {
+		query.SortBy = models.UserSortUsername
+	}
+	if !isUserSortField(query.SortBy) {
+		return nil, http.StatusBadRequest, errors.New("invalid sort field: " + query.SortBy)
+	}
+	query.Page, query.Limit = models.NormalizePage(query.Page, query.Limit)
+	query.Search = strings.TrimSpace(query.Search)
+
+	users, total, err := s.userRepo.ListUsers(ctx, query)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
-	return users, http.StatusOK, nil
+	return &models.UserListResult{
+		Items:      users,
+		Pagination: models.NewPagination(query.Page, query.Limit, total),
+	}, http.StatusOK, nil
+}
+
+func isUserSortField(field string) bool {
+	switch field {
+	case models.UserSortUsername, models.UserSortName, models.UserSortEmail, models.UserSortRole,
+		models.UserSortCreatedAt, models.UserSortStudentID, models.UserSortLecturerID:
+		return true
+	}
+	return false
 }
 
 // GetUserByID
@@ -59,18 +92,31 @@ func (s *userService) GetUserByID(ctx context.Context, userID uuid.UUID) (*model
 
 // CreateUser
 func (s *userService) CreateUser(ctx context.Context, req *models.CreateUserRequest) (*models.User, int, error) {
-	// 1. Cek duplikasi username/email
-    // ... (logic untuk pengecekan duplikasi) ...
+	// Username selalu lowercase: login dan pengecekan duplikasi tidak membedakan huruf besar/kecil
+	req.Username = strings.ToLower(strings.TrimSpace(req.Username))
+	req.Email = strings.TrimSpace(req.Email)
+
+	// 1. Validasi input (422 dengan detail per field)
+	if fields := validateCreateUser(req); len(fields) > 0 {
+		return nil, http.StatusUnprocessableEntity, newValidationError(fields)
+	}
+
+	// 2. Cek duplikasi username/email (keduanya tidak membedakan huruf besar/kecil)
+	if status, err := checkDuplicates(ctx, s.userRepo, models.UserUniqueFields{Username: req.Username, Email: req.Email}, uuid.Nil); err != nil {
+		return nil, status, err
+	}
 
-	// 2. Dapatkan RoleID
+	// 3. Dapatkan RoleID
 	role, err := s.roleRepo.GetRoleByName(ctx, req.RoleName)
 	if err != nil || role == nil {
 		return nil, http.StatusBadRequest, errors.New("invalid role name specified")
 	}
 
-	// 3. Hash Password dan Buat User Object (menggunakan utils/password.go)
-    // ... (logic hashing password) ...
-    hashedPassword, _ := utils.HashPassword(req.Password)
+	// 4. Hash Password dan Buat User Object (menggunakan utils/password.go)
+	hashedPassword, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to hash password")
+	}
 	
 	newUser := &models.User{
 		ID: uuid.New(), Username: req.Username, Email: req.Email, 
@@ -78,10 +124,11 @@ func (s *userService) CreateUser(ctx context.Context, req *models.CreateUserRequ
         RoleID: role.ID, IsActive: true,
 	}
 
-	// 4. Simpan ke database
+	// 5. Simpan ke database
 	createdUser, err := s.userRepo.CreateUser(ctx, newUser)
 	if err != nil {
-		return nil, http.StatusInternalServerError, fmt.Errorf("failed to create user: %w", err)
+		status, err := repositoryWriteError(err)
+		return nil, status, err
 	}
 
 	return createdUser, http.StatusCreated, nil
@@ -94,7 +141,19 @@ func (s *userService) UpdateUser(ctx context.Context, userID uuid.UUID, req *mod
 		return nil, http.StatusNotFound, errors.New("user not found")
 	}
 
-	// 2. Dapatkan RoleID baru jika RoleName diubah
+	// 2. Validasi & cek duplikasi email baru
+	if req.Email != nil {
+		email := strings.TrimSpace(*req.Email)
+		if !isValidEmail(email) {
+			return nil, http.StatusUnprocessableEntity, newValidationError([]utils.FieldError{{Field: "email", Message: "email format is invalid"}})
+		}
+		if status, err := checkDuplicates(ctx, s.userRepo, models.UserUniqueFields{Email: email}, userID); err != nil {
+			return nil, status, err
+		}
+		req.Email = &email
+	}
+
+	// 3. Dapatkan RoleID baru jika RoleName diubah
 	var newRoleID *uuid.UUID
 	if req.RoleName != nil && *req.RoleName != "" {
 		role, err := s.roleRepo.GetRoleByName(ctx, *req.RoleName)
@@ -104,10 +163,11 @@ func (s *userService) UpdateUser(ctx context.Context, userID uuid.UUID, req *mod
 		newRoleID = &role.ID
 	}
 
-	// 3. Update User
+	// 4. Update User
 	updatedUser, err := s.userRepo.UpdateUser(ctx, userID, req, newRoleID)
 	if err != nil {
-		return nil, http.StatusInternalServerError, fmt.Errorf("failed to update user: %w", err)
+		status, err := repositoryWriteError(err)
+		return nil, status, err
 	}
 	return updatedUser, http.StatusOK, nil
 }
@@ -133,17 +193,30 @@ func (s *userService) SetStudentProfile(ctx context.Context, userID uuid.UUID, r
 		return nil, http.StatusNotFound, errors.New("user not found")
 	}
 
+	program, status, err := resolveStudyProgram(ctx, s.unitRepo, req.StudyProgramID, req.ProgramStudy)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if status, err := checkDuplicates(ctx, s.userRepo, models.UserUniqueFields{StudentID: req.StudentID}, userID); err != nil {
+		return nil, status, err
+	}
+
+	// Kolom teks program_study tetap diisi nama prodi agar data lama konsisten
 	student := &models.Student{
-		UserID:       userID,
-		StudentID:    req.StudentID,
-		ProgramStudy: req.ProgramStudy,
-		AcademicYear: req.AcademicYear,
+		UserID:         userID,
+		StudentID:      req.StudentID,
+		ProgramStudy:   program.Name,
+		StudyProgramID: &program.ID,
+		AcademicYear:   req.AcademicYear,
 	}
 
 	updated, err := s.profileRepo.UpsertStudent(ctx, student)
 	if err != nil {
-		return nil, http.StatusInternalServerError, err
+		status, err := repositoryWriteError(err)
+		return nil, status, err
 	}
+	invalidateStats(ctx, s.stats)
 	return updated, http.StatusOK, nil
 }
 
@@ -152,30 +225,101 @@ func (s *userService) SetLecturerProfile(ctx context.Context, userID uuid.UUID,
 		return nil, http.StatusNotFound, errors.New("user not found")
 	}
 
+	department, status, err := resolveDepartment(ctx, s.unitRepo, req.DepartmentID, req.Department)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if status, err := checkDuplicates(ctx, s.userRepo, models.UserUniqueFields{LecturerID: req.LecturerID}, userID); err != nil {
+		return nil, status, err
+	}
+
 	lecturer := &models.Lecturer{
-		UserID:     userID,
-		LecturerID: req.LecturerID,
-		Department: req.Department,
+		UserID:       userID,
+		LecturerID:   req.LecturerID,
+		Department:   department.Name,
+		DepartmentID: &department.ID,
 	}
 
 	updated, err := s.profileRepo.UpsertLecturer(ctx, lecturer)
+	if err != nil {
+		status, err := repositoryWriteError(err)
+		return nil, status, err
+	}
+	invalidateStats(ctx, s.stats)
+	return updated, http.StatusOK, nil
+}
+
+// SetFacultyStaffProfile mengikat staf fakultas ke satu departemen (dipakai scope achievement:read:department)
+func (s *userService) SetFacultyStaffProfile(ctx context.Context, userID uuid.UUID, req *models.FacultyStaffProfileRequest) (*models.FacultyStaff, int, error) {
+	if _, err := s.userRepo.GetUserByID(ctx, userID); err != nil {
+		return nil, http.StatusNotFound, errors.New("user not found")
+	}
+	if req.StaffID == "" {
+		return nil, http.StatusBadRequest, errors.New("staffId is required")
+	}
+
+	department, status, err := resolveDepartment(ctx, s.unitRepo, req.DepartmentID, "")
+	if err != nil {
+		return nil, status, err
+	}
+
+	staff := &models.FacultyStaff{
+		UserID:       userID,
+		StaffID:      req.StaffID,
+		DepartmentID: department.ID,
+	}
+
+	updated, err := s.profileRepo.UpsertFacultyStaff(ctx, staff)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
+	invalidateStats(ctx, s.stats)
 	return updated, http.StatusOK, nil
 }
 
-func (s *userService) AssignAdvisor(ctx context.Context, studentUserID uuid.UUID, advisorUserID uuid.UUID) (int, error) {
-	// 1. Cari Profil Dosen berdasarkan User ID Dosen
-	lecturer, err := s.profileRepo.GetLecturerByUserID(ctx, advisorUserID)
+// IssuePasswordResetToken (Admin membuat token reset sekali pakai untuk user)
+func (s *userService) IssuePasswordResetToken(ctx context.Context, adminUserID uuid.UUID, userID uuid.UUID) (*models.PasswordResetTokenResponse, int, error) {
+	if _, err := s.userRepo.GetUserByID(ctx, userID); err != nil {
+		return nil, http.StatusNotFound, errors.New("user not found")
+	}
+
+	plainToken, err := utils.GenerateRandomToken(32)
 	if err != nil {
-		return http.StatusNotFound, errors.New("advisor profile not found (user must have lecturer profile first)")
+		return nil, http.StatusInternalServerError, errors.New("failed to generate reset token")
+	}
+
+	// Hanya token terbaru yang berlaku
+	if err := s.resetRepo.InvalidateUserTokens(ctx, userID); err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to invalidate old tokens: %w", err)
+	}
+
+	token := &models.PasswordResetToken{
+		UserID:    userID,
+		TokenHash: utils.HashToken(plainToken),
+		ExpiresAt: time.Now().Add(utils.GetEnvDuration("PASSWORD_RESET_TOKEN_TTL", 24*time.Hour)),
+		CreatedBy: &adminUserID,
+	}
+	if err := s.resetRepo.CreateToken(ctx, token); err != nil {
+		return nil, http.StatusInternalServerError, err
 	}
 
-	// 2. Update Student Profile dengan ID Dosen (Lecturer ID)
-	err = s.profileRepo.AssignAdvisor(ctx, studentUserID, lecturer.ID)
+	return &models.PasswordResetTokenResponse{
+		UserID:    userID,
+		Token:     plainToken,
+		ExpiresAt: token.ExpiresAt,
+	}, http.StatusCreated, nil
+}
+
+// UnlockUser (Admin membuka lockout akun akibat login gagal berulang)
+func (s *userService) UnlockUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
 	if err != nil {
-		return http.StatusInternalServerError, err
+		return http.StatusNotFound, errors.New("user not found")
+	}
+
+	if err := s.loginGuard.Unlock(ctx, user.Username); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to unlock user: %w", err)
 	}
 	return http.StatusOK, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/mail"
+	"strings"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/repositories"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+)
+
+var duplicateFieldMessages = map[string]string{
+	"username":   "username already exists",
+	"email":      "email already exists",
+	"studentId":  "student ID (NIM) already registered",
+	"lecturerId": "lecturer ID (NIP) already registered",
+}
+
+// isValidEmail menerima alamat email polos saja (tanpa display name seperti "Nama <a@b.c>")
+func isValidEmail(email string) bool {
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Address == email
+}
+
+// validateCreateUser mengumpulkan semua kesalahan input sekaligus agar klien bisa menampilkan per field
+func validateCreateUser(req *models.CreateUserRequest) []utils.FieldError {
+	var fields []utils.FieldError
+	if req.Username == "" {
+		fields = append(fields, utils.FieldError{Field: "username", Message: "username is required"})
+	} else if strings.ContainsAny(req.Username, " \t\r\n") {
+		fields = append(fields, utils.FieldError{Field: "username", Message: "username must not contain whitespace"})
+	}
+	if req.Email == "" {
+		fields = append(fields, utils.FieldError{Field: "email", Message: "email is required"})
+	} else if !isValidEmail(req.Email) {
+		fields = append(fields, utils.FieldError{Field: "email", Message: "email format is invalid"})
+	}
+	if err := utils.ValidatePasswordPolicy(req.Password); err != nil {
+		fields = append(fields, utils.FieldError{Field: "password", Message: err.Error()})
+	}
+	if strings.TrimSpace(req.FullName) == "" {
+		fields = append(fields, utils.FieldError{Field: "fullName", Message: "fullName is required"})
+	}
+	if strings.TrimSpace(req.RoleName) == "" {
+		fields = append(fields, utils.FieldError{Field: "roleName", Message: "roleName is required"})
+	}
+	return fields
+}
+
+func newValidationError(fields []utils.FieldError) error {
+	return &utils.RequestError{Message: "validation failed", Fields: fields}
+}
+
+func newDuplicateError(fieldNames []string) error {
+	fields := make([]utils.FieldError, 0, len(fieldNames))
+	for _, name := range fieldNames {
+		message, ok := duplicateFieldMessages[name]
+		if !ok {
+			message = name + " already exists"
+		}
+		fields = append(fields, utils.FieldError{Field: name, Message: message})
+	}
+	return &utils.RequestError{Message: fields[0].Message, Fields: fields}
+}
+
+// checkDuplicates memastikan nilai unik belum dipakai user lain (409 beserta field yang bentrok)
+func checkDuplicates(ctx context.Context, userRepo repositories.UserRepository, fields models.UserUniqueFields, excludeUserID uuid.UUID) (int, error) {
+	duplicates, err := userRepo.FindDuplicateFields(ctx, fields, excludeUserID)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if len(duplicates) > 0 {
+		return http.StatusConflict, newDuplicateError(duplicates)
+	}
+	return http.StatusOK, nil
+}
+
+// repositoryWriteError memetakan pelanggaran unique constraint (mis. race antar request) ke 409
+func repositoryWriteError(err error) (int, error) {
+	var dupErr *repositories.DuplicateFieldError
+	if errors.As(err, &dupErr) && dupErr.Field != "" {
+		return http.StatusConflict, newDuplicateError([]string{dupErr.Field})
+	}
+	if errors.As(err, &dupErr) {
+		return http.StatusConflict, dupErr
+	}
+	return http.StatusInternalServerError, err
+}
@@ -0,0 +1,168 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAcademicUnitRepo
+type MockAcademicUnitRepo struct {
+	mock.Mock
+}
+
+func (m *MockAcademicUnitRepo) ListDepartments(ctx context.Context) ([]models.Department, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]models.Department), args.Error(1)
+}
+
+func (m *MockAcademicUnitRepo) GetDepartmentByID(ctx context.Context, id uuid.UUID) (*models.Department, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.Department), args.Error(1)
+}
+
+func (m *MockAcademicUnitRepo) GetDepartmentByName(ctx context.Context, name string) (*models.Department, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.Department), args.Error(1)
+}
+
+func (m *MockAcademicUnitRepo) CreateDepartment(ctx context.Context, d *models.Department) error {
+	return m.Called(ctx, d).Error(0)
+}
+
+func (m *MockAcademicUnitRepo) UpdateDepartment(ctx context.Context, d *models.Department) error {
+	return m.Called(ctx, d).Error(0)
+}
+
+func (m *MockAcademicUnitRepo) DeleteDepartment(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *MockAcademicUnitRepo) ListStudyPrograms(ctx context.Context, departmentID *uuid.UUID) ([]models.StudyProgram, error) {
+	args := m.Called(ctx, departmentID)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]models.StudyProgram), args.Error(1)
+}
+
+func (m *MockAcademicUnitRepo) GetStudyProgramByID(ctx context.Context, id uuid.UUID) (*models.StudyProgram, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.StudyProgram), args.Error(1)
+}
+
+func (m *MockAcademicUnitRepo) GetStudyProgramByName(ctx context.Context, name string) (*models.StudyProgram, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.StudyProgram), args.Error(1)
+}
+
+func (m *MockAcademicUnitRepo) CreateStudyProgram(ctx context.Context, p *models.StudyProgram) error {
+	return m.Called(ctx, p).Error(0)
+}
+
+func (m *MockAcademicUnitRepo) UpdateStudyProgram(ctx context.Context, p *models.StudyProgram) error {
+	return m.Called(ctx, p).Error(0)
+}
+
+func (m *MockAcademicUnitRepo) DeleteStudyProgram(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func TestCreateStudyProgram(t *testing.T) {
+	t.Run("Department Required", func(t *testing.T) {
+		mockUnit := new(MockAcademicUnitRepo)
+		service := services.NewAcademicUnitService(mockUnit, new(MockProfileRepo), nil)
+
+		mockUnit.On("GetStudyProgramByName", mock.Anything, "Informatika").Return(nil, nil)
+
+		_, status, err := service.CreateStudyProgram(context.Background(), &models.StudyProgramRequest{Name: "Informatika"})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, status)
+		mockUnit.AssertNotCalled(t, "CreateStudyProgram", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Head Must Be Lecturer", func(t *testing.T) {
+		mockUnit := new(MockAcademicUnitRepo)
+		mockProfile := new(MockProfileRepo)
+		service := services.NewAcademicUnitService(mockUnit, mockProfile, nil)
+		departmentID, headUserID := uuid.New(), uuid.New()
+
+		mockUnit.On("GetStudyProgramByName", mock.Anything, "Informatika").Return(nil, nil)
+		mockUnit.On("GetDepartmentByID", mock.Anything, departmentID).Return(&models.Department{ID: departmentID, Name: "Teknik"}, nil)
+		mockProfile.On("GetLecturerByUserID", mock.Anything, headUserID).Return(nil, errors.New("lecturer profile not found"))
+
+		_, status, err := service.CreateStudyProgram(context.Background(), &models.StudyProgramRequest{
+			Name: "Informatika", DepartmentID: departmentID.String(), HeadUserID: headUserID.String(),
+		})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("Success With Head", func(t *testing.T) {
+		mockUnit := new(MockAcademicUnitRepo)
+		mockProfile := new(MockProfileRepo)
+		service := services.NewAcademicUnitService(mockUnit, mockProfile, nil)
+		departmentID, headUserID, lecturerID := uuid.New(), uuid.New(), uuid.New()
+
+		mockUnit.On("GetStudyProgramByName", mock.Anything, "Informatika").Return(nil, nil)
+		mockUnit.On("GetDepartmentByID", mock.Anything, departmentID).Return(&models.Department{ID: departmentID, Name: "Teknik"}, nil)
+		mockProfile.On("GetLecturerByUserID", mock.Anything, headUserID).Return(&models.Lecturer{ID: lecturerID, UserID: headUserID}, nil)
+		mockUnit.On("CreateStudyProgram", mock.Anything, mock.AnythingOfType("*models.StudyProgram")).Return(nil)
+
+		program, status, err := service.CreateStudyProgram(context.Background(), &models.StudyProgramRequest{
+			Name: "Informatika", Code: "if", DepartmentID: departmentID.String(), HeadUserID: headUserID.String(),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, lecturerID, *program.HeadLecturerID)
+		assert.Equal(t, "IF", *program.Code)
+	})
+}
+
+func TestSetStudentProfileStudyProgram(t *testing.T) {
+	t.Run("Legacy Name Is Resolved To Entity", func(t *testing.T) {
+		mockUser := new(MockUserRepo)
+		mockProfile := new(MockProfileRepo)
+		mockUnit := new(MockAcademicUnitRepo)
+		service := services.NewUserService(mockUser, new(MockRoleRepo), mockProfile, new(MockResetRepo), newTestLoginGuard(), mockUnit, nil)
+		userID, programID := uuid.New(), uuid.New()
+
+		mockUser.On("GetUserByID", mock.Anything, userID).Return(&models.User{ID: userID}, nil)
+		mockUnit.On("GetStudyProgramByName", mock.Anything, "informatika").Return(&models.StudyProgram{ID: programID, Name: "Informatika"}, nil)
+		mockUser.On("FindDuplicateFields", mock.Anything, models.UserUniqueFields{StudentID: "2110511001"}, userID).Return([]string{}, nil)
+		mockProfile.On("UpsertStudent", mock.Anything, mock.MatchedBy(func(s *models.Student) bool {
+			return s.StudyProgramID != nil && *s.StudyProgramID == programID && s.ProgramStudy == "Informatika"
+		})).Return(&models.Student{UserID: userID, StudyProgramID: &programID}, nil)
+
+		_, status, err := service.SetStudentProfile(context.Background(), userID, &models.StudentProfileRequest{StudentID: "2110511001", ProgramStudy: "informatika"})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+	})
+
+	t.Run("Unknown Study Program Rejected", func(t *testing.T) {
+		mockUser := new(MockUserRepo)
+		mockProfile := new(MockProfileRepo)
+		mockUnit := new(MockAcademicUnitRepo)
+		service := services.NewUserService(mockUser, new(MockRoleRepo), mockProfile, new(MockResetRepo), newTestLoginGuard(), mockUnit, nil)
+		userID, programID := uuid.New(), uuid.New()
+
+		mockUser.On("GetUserByID", mock.Anything, userID).Return(&models.User{ID: userID}, nil)
+		mockUnit.On("GetStudyProgramByID", mock.Anything, programID).Return(nil, nil)
+
+		_, status, err := service.SetStudentProfile(context.Background(), userID, &models.StudentProfileRequest{StudentID: "2110511001", StudyProgramID: programID.String()})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, status)
+		mockProfile.AssertNotCalled(t, "UpsertStudent", mock.Anything, mock.Anything)
+	})
+}
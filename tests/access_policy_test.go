@@ -0,0 +1,172 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResolveAchievementScope(t *testing.T) {
+	t.Run("All Overrides Other Scopes", func(t *testing.T) {
+		mockUser := new(MockUserRepoForService)
+		policy := services.NewAccessPolicy(mockUser)
+		claims := &utils.JWTCustomClaims{UserID: uuid.New(), Permissions: []string{
+			models.PermissionAchievementReadAdvisees, models.PermissionAchievementReadAll,
+		}}
+
+		scope, err := policy.ResolveAchievementScope(context.Background(), claims)
+		assert.NoError(t, err)
+		assert.True(t, scope.All)
+		mockUser.AssertNotCalled(t, "GetAdviseeStudentUserIDsByAdvisorUserID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Renamed Role Keeps Advisee Scope", func(t *testing.T) {
+		mockUser := new(MockUserRepoForService)
+		policy := services.NewAccessPolicy(mockUser)
+		lecturerID, adviseeID := uuid.New(), uuid.New()
+		claims := &utils.JWTCustomClaims{UserID: lecturerID, Role: "Pembimbing Akademik", Permissions: []string{models.PermissionAchievementReadAdvisees}}
+
+		mockUser.On("GetAdviseeStudentUserIDsByAdvisorUserID", mock.Anything, lecturerID).Return([]uuid.UUID{adviseeID}, nil)
+
+		scope, err := policy.ResolveAchievementScope(context.Background(), claims)
+		assert.NoError(t, err)
+		assert.False(t, scope.All)
+		assert.Equal(t, []uuid.UUID{adviseeID}, scope.StudentIDs)
+
+		allowed, err := policy.CanReadStudent(context.Background(), claims, uuid.New())
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+	})
+
+	t.Run("Scopes Are Combined Without Duplicates", func(t *testing.T) {
+		mockUser := new(MockUserRepoForService)
+		policy := services.NewAccessPolicy(mockUser)
+		lecturerID, adviseeID, otherID := uuid.New(), uuid.New(), uuid.New()
+		claims := &utils.JWTCustomClaims{UserID: lecturerID, Permissions: []string{
+			models.PermissionAchievementReadAdvisees, models.PermissionAchievementReadDepartment,
+		}}
+
+		mockUser.On("GetDepartmentStudentUserIDsByUserID", mock.Anything, lecturerID).Return([]uuid.UUID{adviseeID, otherID}, nil)
+		mockUser.On("GetAdviseeStudentUserIDsByAdvisorUserID", mock.Anything, lecturerID).Return([]uuid.UUID{adviseeID}, nil)
+
+		scope, err := policy.ResolveAchievementScope(context.Background(), claims)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []uuid.UUID{adviseeID, otherID}, scope.StudentIDs)
+	})
+
+	t.Run("Program Scope For Head Of Study Program", func(t *testing.T) {
+		mockUser := new(MockUserRepoForService)
+		policy := services.NewAccessPolicy(mockUser)
+		kaprodiID, studentID := uuid.New(), uuid.New()
+		claims := &utils.JWTCustomClaims{UserID: kaprodiID, Role: "Kaprodi", Permissions: []string{models.PermissionAchievementReadProgram}}
+
+		mockUser.On("GetProgramStudentUserIDsByHeadUserID", mock.Anything, kaprodiID).Return([]uuid.UUID{studentID}, nil)
+
+		allowed, err := policy.CanReadStudent(context.Background(), claims, studentID)
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+		mockUser.AssertNotCalled(t, "GetAdviseeStudentUserIDsByAdvisorUserID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Own Scope Does Not Need Repository", func(t *testing.T) {
+		mockUser := new(MockUserRepoForService)
+		policy := services.NewAccessPolicy(mockUser)
+		studentID := uuid.New()
+		claims := &utils.JWTCustomClaims{UserID: studentID, Permissions: []string{models.PermissionAchievementReadOwn}}
+
+		allowed, err := policy.CanReadStudent(context.Background(), claims, studentID)
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+	})
+}
+
+func TestDashboardStatsScope(t *testing.T) {
+	t.Run("Advisee Scope Filters Statistics", func(t *testing.T) {
+		mockStats := new(MockStatsRepo)
+		mockUser := new(MockUserRepoForService)
+		service := services.NewReportService(new(MockAchieveRepo), new(MockProfileRepo), services.NewAccessPolicy(mockUser), newTestStatsAggregator(mockStats))
+		lecturerID, adviseeID := uuid.New(), uuid.New()
+		claims := &utils.JWTCustomClaims{UserID: lecturerID, Role: "Dosen Wali", Permissions: []string{models.PermissionAchievementReadAdvisees}}
+		expectedScope := models.AchievementScope{StudentIDs: []uuid.UUID{adviseeID}}
+
+		mockUser.On("GetAdviseeStudentUserIDsByAdvisorUserID", mock.Anything, lecturerID).Return([]uuid.UUID{adviseeID}, nil)
+		mockStats.On("ListAggregates", mock.Anything, expectedScope).Return([]models.StatsAggregateRow{
+			{StudentID: adviseeID, Status: "verified", AchievementType: "competition", Total: 2},
+			{StudentID: adviseeID, Status: "submitted", AchievementType: "competition", Total: 1},
+		}, nil)
+
+		stats, status, err := service.GetDashboardStats(context.Background(), claims, models.StatsFilter{})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, 3, stats.TotalAchievements)
+	})
+
+	t.Run("No Scope Permission Forbidden", func(t *testing.T) {
+		mockStats := new(MockStatsRepo)
+		service := services.NewReportService(new(MockAchieveRepo), new(MockProfileRepo), services.NewAccessPolicy(new(MockUserRepoForService)), newTestStatsAggregator(mockStats))
+		claims := &utils.JWTCustomClaims{UserID: uuid.New(), Role: "Admin"}
+
+		_, status, err := service.GetDashboardStats(context.Background(), claims, models.StatsFilter{})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, status)
+		mockStats.AssertNotCalled(t, "ListAggregates", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAdviseeStats(t *testing.T) {
+	lecturerID := uuid.New()
+	budi, sari := uuid.New(), uuid.New()
+
+	t.Run("Points Per Student And Pending Queue", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		mockProfile := new(MockProfileRepo)
+		mockStats := new(MockStatsRepo)
+		service := services.NewReportService(mockRepo, mockProfile, services.NewAccessPolicy(new(MockUserRepoForService)), newTestStatsAggregator(mockStats))
+		claims := &utils.JWTCustomClaims{UserID: lecturerID, Role: "Dosen Wali"}
+		scope := models.AchievementScope{StudentIDs: []uuid.UUID{budi, sari}}
+
+		mockProfile.On("GetLecturerProfileByUserID", mock.Anything, lecturerID).Return(&models.LecturerProfileDetail{AdviseeCount: 2}, nil)
+		mockProfile.On("ListAdviseesByLecturerUserID", mock.Anything, lecturerID).Return([]models.AdviseeSummary{
+			{UserID: budi, StudentID: "2110511001", FullName: "Budi"},
+			{UserID: sari, StudentID: "2110511002", FullName: "Sari"},
+		}, nil)
+		mockStats.On("ListAggregates", mock.Anything, scope).Return([]models.StatsAggregateRow{
+			{StudentID: sari, Status: "verified", AchievementType: "competition", Total: 3, Points: 120},
+			{StudentID: budi, Status: "submitted", AchievementType: "competition", Total: 2},
+		}, nil)
+		mockRepo.On("GetVerifiedPointsByStudent", mock.Anything, scope.StudentIDs).Return(map[uuid.UUID]models.StudentPointTotal{
+			sari: {Points: 120, Verified: 3},
+		}, nil)
+		mockRepo.On("CountPendingVerification", mock.Anything, lecturerID).Return(2, nil)
+
+		stats, status, err := service.GetAdviseeStats(context.Background(), claims, lecturerID)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, 5, stats.TotalAchievements)
+		assert.Equal(t, 2, stats.PendingVerification)
+		assert.Equal(t, []models.AdviseePoints{
+			{UserID: sari, StudentID: "2110511002", FullName: "Sari", TotalPoints: 120, VerifiedAchievements: 3},
+			{UserID: budi, StudentID: "2110511001", FullName: "Budi"},
+		}, stats.Students)
+	})
+
+	t.Run("Other Lecturer Forbidden", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		mockProfile := new(MockProfileRepo)
+		service := services.NewReportService(mockRepo, mockProfile, services.NewAccessPolicy(new(MockUserRepoForService)), nil)
+		claims := &utils.JWTCustomClaims{UserID: uuid.New(), Role: "Dosen Wali"}
+
+		_, status, err := service.GetAdviseeStats(context.Background(), claims, lecturerID)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, status)
+		mockProfile.AssertNotCalled(t, "ListAdviseesByLecturerUserID", mock.Anything, mock.Anything)
+	})
+}
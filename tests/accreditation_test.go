@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestAccreditationReport(t *testing.T) {
+	programID := uuid.New()
+	otherProgramID := uuid.New()
+	adminClaims := &utils.JWTCustomClaims{UserID: uuid.New(), Role: "Admin", Permissions: []string{models.PermissionAchievementReadAll}}
+	date := func(y int) *time.Time { d := time.Date(y, time.May, 1, 0, 0, 0, 0, time.UTC); return &d }
+	mapping := services.AccreditationMapping{
+		AcademicTypes: []string{"competition", "publication"},
+		LevelAliases: map[string]string{
+			"lokal": models.AccreditationLevelRegional, "nasional": models.AccreditationLevelNational,
+			"international": models.AccreditationLevelInternational,
+		},
+	}
+	records := []models.AccreditationRecord{
+		{StudentNumber: "001", StudyProgramID: &programID, ProgramStudy: "Informatika", AchievementType: "competition", Title: "Gemastik", Level: "Nasional", Rank: "Juara 1", EventDate: date(2025)},
+		{StudentNumber: "002", StudyProgramID: &programID, ProgramStudy: "Informatika", AchievementType: "publication", Title: "IEEE Conf", Level: "international", VerifiedAt: date(2024)},
+		{StudentNumber: "003", StudyProgramID: &programID, ProgramStudy: "Informatika", AchievementType: "organization", Title: "Ketua BEM", Level: "lokal", EventDate: date(2023)},
+		// details.category menimpa jenis: lomba seni dihitung non-akademik
+		{StudentNumber: "004", StudyProgramID: &programID, ProgramStudy: "Informatika", AchievementType: "competition", Category: "non-akademik", Title: "Lomba Tari", Level: "nasional", EventDate: date(2025)},
+		{StudentNumber: "005", StudyProgramID: &programID, ProgramStudy: "Informatika", AchievementType: "competition", Title: "Lomba Kampus", Level: "fakultas", EventDate: date(2025)},
+		{StudentNumber: "006", StudyProgramID: &programID, ProgramStudy: "Informatika", AchievementType: "competition", Title: "Lama", Level: "nasional", EventDate: date(2021)},
+		{StudentNumber: "101", StudyProgramID: &otherProgramID, ProgramStudy: "Sistem Informasi", AchievementType: "competition", Title: "Hackathon", Level: "nasional", EventDate: date(2025)},
+	}
+
+	t.Run("Maps Types And Levels Per Program", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		service := services.NewAccreditationService(mockRepo, new(MockAcademicUnitRepo), services.NewAccessPolicy(new(MockUserRepoForService)), mapping)
+		mockRepo.On("ListAccreditationRecords", mock.Anything, models.AchievementScope{All: true}, (*uuid.UUID)(nil)).Return(records, nil)
+
+		report, status, err := service.GetReport(context.Background(), adminClaims, models.AccreditationQuery{ReferenceYear: 2025})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, []int{2023, 2024, 2025}, report.Years)
+		assert.Len(t, report.Programs, 2)
+
+		inf := report.Programs[0]
+		assert.Equal(t, "Informatika", inf.ProgramStudy)
+		assert.Len(t, inf.Academic.Entries, 2)
+		assert.Equal(t, []models.AccreditationYearCount{
+			{Year: 2023, Label: "TS-2"},
+			{Year: 2024, Label: "TS-1", International: 1, Total: 1},
+			{Year: 2025, Label: "TS", National: 1, Total: 1},
+		}, inf.Academic.Summary)
+		assert.Equal(t, []models.AccreditationYearCount{
+			{Year: 2023, Label: "TS-2", Regional: 1, Total: 1},
+			{Year: 2024, Label: "TS-1"},
+			{Year: 2025, Label: "TS", National: 1, Total: 1},
+		}, inf.NonAcademic.Summary)
+		assert.Len(t, inf.Unmapped, 1)
+		assert.Equal(t, "Lomba Kampus", inf.Unmapped[0].Title)
+		assert.Equal(t, "Sistem Informasi", report.Programs[1].ProgramStudy)
+	})
+
+	t.Run("Unknown Study Program", func(t *testing.T) {
+		mockUnit := new(MockAcademicUnitRepo)
+		service := services.NewAccreditationService(new(MockAchieveRepo), mockUnit, services.NewAccessPolicy(new(MockUserRepoForService)), mapping)
+		mockUnit.On("GetStudyProgramByID", mock.Anything, programID).Return(nil, nil)
+
+		_, status, err := service.GetReport(context.Background(), adminClaims, models.AccreditationQuery{StudyProgramID: &programID})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+
+	t.Run("XLSX Has Sheet Per Program", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		service := services.NewAccreditationService(mockRepo, new(MockAcademicUnitRepo), services.NewAccessPolicy(new(MockUserRepoForService)), mapping)
+		mockRepo.On("ListAccreditationRecords", mock.Anything, models.AchievementScope{All: true}, (*uuid.UUID)(nil)).Return(records, nil)
+
+		job, status, err := service.ExportReport(context.Background(), adminClaims, models.AccreditationQuery{ReferenceYear: 2025}, "")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "laporan-akreditasi-ts2025-"+time.Now().Format("20060102")+".xlsx", job.FileName)
+
+		var buf bytes.Buffer
+		assert.NoError(t, job.Write(context.Background(), &buf))
+		f, err := excelize.OpenReader(&buf)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Informatika", "Sistem Informasi"}, f.GetSheetList())
+
+		rows, err := f.GetRows("Informatika")
+		assert.NoError(t, err)
+		found := false
+		for _, row := range rows {
+			if len(row) > 6 && row[1] == "Gemastik" {
+				found = true
+				assert.Equal(t, []string{"", "V", ""}, row[3:6])
+				assert.Equal(t, "Juara 1", row[6])
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("No Read Scope Forbidden", func(t *testing.T) {
+		service := services.NewAccreditationService(new(MockAchieveRepo), new(MockAcademicUnitRepo), services.NewAccessPolicy(new(MockUserRepoForService)), mapping)
+
+		_, status, err := service.GetReport(context.Background(), &utils.JWTCustomClaims{UserID: uuid.New()}, models.AccreditationQuery{})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"prestasi-mahasiswa-api/models"
 	"prestasi-mahasiswa-api/services"
@@ -31,6 +32,12 @@ func (m *MockAchieveRepo) GetReferenceByID(ctx context.Context, refID uuid.UUID)
 	return args.Get(0).(*models.AchievementReference), args.Error(1)
 }
 
+func (m *MockAchieveRepo) GetAssignedAdvisorUserID(ctx context.Context, refID uuid.UUID) (*uuid.UUID, error) {
+	args := m.Called(ctx, refID)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*uuid.UUID), args.Error(1)
+}
+
 func (m *MockAchieveRepo) UpdateAchievement(ctx context.Context, mid string, u interface{}) error {
 	args := m.Called(ctx, mid, u)
 	return args.Error(0)
@@ -42,14 +49,118 @@ func (m *MockAchieveRepo) UpdateReferenceUpdatedAt(ctx context.Context, rid uuid
 	return args.Get(0).(*models.AchievementReference), args.Error(1)
 }
 
+func (m *MockAchieveRepo) UpdateReferenceStatus(ctx context.Context, rid uuid.UUID, cs string, ns string, rn string, vb uuid.UUID) (*models.AchievementReference, error) {
+	args := m.Called(ctx, rid, cs, ns, rn, vb)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.AchievementReference), args.Error(1)
+}
+
 // Placeholder untuk method lain agar memenuhi interface AchievementRepository
 func (m *MockAchieveRepo) SoftDeleteAchievementAndReference(ctx context.Context, aid uuid.UUID, sid uuid.UUID) error { return nil }
-func (m *MockAchieveRepo) UpdateReferenceStatus(ctx context.Context, rid uuid.UUID, cs string, ns string, rn string, vb uuid.UUID) (*models.AchievementReference, error) { return nil, nil }
 func (m *MockAchieveRepo) GetAchievementDetail(ctx context.Context, mid string) (*models.Achievement, error) { return nil, nil }
-func (m *MockAchieveRepo) ListAchievementReferences(ctx context.Context, sids []uuid.UUID) ([]models.AchievementReference, error) { return nil, nil }
-func (m *MockAchieveRepo) GetStatsByStatus(ctx context.Context, sid *uuid.UUID) (map[string]int, error) { return nil, nil }
-func (m *MockAchieveRepo) GetStatsByType(ctx context.Context, sid *uuid.UUID) (map[string]int, error) { return nil, nil }
-func (m *MockAchieveRepo) HardDeleteAchievement(ctx context.Context, rid uuid.UUID) error { return nil }
+func (m *MockAchieveRepo) ListAchievementReferences(ctx context.Context, scope models.AchievementScope) ([]models.AchievementReference, error) {
+	args := m.Called(ctx, scope)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]models.AchievementReference), args.Error(1)
+}
+
+func (m *MockAchieveRepo) GetVerifiedPointsByStudent(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]models.StudentPointTotal, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(map[uuid.UUID]models.StudentPointTotal), args.Error(1)
+}
+
+func (m *MockAchieveRepo) ListAchievementRecords(ctx context.Context, scope models.AchievementScope) ([]models.AchievementRecord, error) {
+	args := m.Called(ctx, scope)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]models.AchievementRecord), args.Error(1)
+}
+
+func (m *MockAchieveRepo) ListTrendRows(ctx context.Context, scope models.AchievementScope, q models.TrendQuery) ([]models.TrendRow, error) {
+	args := m.Called(ctx, scope, q)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]models.TrendRow), args.Error(1)
+}
+
+func (m *MockAchieveRepo) ListLeaderboardEntries(ctx context.Context, q models.LeaderboardQuery) ([]models.LeaderboardEntry, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]models.LeaderboardEntry), args.Error(1)
+}
+
+func (m *MockAchieveRepo) ListAccreditationRecords(ctx context.Context, scope models.AchievementScope, programID *uuid.UUID) ([]models.AccreditationRecord, error) {
+	args := m.Called(ctx, scope, programID)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]models.AccreditationRecord), args.Error(1)
+}
+
+func (m *MockAchieveRepo) EachAchievementRecord(ctx context.Context, scope models.AchievementScope, fn func(models.AchievementRecord) error) error {
+	args := m.Called(ctx, scope)
+	if records, ok := args.Get(0).([]models.AchievementRecord); ok {
+		for _, rec := range records {
+			if err := fn(rec); err != nil { return err }
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockAchieveRepo) CountPendingVerification(ctx context.Context, advisorUserID uuid.UUID) (int, error) {
+	args := m.Called(ctx, advisorUserID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockAchieveRepo) RevokeVerification(ctx context.Context, rid uuid.UUID, by uuid.UUID, reason string) (*models.AchievementReference, error) {
+	args := m.Called(ctx, rid, by, reason)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.AchievementReference), args.Error(1)
+}
+
+func (m *MockAchieveRepo) GetActiveVerificationCode(ctx context.Context, rid uuid.UUID) (string, error) {
+	args := m.Called(ctx, rid)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockAchieveRepo) GetPublicVerification(ctx context.Context, code string) (*models.AchievementVerification, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.AchievementVerification), args.Error(1)
+}
+
+func (m *MockAchieveRepo) HardDeleteAchievement(ctx context.Context, rid uuid.UUID) ([]models.AttachmentFile, error) {
+	args := m.Called(ctx, rid)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]models.AttachmentFile), args.Error(1)
+}
+
+func (m *MockAchieveRepo) ListTrashedReferences(ctx context.Context, scope models.AchievementScope) ([]models.AchievementReference, error) {
+	args := m.Called(ctx, scope)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]models.AchievementReference), args.Error(1)
+}
+
+func (m *MockAchieveRepo) GetTrashedReferenceByID(ctx context.Context, rid uuid.UUID) (*models.AchievementReference, error) {
+	args := m.Called(ctx, rid)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.AchievementReference), args.Error(1)
+}
+
+func (m *MockAchieveRepo) GetAchievementDetailWithDeleted(ctx context.Context, mid string) (*models.Achievement, error) {
+	args := m.Called(ctx, mid)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.Achievement), args.Error(1)
+}
+
+func (m *MockAchieveRepo) RestoreAchievementAndReference(ctx context.Context, rid uuid.UUID) (*models.AchievementReference, error) {
+	args := m.Called(ctx, rid)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.AchievementReference), args.Error(1)
+}
+
+func (m *MockAchieveRepo) ListExpiredTrashReferenceIDs(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
 
 // --- MOCK REPOSITORY USER ---
 type MockUserRepoForService struct {
@@ -61,20 +172,35 @@ func (m *MockUserRepoForService) GetAdviseeStudentUserIDsByAdvisorUserID(ctx con
 	return args.Get(0).([]uuid.UUID), args.Error(1)
 }
 
+func (m *MockUserRepoForService) GetDepartmentStudentUserIDsByUserID(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockUserRepoForService) GetProgramStudentUserIDsByHeadUserID(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
 // Placeholder UserRepo
 func (m *MockUserRepoForService) FindUserByUsernameOrEmail(ctx context.Context, ident string) (*models.User, error) { return nil, nil }
 func (m *MockUserRepoForService) GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error) { return nil, nil }
 func (m *MockUserRepoForService) CreateUser(ctx context.Context, u *models.User) (*models.User, error) { return nil, nil }
 func (m *MockUserRepoForService) UpdateUser(ctx context.Context, id uuid.UUID, req *models.UpdateUserRequest, rid *uuid.UUID) (*models.User, error) { return nil, nil }
 func (m *MockUserRepoForService) DeleteUser(ctx context.Context, id uuid.UUID) error { return nil }
-func (m *MockUserRepoForService) ListAllUsers(ctx context.Context) ([]models.User, error) { return nil, nil }
+func (m *MockUserRepoForService) ListUsers(ctx context.Context, q models.UserListQuery) ([]models.UserListItem, int, error) { return nil, 0, nil }
+func (m *MockUserRepoForService) FindDuplicateFields(ctx context.Context, f models.UserUniqueFields, exclude uuid.UUID) ([]string, error) { return nil, nil }
+func (m *MockUserRepoForService) UpdatePassword(ctx context.Context, id uuid.UUID, hash string) error { return nil }
+func (m *MockUserRepoForService) FindUserByStudentNumber(ctx context.Context, nim string) (*models.User, error) { return nil, nil }
+func (m *MockUserRepoForService) FindUserByLecturerNumber(ctx context.Context, nip string) (*models.User, error) { return nil, nil }
+func (m *MockUserRepoForService) FindUserByEmail(ctx context.Context, email string) (*models.User, error) { return nil, nil }
 
 // --- TEST CASES ---
 
 func TestCreateAchievement(t *testing.T) {
 	mockRepo := new(MockAchieveRepo)
 	mockUser := new(MockUserRepoForService)
-	service := services.NewAchievementService(mockRepo, mockUser)
+	service := services.NewAchievementService(mockRepo, mockUser, services.NewAccessPolicy(mockUser), nil)
 
 	t.Run("Create Draft Success", func(t *testing.T) {
 		studentID := uuid.New()
@@ -93,7 +219,7 @@ func TestCreateAchievement(t *testing.T) {
 func TestAddAttachment(t *testing.T) {
 	mockRepo := new(MockAchieveRepo)
 	mockUser := new(MockUserRepoForService)
-	service := services.NewAchievementService(mockRepo, mockUser)
+	service := services.NewAchievementService(mockRepo, mockUser, services.NewAccessPolicy(mockUser), nil)
 
 	t.Run("Add Attachment Success", func(t *testing.T) {
 		studentID := uuid.New()
@@ -0,0 +1,158 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRestoreFromTrash(t *testing.T) {
+	mockRepo := new(MockAchieveRepo)
+	mockUser := new(MockUserRepoForService)
+	service := services.NewAchievementService(mockRepo, mockUser, services.NewAccessPolicy(mockUser), nil)
+
+	t.Run("Owner Can Restore", func(t *testing.T) {
+		studentID := uuid.New()
+		refID := uuid.New()
+		claims := &utils.JWTCustomClaims{UserID: studentID, Role: "Mahasiswa", Permissions: []string{models.PermissionAchievementReadOwn}}
+
+		mockRepo.On("GetTrashedReferenceByID", mock.Anything, refID).Return(&models.AchievementReference{ID: refID, StudentID: studentID, IsDeleted: true}, nil)
+		mockRepo.On("RestoreAchievementAndReference", mock.Anything, refID).Return(&models.AchievementReference{ID: refID, StudentID: studentID}, nil)
+
+		ref, status, err := service.RestoreFromTrash(context.Background(), claims, refID)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.False(t, ref.IsDeleted)
+	})
+
+	t.Run("Other Student Forbidden", func(t *testing.T) {
+		refID := uuid.New()
+		claims := &utils.JWTCustomClaims{UserID: uuid.New(), Role: "Mahasiswa", Permissions: []string{models.PermissionAchievementReadOwn}}
+
+		mockRepo.On("GetTrashedReferenceByID", mock.Anything, refID).Return(&models.AchievementReference{ID: refID, StudentID: uuid.New(), IsDeleted: true}, nil)
+
+		_, status, err := service.RestoreFromTrash(context.Background(), claims, refID)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, status)
+		mockRepo.AssertNotCalled(t, "RestoreAchievementAndReference", mock.Anything, refID)
+	})
+
+	t.Run("Read Scope Alone Cannot Restore", func(t *testing.T) {
+		refID := uuid.New()
+		advisee := uuid.New()
+		claims := &utils.JWTCustomClaims{UserID: uuid.New(), Role: "Dosen Wali", Permissions: []string{"achievement:delete", models.PermissionAchievementReadAll}}
+
+		mockRepo.On("GetTrashedReferenceByID", mock.Anything, refID).Return(&models.AchievementReference{ID: refID, StudentID: advisee, IsDeleted: true}, nil)
+
+		_, status, err := service.RestoreFromTrash(context.Background(), claims, refID)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, status)
+		mockRepo.AssertNotCalled(t, "RestoreAchievementAndReference", mock.Anything, refID)
+	})
+
+	t.Run("Delete All Can Restore Others", func(t *testing.T) {
+		refID := uuid.New()
+		studentID := uuid.New()
+		claims := &utils.JWTCustomClaims{UserID: uuid.New(), Role: "Admin", Permissions: []string{models.PermissionAchievementDeleteAll}}
+
+		mockRepo.On("GetTrashedReferenceByID", mock.Anything, refID).Return(&models.AchievementReference{ID: refID, StudentID: studentID, IsDeleted: true}, nil)
+		mockRepo.On("RestoreAchievementAndReference", mock.Anything, refID).Return(&models.AchievementReference{ID: refID, StudentID: studentID}, nil)
+
+		_, status, err := service.RestoreFromTrash(context.Background(), claims, refID)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+	})
+
+	t.Run("Not In Trash", func(t *testing.T) {
+		refID := uuid.New()
+		claims := &utils.JWTCustomClaims{UserID: uuid.New(), Role: "Admin", Permissions: []string{models.PermissionAchievementReadAll}}
+
+		mockRepo.On("GetTrashedReferenceByID", mock.Anything, refID).Return(nil, errors.New("achievement not found in trash"))
+
+		_, status, err := service.RestoreFromTrash(context.Background(), claims, refID)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+}
+
+func TestListTrashScope(t *testing.T) {
+	t.Run("Own Trash Only Without Delete All", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		mockUser := new(MockUserRepoForService)
+		service := services.NewAchievementService(mockRepo, mockUser, services.NewAccessPolicy(mockUser), nil)
+		lecturerID := uuid.New()
+		claims := &utils.JWTCustomClaims{UserID: lecturerID, Role: "Dosen Wali", Permissions: []string{"achievement:delete", models.PermissionAchievementReadAll}}
+
+		mockRepo.On("ListTrashedReferences", mock.Anything, models.AchievementScope{StudentIDs: []uuid.UUID{lecturerID}}).Return([]models.AchievementReference{}, nil)
+
+		_, status, err := service.ListTrash(context.Background(), claims)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("All Trash With Delete All", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		mockUser := new(MockUserRepoForService)
+		service := services.NewAchievementService(mockRepo, mockUser, services.NewAccessPolicy(mockUser), nil)
+		claims := &utils.JWTCustomClaims{UserID: uuid.New(), Role: "Admin", Permissions: []string{models.PermissionAchievementDeleteAll}}
+
+		mockRepo.On("ListTrashedReferences", mock.Anything, models.AchievementScope{All: true}).Return([]models.AchievementReference{}, nil)
+
+		_, status, err := service.ListTrash(context.Background(), claims)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestPurgeExpiredTrash(t *testing.T) {
+	mockRepo := new(MockAchieveRepo)
+	mockUser := new(MockUserRepoForService)
+	service := services.NewAchievementService(mockRepo, mockUser, services.NewAccessPolicy(mockUser), nil)
+
+	t.Run("Purge Removes Attachment Files", func(t *testing.T) {
+		refID := uuid.New()
+		filePath := filepath.Join(t.TempDir(), "bukti.pdf")
+		assert.NoError(t, os.WriteFile(filePath, []byte("dummy"), 0o644))
+
+		mockRepo.On("ListExpiredTrashReferenceIDs", mock.Anything, mock.AnythingOfType("time.Time")).Return([]uuid.UUID{refID}, nil)
+		mockRepo.On("HardDeleteAchievement", mock.Anything, refID).Return([]models.AttachmentFile{{FileName: "bukti.pdf", FileUrl: filePath}}, nil)
+
+		purged, err := service.PurgeExpiredTrash(context.Background(), 30*24*time.Hour)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, purged)
+
+		_, statErr := os.Stat(filePath)
+		assert.True(t, os.IsNotExist(statErr))
+	})
+}
+
+func TestTrashPurgeJobInterval(t *testing.T) {
+	t.Run("Non-Positive Env Interval Uses Fallback", func(t *testing.T) {
+		t.Setenv("TRASH_PURGE_INTERVAL", "0s")
+		assert.Equal(t, time.Hour, utils.GetEnvPositiveDuration("TRASH_PURGE_INTERVAL", time.Hour))
+		t.Setenv("TRASH_PURGE_INTERVAL", "-5m")
+		assert.Equal(t, time.Hour, utils.GetEnvPositiveDuration("TRASH_PURGE_INTERVAL", time.Hour))
+	})
+
+	t.Run("Zero Interval Does Not Panic", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		assert.NotPanics(t, func() {
+			services.StartTrashPurgeJob(ctx, new(MockAchieveService), time.Hour, 0)
+		})
+	})
+}
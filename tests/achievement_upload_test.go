@@ -12,6 +12,7 @@ import (
 	"prestasi-mahasiswa-api/models"
 	"prestasi-mahasiswa-api/utils"
 	"testing"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -37,9 +38,14 @@ func (m *MockAchieveService) DeleteDraft(ctx context.Context, sid uuid.UUID, rid
 func (m *MockAchieveService) SubmitForVerification(ctx context.Context, sid uuid.UUID, rid uuid.UUID) (*models.AchievementReference, int, error) { return nil, 0, nil }
 func (m *MockAchieveService) ListFilteredAchievements(ctx context.Context, c *utils.JWTCustomClaims) ([]models.AchievementDetailResponse, int, error) { return nil, 0, nil }
 func (m *MockAchieveService) GetDetailWithVerification(ctx context.Context, c *utils.JWTCustomClaims, rid uuid.UUID) (*models.AchievementDetailResponse, int, error) { return nil, 0, nil }
-func (m *MockAchieveService) VerifyAchievement(ctx context.Context, aid uuid.UUID, rid uuid.UUID) (*models.AchievementReference, int, error) { return nil, 0, nil }
-func (m *MockAchieveService) RejectAchievement(ctx context.Context, aid uuid.UUID, rid uuid.UUID, n string) (*models.AchievementReference, int, error) { return nil, 0, nil }
+func (m *MockAchieveService) VerifyAchievement(ctx context.Context, c *utils.JWTCustomClaims, rid uuid.UUID) (*models.AchievementReference, int, error) { return nil, 0, nil }
+func (m *MockAchieveService) RejectAchievement(ctx context.Context, c *utils.JWTCustomClaims, rid uuid.UUID, n string) (*models.AchievementReference, int, error) { return nil, 0, nil }
 func (m *MockAchieveService) HardDelete(ctx context.Context, rid uuid.UUID) (int, error) { return 0, nil }
+func (m *MockAchieveService) ListTrash(ctx context.Context, c *utils.JWTCustomClaims) ([]models.AchievementDetailResponse, int, error) { return nil, 0, nil }
+func (m *MockAchieveService) RestoreFromTrash(ctx context.Context, c *utils.JWTCustomClaims, rid uuid.UUID) (*models.AchievementReference, int, error) { return nil, 0, nil }
+func (m *MockAchieveService) PurgeExpiredTrash(ctx context.Context, r time.Duration) (int, error) { return 0, nil }
+func (m *MockAchieveService) RevokeVerification(ctx context.Context, c *utils.JWTCustomClaims, rid uuid.UUID, r string) (*models.AchievementReference, int, error) { return nil, 0, nil }
+func (m *MockAchieveService) VerifyPublicCode(ctx context.Context, code string) (*models.AchievementVerification, int, error) { return nil, 0, nil }
 
 // --- TEST CASE ---
 func TestUploadAttachmentFromTestsFolder(t *testing.T) {
@@ -0,0 +1,194 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- MOCK REPOSITORY ADVISOR ---
+type MockAdvisorRepo struct {
+	mock.Mock
+}
+
+func (m *MockAdvisorRepo) FindStudentUserIDs(ctx context.Context, filter models.AdviseeFilter) ([]uuid.UUID, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockAdvisorRepo) AssignAdvisor(ctx context.Context, ids []uuid.UUID, lid uuid.UUID, by uuid.UUID, reason string) (*models.AdvisorAssignmentResult, error) {
+	args := m.Called(ctx, ids, lid, by, reason)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.AdvisorAssignmentResult), args.Error(1)
+}
+
+func (m *MockAdvisorRepo) ListAssignmentHistory(ctx context.Context, id uuid.UUID) ([]models.AdvisorAssignment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]models.AdvisorAssignment), args.Error(1)
+}
+
+func TestBulkAssignAdvisor(t *testing.T) {
+	adminID := uuid.New()
+	advisorUserID := uuid.New()
+	lecturer := &models.Lecturer{ID: uuid.New(), UserID: advisorUserID}
+
+	t.Run("By Study Program And Academic Year", func(t *testing.T) {
+		mockAdvisor := new(MockAdvisorRepo)
+		mockProfile := new(MockProfileRepo)
+		mockUnit := new(MockAcademicUnitRepo)
+		service := services.NewAdvisorService(mockAdvisor, mockProfile, mockUnit, nil)
+
+		programID := uuid.New()
+		students := []uuid.UUID{uuid.New(), uuid.New()}
+
+		mockProfile.On("GetLecturerByUserID", mock.Anything, advisorUserID).Return(lecturer, nil)
+		mockUnit.On("GetStudyProgramByID", mock.Anything, programID).Return(&models.StudyProgram{ID: programID, Name: "Informatika"}, nil)
+		mockAdvisor.On("FindStudentUserIDs", mock.Anything, models.AdviseeFilter{StudyProgramID: &programID, AcademicYear: "2023"}).Return(students, nil)
+		mockAdvisor.On("AssignAdvisor", mock.Anything, students, lecturer.ID, adminID, "angkatan baru").
+			Return(&models.AdvisorAssignmentResult{Matched: 2, Assigned: 2, ReroutedSubmissions: 1}, nil)
+
+		res, status, err := service.BulkAssignAdvisor(context.Background(), adminID, &models.BulkAssignAdvisorRequest{
+			AdvisorUserID: advisorUserID.String(), StudyProgramID: programID.String(), AcademicYear: "2023", Reason: " angkatan baru ",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, 2, res.Assigned)
+		assert.Equal(t, 1, res.ReroutedSubmissions)
+		assert.Equal(t, advisorUserID, res.AdvisorUserID)
+	})
+
+	t.Run("Explicit List Takes Precedence", func(t *testing.T) {
+		mockAdvisor := new(MockAdvisorRepo)
+		mockProfile := new(MockProfileRepo)
+		service := services.NewAdvisorService(mockAdvisor, mockProfile, new(MockAcademicUnitRepo), nil)
+
+		studentID := uuid.New()
+		mockProfile.On("GetLecturerByUserID", mock.Anything, advisorUserID).Return(lecturer, nil)
+		mockAdvisor.On("AssignAdvisor", mock.Anything, []uuid.UUID{studentID}, lecturer.ID, adminID, "").
+			Return(&models.AdvisorAssignmentResult{Matched: 1, Assigned: 1}, nil)
+
+		_, status, err := service.BulkAssignAdvisor(context.Background(), adminID, &models.BulkAssignAdvisorRequest{
+			AdvisorUserID: advisorUserID.String(), StudentUserIDs: []string{studentID.String(), studentID.String()}, AcademicYear: "2023",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		mockAdvisor.AssertNotCalled(t, "FindStudentUserIDs", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Missing Criteria", func(t *testing.T) {
+		mockProfile := new(MockProfileRepo)
+		service := services.NewAdvisorService(new(MockAdvisorRepo), mockProfile, new(MockAcademicUnitRepo), nil)
+		mockProfile.On("GetLecturerByUserID", mock.Anything, advisorUserID).Return(lecturer, nil)
+
+		_, status, err := service.BulkAssignAdvisor(context.Background(), adminID, &models.BulkAssignAdvisorRequest{AdvisorUserID: advisorUserID.String()})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
+func TestTransferAdvisees(t *testing.T) {
+	adminID := uuid.New()
+	from := &models.Lecturer{ID: uuid.New(), UserID: uuid.New()}
+	to := &models.Lecturer{ID: uuid.New(), UserID: uuid.New()}
+
+	t.Run("Moves All Advisees", func(t *testing.T) {
+		mockAdvisor := new(MockAdvisorRepo)
+		mockProfile := new(MockProfileRepo)
+		service := services.NewAdvisorService(mockAdvisor, mockProfile, new(MockAcademicUnitRepo), nil)
+
+		advisees := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+		mockProfile.On("GetLecturerByUserID", mock.Anything, from.UserID).Return(from, nil)
+		mockProfile.On("GetLecturerByUserID", mock.Anything, to.UserID).Return(to, nil)
+		mockAdvisor.On("FindStudentUserIDs", mock.Anything, models.AdviseeFilter{AdvisorID: &from.ID}).Return(advisees, nil)
+		mockAdvisor.On("AssignAdvisor", mock.Anything, advisees, to.ID, adminID, "pensiun").
+			Return(&models.AdvisorAssignmentResult{Matched: 3, Assigned: 3, ReroutedSubmissions: 2}, nil)
+
+		res, status, err := service.TransferAdvisees(context.Background(), adminID, &models.TransferAdviseesRequest{
+			FromAdvisorUserID: from.UserID.String(), ToAdvisorUserID: to.UserID.String(), Reason: "pensiun",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, 3, res.Assigned)
+		assert.Equal(t, 2, res.ReroutedSubmissions)
+	})
+
+	t.Run("Same Advisor Rejected", func(t *testing.T) {
+		mockProfile := new(MockProfileRepo)
+		service := services.NewAdvisorService(new(MockAdvisorRepo), mockProfile, new(MockAcademicUnitRepo), nil)
+		mockProfile.On("GetLecturerByUserID", mock.Anything, from.UserID).Return(from, nil)
+
+		_, status, err := service.TransferAdvisees(context.Background(), adminID, &models.TransferAdviseesRequest{
+			FromAdvisorUserID: from.UserID.String(), ToAdvisorUserID: from.UserID.String(),
+		})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
+func TestAssignAdvisorStudentNotFound(t *testing.T) {
+	mockAdvisor := new(MockAdvisorRepo)
+	mockProfile := new(MockProfileRepo)
+	service := services.NewAdvisorService(mockAdvisor, mockProfile, new(MockAcademicUnitRepo), nil)
+
+	adminID := uuid.New()
+	studentID := uuid.New()
+	lecturer := &models.Lecturer{ID: uuid.New(), UserID: uuid.New()}
+	mockProfile.On("GetLecturerByUserID", mock.Anything, lecturer.UserID).Return(lecturer, nil)
+	mockAdvisor.On("AssignAdvisor", mock.Anything, []uuid.UUID{studentID}, lecturer.ID, adminID, "").
+		Return(&models.AdvisorAssignmentResult{NotFound: []uuid.UUID{studentID}}, nil)
+
+	_, status, err := service.AssignAdvisor(context.Background(), adminID, studentID, &models.AssignAdvisorRequest{AdvisorUserID: lecturer.UserID.String()})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestVerifyAchievementAssignedAdvisor(t *testing.T) {
+	mockRepo := new(MockAchieveRepo)
+	mockUser := new(MockUserRepoForService)
+	service := services.NewAchievementService(mockRepo, mockUser, services.NewAccessPolicy(mockUser), nil)
+
+	refID := uuid.New()
+	assignedAdvisor := uuid.New()
+	mockRepo.On("GetReferenceByID", mock.Anything, refID).Return(&models.AchievementReference{ID: refID, Status: "submitted"}, nil)
+	mockRepo.On("GetAssignedAdvisorUserID", mock.Anything, refID).Return(&assignedAdvisor, nil)
+
+	t.Run("Previous Advisor Forbidden", func(t *testing.T) {
+		claims := &utils.JWTCustomClaims{UserID: uuid.New(), Role: "Dosen Wali"}
+
+		_, status, err := service.VerifyAchievement(context.Background(), claims, refID)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+
+	t.Run("Assigned Advisor Verifies", func(t *testing.T) {
+		claims := &utils.JWTCustomClaims{UserID: assignedAdvisor, Role: "Dosen Wali"}
+		mockRepo.On("UpdateReferenceStatus", mock.Anything, refID, "submitted", "verified", "", assignedAdvisor).
+			Return(&models.AchievementReference{ID: refID, Status: "verified"}, nil).Once()
+
+		ref, status, err := service.VerifyAchievement(context.Background(), claims, refID)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "verified", ref.Status)
+	})
+
+	t.Run("Verify All Permission Bypasses Assignment", func(t *testing.T) {
+		adminID := uuid.New()
+		claims := &utils.JWTCustomClaims{UserID: adminID, Role: "Admin", Permissions: []string{models.PermissionAchievementVerifyAll}}
+		mockRepo.On("UpdateReferenceStatus", mock.Anything, refID, "submitted", "rejected", "bukti kurang", adminID).
+			Return(&models.AchievementReference{ID: refID, Status: "rejected"}, nil).Once()
+
+		_, status, err := service.RejectAchievement(context.Background(), claims, refID, "bukti kurang")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+	})
+}
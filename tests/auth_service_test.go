@@ -23,22 +23,72 @@ func (m *MockUserRepo) FindUserByUsernameOrEmail(ctx context.Context, identifier
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
+func (m *MockUserRepo) FindDuplicateFields(ctx context.Context, f models.UserUniqueFields, exclude uuid.UUID) ([]string, error) {
+	args := m.Called(ctx, f, exclude)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockUserRepo) GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepo) UpdatePassword(ctx context.Context, id uuid.UUID, hash string) error {
+	args := m.Called(ctx, id, hash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) FindUserByStudentNumber(ctx context.Context, nim string) (*models.User, error) {
+	args := m.Called(ctx, nim)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepo) FindUserByLecturerNumber(ctx context.Context, nip string) (*models.User, error) {
+	args := m.Called(ctx, nip)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepo) FindUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepo) CreateUser(ctx context.Context, user *models.User) (*models.User, error) {
+	args := m.Called(ctx, user)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepo) UpdateUser(ctx context.Context, id uuid.UUID, req *models.UpdateUserRequest, roleID *uuid.UUID) (*models.User, error) {
+	args := m.Called(ctx, id, req, roleID)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
 // Implementasikan method interface lainnya (kosongkan saja)
-func (m *MockUserRepo) GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error) { return nil, nil }
-func (m *MockUserRepo) CreateUser(ctx context.Context, user *models.User) (*models.User, error) { return nil, nil }
-func (m *MockUserRepo) UpdateUser(ctx context.Context, u uuid.UUID, r *models.UpdateUserRequest, ri *uuid.UUID) (*models.User, error) { return nil, nil }
 func (m *MockUserRepo) DeleteUser(ctx context.Context, id uuid.UUID) error { return nil }
-func (m *MockUserRepo) ListAllUsers(ctx context.Context) ([]models.User, error) { return nil, nil }
+func (m *MockUserRepo) ListUsers(ctx context.Context, q models.UserListQuery) ([]models.UserListItem, int, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil { return nil, 0, args.Error(2) }
+	return args.Get(0).([]models.UserListItem), args.Int(1), args.Error(2)
+}
 func (m *MockUserRepo) GetAdviseeStudentUserIDsByAdvisorUserID(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) { return nil, nil }
+func (m *MockUserRepo) GetDepartmentStudentUserIDsByUserID(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) { return nil, nil }
+func (m *MockUserRepo) GetProgramStudentUserIDsByHeadUserID(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) { return nil, nil }
 
 func TestLoginService(t *testing.T) {
 	mockRepo := new(MockUserRepo)
-	service := services.NewAuthService(mockRepo)
+	service := services.NewAuthService(mockRepo, new(MockResetRepo), new(MockProfileRepo), newTestLoginGuard(), newNoMFAService(mockRepo), services.NewLocalAuthenticator())
 
 	t.Run("User Not Found", func(t *testing.T) {
 		mockRepo.On("FindUserByUsernameOrEmail", mock.Anything, "unknown").Return(nil, errors.New("not found"))
 
-		_, status, err := service.PerformLogin(context.Background(), "unknown", "password")
+		_, status, err := service.PerformLogin(context.Background(), "unknown", "password", models.ClientInfo{})
 		assert.Error(t, err)
 		assert.Equal(t, 401, status)
 	})
@@ -0,0 +1,155 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/xuri/excelize/v2"
+)
+
+func newExportService(mockRepo *MockAchieveRepo, mockProfile *MockProfileRepo) services.ExportService {
+	policy := services.NewAccessPolicy(new(MockUserRepoForService))
+	return services.NewExportService(mockRepo, services.NewReportService(mockRepo, mockProfile, policy, nil), policy)
+}
+
+func TestExportAchievements(t *testing.T) {
+	adminClaims := &utils.JWTCustomClaims{UserID: uuid.New(), Role: "Admin", Permissions: []string{models.PermissionAchievementReadAll}}
+	verifiedAt := time.Date(2025, time.March, 12, 9, 0, 0, 0, time.UTC)
+	records := []models.AchievementRecord{
+		{StudentNumber: "2110511001", StudentName: "Budi", ProgramStudy: "Informatika", Title: "Olimpiade, Nasional", AchievementType: "competition",
+			Status: "verified", Points: 80, SubmittedAt: &verifiedAt, VerifiedAt: &verifiedAt},
+		{StudentNumber: "2110511002", StudentName: "Sari", ProgramStudy: "Informatika", Title: "Seminar", AchievementType: "academic", Status: "draft"},
+	}
+
+	t.Run("CSV Uses Indonesian Headers And Labels", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		service := newExportService(mockRepo, new(MockProfileRepo))
+		mockRepo.On("EachAchievementRecord", mock.Anything, models.AchievementScope{All: true}).Return(records, nil)
+
+		job, status, err := service.ExportAchievements(context.Background(), adminClaims, "")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.True(t, strings.HasSuffix(job.FileName, ".csv"))
+		assert.Contains(t, job.ContentType, "text/csv")
+
+		var buf bytes.Buffer
+		assert.NoError(t, job.Write(context.Background(), &buf))
+		assert.True(t, strings.HasPrefix(buf.String(), "\ufeff"))
+
+		rows, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(buf.String(), "\ufeff"))).ReadAll()
+		assert.NoError(t, err)
+		assert.Len(t, rows, 3)
+		assert.Equal(t, []string{"No", "NIM", "Nama Mahasiswa", "Program Studi", "Jenis Prestasi", "Judul Prestasi", "Poin", "Status", "Tanggal Diajukan", "Tanggal Diverifikasi"}, rows[0])
+		assert.Equal(t, []string{"1", "2110511001", "Budi", "Informatika", "competition", "Olimpiade, Nasional", "80", "Terverifikasi", "12-03-2025", "12-03-2025"}, rows[1])
+		assert.Equal(t, "Draf", rows[2][7])
+		assert.Equal(t, "", rows[2][9])
+	})
+
+	t.Run("XLSX Readable", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		service := newExportService(mockRepo, new(MockProfileRepo))
+		mockRepo.On("EachAchievementRecord", mock.Anything, models.AchievementScope{All: true}).Return(records, nil)
+
+		job, _, err := service.ExportAchievements(context.Background(), adminClaims, "xlsx")
+		assert.NoError(t, err)
+
+		var buf bytes.Buffer
+		assert.NoError(t, job.Write(context.Background(), &buf))
+		f, err := excelize.OpenReader(&buf)
+		assert.NoError(t, err)
+		rows, err := f.GetRows(f.GetSheetName(0))
+		assert.NoError(t, err)
+		assert.Equal(t, "Rekap Prestasi Mahasiswa", rows[0][0])
+
+		found := false
+		for _, row := range rows {
+			if len(row) > 5 && row[1] == "2110511001" {
+				found = true
+				assert.Equal(t, "Olimpiade, Nasional", row[5])
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("PDF Generated", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		service := newExportService(mockRepo, new(MockProfileRepo))
+		mockRepo.On("EachAchievementRecord", mock.Anything, models.AchievementScope{All: true}).Return(records, nil)
+
+		job, _, err := service.ExportAchievements(context.Background(), adminClaims, "PDF")
+		assert.NoError(t, err)
+		assert.Equal(t, "application/pdf", job.ContentType)
+
+		var buf bytes.Buffer
+		assert.NoError(t, job.Write(context.Background(), &buf))
+		assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF")))
+	})
+
+	t.Run("Unsupported Format Rejected", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		service := newExportService(mockRepo, new(MockProfileRepo))
+
+		_, status, err := service.ExportAchievements(context.Background(), adminClaims, "docx")
+		assert.ErrorIs(t, err, utils.ErrUnsupportedExportFormat)
+		assert.Equal(t, http.StatusBadRequest, status)
+		mockRepo.AssertNotCalled(t, "EachAchievementRecord", mock.Anything, mock.Anything)
+	})
+
+	t.Run("No Read Permission Forbidden", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		service := newExportService(mockRepo, new(MockProfileRepo))
+		claims := &utils.JWTCustomClaims{UserID: uuid.New(), Role: "Mahasiswa"}
+
+		_, status, err := service.ExportAchievements(context.Background(), claims, "csv")
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+}
+
+func TestExportStudentReport(t *testing.T) {
+	studentID := uuid.New()
+
+	t.Run("PDF For Own Report", func(t *testing.T) {
+		mockRepo, mockProfile := new(MockAchieveRepo), new(MockProfileRepo)
+		service := newExportService(mockRepo, mockProfile)
+		claims := &utils.JWTCustomClaims{UserID: studentID, Role: "Mahasiswa", Permissions: []string{models.PermissionAchievementReadOwn}}
+
+		mockProfile.On("GetStudentProfileByUserID", mock.Anything, studentID).Return(&models.StudentProfileDetail{
+			Student:  models.Student{UserID: studentID, StudentID: "2110511001", ProgramStudy: "Informatika", AcademicYear: "2021"},
+			FullName: "Budi",
+		}, nil)
+		mockRepo.On("ListAchievementRecords", mock.Anything, models.AchievementScope{StudentIDs: []uuid.UUID{studentID}}).Return([]models.AchievementRecord{
+			{Title: "Hackathon", AchievementType: "competition", Status: "verified", Points: 50, CreatedAt: time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC)},
+		}, nil)
+
+		job, status, err := service.ExportStudentReport(context.Background(), claims, studentID, "pdf")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Contains(t, job.FileName, "2110511001")
+
+		var buf bytes.Buffer
+		assert.NoError(t, job.Write(context.Background(), &buf))
+		assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF")))
+	})
+
+	t.Run("Other Student Forbidden", func(t *testing.T) {
+		service := newExportService(new(MockAchieveRepo), new(MockProfileRepo))
+		claims := &utils.JWTCustomClaims{UserID: uuid.New(), Role: "Mahasiswa", Permissions: []string{models.PermissionAchievementReadOwn}}
+
+		_, status, err := service.ExportStudentReport(context.Background(), claims, studentID, "xlsx")
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+}
@@ -17,7 +17,7 @@ func TestJWTFlow(t *testing.T) {
 	permissions := []string{"achievement:create"}
 
 	// 1. Test Generate Token
-	token, _, err := utils.GenerateAuthTokens(userID, role, permissions)
+	token, _, err := utils.GenerateAuthTokens(userID, role, permissions, 0)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, token)
 
@@ -0,0 +1,189 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+	"github.com/jimlambrt/gldap"
+	"github.com/jimlambrt/gldap/testdirectory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testLDAPAdminGroup = "cn=admins,ou=groups,dc=example,dc=org"
+
+// startTestDirectory menjalankan server LDAP in-process berisi akun staf
+func startTestDirectory(t *testing.T) *testdirectory.Directory {
+	dir := testdirectory.Start(t, testdirectory.WithNoTLS(t))
+	dir.SetUsers(
+		gldap.NewEntry("cn=dosen1,"+testdirectory.DefaultUserDN, map[string][]string{
+			"email":    {"Dosen1@Kampus.ac.id"},
+			"name":     {"Dr. Dosen Satu"},
+			"password": {"ldap-secret"},
+			"memberOf": {"cn=dosen,ou=groups,dc=example,dc=org"},
+		}),
+		gldap.NewEntry("cn=kajur,"+testdirectory.DefaultUserDN, map[string][]string{
+			"email":    {"kajur@kampus.ac.id"},
+			"password": {"ldap-secret"},
+			"memberOf": {"cn=dosen,ou=groups,dc=example,dc=org", testLDAPAdminGroup},
+		}),
+	)
+	return dir
+}
+
+func testLDAPConfig(dir *testdirectory.Directory) services.LDAPConfig {
+	return services.LDAPConfig{
+		URL:            fmt.Sprintf("ldap://%s:%d", dir.Host(), dir.Port()),
+		BaseDN:         testdirectory.DefaultUserDN,
+		UserFilter:     "(cn=%s)",
+		EmailAttribute: "email",
+		NameAttribute:  "name",
+		RoleAttribute:  "memberOf",
+		RoleMappings: services.ParseLDAPRoleMappings(
+			testLDAPAdminGroup + "=>Admin; cn=dosen,ou=groups,dc=example,dc=org=>Dosen Wali"),
+	}
+}
+
+func TestParseLDAPRoleMappings(t *testing.T) {
+	mappings := services.ParseLDAPRoleMappings("cn=a,dc=x=>Admin;invalid; =>Empty;cn=b,dc=x=>Dosen Wali")
+	assert.Equal(t, []services.LDAPRoleMapping{
+		{Value: "cn=a,dc=x", RoleName: "Admin"},
+		{Value: "cn=b,dc=x", RoleName: "Dosen Wali"},
+	}, mappings)
+}
+
+func TestLDAPAuthenticator(t *testing.T) {
+	ctx := context.Background()
+	dir := startTestDirectory(t)
+	dosen := &models.User{ID: uuid.New(), Username: "dosen1", IsActive: true, Role: "Dosen Wali", AuthSource: models.AuthSourceLDAP}
+
+	auth := services.NewLDAPAuthenticator(testLDAPConfig(dir), new(MockUserRepo), new(MockRoleRepo), nil)
+
+	t.Run("Valid Bind", func(t *testing.T) {
+		user, err := auth.Authenticate(ctx, "dosen1", "ldap-secret", dosen)
+		assert.NoError(t, err)
+		assert.Equal(t, dosen.ID, user.ID)
+	})
+
+	t.Run("Wrong Password", func(t *testing.T) {
+		_, err := auth.Authenticate(ctx, "dosen1", "wrong", dosen)
+		assert.ErrorIs(t, err, services.ErrInvalidCredentials)
+	})
+
+	t.Run("Empty Password Rejected", func(t *testing.T) {
+		_, err := auth.Authenticate(ctx, "dosen1", "", dosen)
+		assert.ErrorIs(t, err, services.ErrInvalidCredentials)
+	})
+
+	t.Run("Unknown Local Account Without Provisioning", func(t *testing.T) {
+		_, err := auth.Authenticate(ctx, "dosen1", "ldap-secret", nil)
+		assert.ErrorIs(t, err, services.ErrInvalidCredentials)
+	})
+}
+
+func TestLDAPRoleSyncAndProvisioning(t *testing.T) {
+	ctx := context.Background()
+	dir := startTestDirectory(t)
+	adminRoleID := uuid.New()
+
+	mockRepo := new(MockUserRepo)
+	mockRole := new(MockRoleRepo)
+	mockRole.On("GetRoleByName", mock.Anything, "Admin").Return(&models.Role{ID: adminRoleID, Name: "Admin"}, nil)
+
+	cfg := testLDAPConfig(dir)
+	cfg.AutoProvision = true
+	auth := services.NewLDAPAuthenticator(cfg, mockRepo, mockRole, nil)
+
+	t.Run("Mapped Role Overrides Local Role", func(t *testing.T) {
+		kajur := &models.User{ID: uuid.New(), Username: "kajur", IsActive: true, Role: "Dosen Wali", AuthSource: models.AuthSourceLDAP}
+		mockRepo.On("UpdateUser", mock.Anything, kajur.ID, mock.Anything, &adminRoleID).
+			Return(&models.User{ID: kajur.ID, Username: "kajur", IsActive: true, Role: "Admin"}, nil).Once()
+
+		user, err := auth.Authenticate(ctx, "kajur", "ldap-secret", kajur)
+		assert.NoError(t, err)
+		assert.Equal(t, "Admin", user.Role)
+	})
+
+	t.Run("Local Account Role Is Never Synced", func(t *testing.T) {
+		hash, _ := utils.HashPassword("local-secret")
+		kajur := &models.User{ID: uuid.New(), Username: "kajur", PasswordHash: hash, IsActive: true, Role: "Dosen Wali", AuthSource: models.AuthSourceLocal}
+
+		user, err := auth.Authenticate(ctx, "kajur", "local-secret", kajur)
+		assert.NoError(t, err)
+		assert.Equal(t, "Dosen Wali", user.Role)
+		mockRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, kajur.ID, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Provisions Staff Account", func(t *testing.T) {
+		mockRepo.On("CreateUser", mock.Anything, mock.MatchedBy(func(u *models.User) bool {
+			return u.Username == "kajur" && u.Email == "kajur@kampus.ac.id" && u.RoleID == adminRoleID &&
+				u.AuthSource == models.AuthSourceLDAP
+		})).Return(&models.User{ID: uuid.New(), Username: "kajur", IsActive: true, Role: "Admin"}, nil).Once()
+
+		user, err := auth.Authenticate(ctx, "kajur", "ldap-secret", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "Admin", user.Role)
+	})
+}
+
+func TestLDAPLocalAccounts(t *testing.T) {
+	ctx := context.Background()
+	dir := startTestDirectory(t)
+	hash, _ := utils.HashPassword("local-secret")
+	student := &models.User{ID: uuid.New(), Username: "mhs1", PasswordHash: hash, IsActive: true, Role: "Mahasiswa", AuthSource: models.AuthSourceLocal}
+	localDosen := &models.User{ID: uuid.New(), Username: "dosen1", PasswordHash: hash, IsActive: true, Role: "Dosen Wali", AuthSource: models.AuthSourceLocal}
+	dosen := &models.User{ID: uuid.New(), Username: "dosen1", PasswordHash: hash, IsActive: true, Role: "Dosen Wali", AuthSource: models.AuthSourceLDAP}
+
+	auth := services.NewLDAPAuthenticator(testLDAPConfig(dir), new(MockUserRepo), new(MockRoleRepo), services.NewLocalAuthenticator())
+
+	t.Run("Local Account Uses Local Password", func(t *testing.T) {
+		user, err := auth.Authenticate(ctx, "mhs1", "local-secret", student)
+		assert.NoError(t, err)
+		assert.Equal(t, student.ID, user.ID)
+	})
+
+	t.Run("Directory Entry Cannot Take Over Local Account", func(t *testing.T) {
+		_, err := auth.Authenticate(ctx, "dosen1", "ldap-secret", localDosen)
+		assert.ErrorIs(t, err, services.ErrInvalidCredentials)
+	})
+
+	t.Run("Wrong Directory Password Is Not Retried Locally", func(t *testing.T) {
+		_, err := auth.Authenticate(ctx, "dosen1", "local-secret", dosen)
+		assert.ErrorIs(t, err, services.ErrInvalidCredentials)
+	})
+
+	t.Run("Directory Down", func(t *testing.T) {
+		cfg := testLDAPConfig(dir)
+		cfg.URL = fmt.Sprintf("ldap://127.0.0.1:%d", testdirectory.FreePort(t))
+		withoutFallback := services.NewLDAPAuthenticator(cfg, new(MockUserRepo), new(MockRoleRepo), services.NewLocalAuthenticator())
+		_, err := withoutFallback.Authenticate(ctx, "dosen1", "local-secret", dosen)
+		assert.ErrorIs(t, err, services.ErrAuthBackendUnavailable)
+
+		cfg.FallbackLocal = true
+		withFallback := services.NewLDAPAuthenticator(cfg, new(MockUserRepo), new(MockRoleRepo), services.NewLocalAuthenticator())
+		user, err := withFallback.Authenticate(ctx, "dosen1", "local-secret", dosen)
+		assert.NoError(t, err)
+		assert.Equal(t, dosen.ID, user.ID)
+	})
+}
+
+func TestPerformLoginWithLDAPUnavailable(t *testing.T) {
+	dosen := &models.User{ID: uuid.New(), Username: "dosen1", IsActive: true, Role: "Dosen Wali", AuthSource: models.AuthSourceLDAP}
+	mockRepo := new(MockUserRepo)
+	mockRepo.On("FindUserByUsernameOrEmail", mock.Anything, "dosen1").Return(dosen, nil)
+
+	cfg := services.LDAPConfig{URL: fmt.Sprintf("ldap://127.0.0.1:%d", testdirectory.FreePort(t)), UserFilter: "(uid=%s)"}
+	auth := services.NewLDAPAuthenticator(cfg, mockRepo, new(MockRoleRepo), nil)
+	service := services.NewAuthService(mockRepo, new(MockResetRepo), new(MockProfileRepo), newTestLoginGuard(), newNoMFAService(mockRepo), auth)
+
+	_, status, err := service.PerformLogin(context.Background(), "dosen1", "secret", models.ClientInfo{})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+}
@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/repositories"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testLoginGuardConfig() services.LoginGuardConfig {
+	return services.LoginGuardConfig{
+		FailureWindow:    15 * time.Minute,
+		DelayAfter:       3,
+		BaseDelay:        time.Second,
+		MaxDelay:         30 * time.Second,
+		LockoutThreshold: 5,
+		LockoutDuration:  15 * time.Minute,
+		IPThreshold:      20,
+	}
+}
+
+func newTestLoginGuard() *services.LoginGuard {
+	return services.NewLoginGuard(repositories.NewInMemoryLoginAttemptStore(), testLoginGuardConfig())
+}
+
+func TestLoginGuardLockout(t *testing.T) {
+	ctx := context.Background()
+	client := models.ClientInfo{IPAddress: "10.0.0.1", UserAgent: "test"}
+	guard := newTestLoginGuard()
+
+	status, err := guard.Check(ctx, "budi", client)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+
+	t.Run("Progressive Delay", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			guard.RecordFailure(ctx, "budi", nil, client, "invalid_password")
+		}
+		status, err := guard.Check(ctx, "budi", client)
+		var throttleErr *services.LoginThrottleError
+		assert.True(t, errors.As(err, &throttleErr))
+		assert.Equal(t, http.StatusTooManyRequests, status)
+		assert.LessOrEqual(t, throttleErr.RetryAfter, time.Second)
+	})
+
+	t.Run("Locked After Threshold", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			guard.RecordFailure(ctx, "budi", nil, client, "invalid_password")
+		}
+		status, err := guard.Check(ctx, "budi", client)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusLocked, status)
+
+		// Akun lain dari IP yang sama tidak ikut terkunci
+		status, err = guard.Check(ctx, "siti", client)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+	})
+
+	t.Run("Admin Unlock", func(t *testing.T) {
+		assert.NoError(t, guard.Unlock(ctx, "budi"))
+		status, err := guard.Check(ctx, "budi", client)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+	})
+}
+
+func TestLoginGuardIPLimit(t *testing.T) {
+	ctx := context.Background()
+	config := testLoginGuardConfig()
+	config.IPThreshold = 3
+	guard := services.NewLoginGuard(repositories.NewInMemoryLoginAttemptStore(), config)
+	client := models.ClientInfo{IPAddress: "10.0.0.2"}
+
+	for _, username := range []string{"a", "b", "c"} {
+		guard.RecordFailure(ctx, username, nil, client, "unknown_user")
+	}
+
+	status, err := guard.Check(ctx, "d", client)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, status)
+
+	status, err = guard.Check(ctx, "d", models.ClientInfo{IPAddress: "10.0.0.3"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestPerformLoginLocksAccount(t *testing.T) {
+	mockRepo := new(MockUserRepo)
+	service := services.NewAuthService(mockRepo, new(MockResetRepo), new(MockProfileRepo), newTestLoginGuard(), newNoMFAService(mockRepo), services.NewLocalAuthenticator())
+
+	hash, _ := utils.HashPassword("Rahasia123")
+	user := &models.User{ID: uuid.New(), Username: "andi", PasswordHash: hash, IsActive: true}
+	mockRepo.On("FindUserByUsernameOrEmail", mock.Anything, "andi").Return(user, nil)
+
+	client := models.ClientInfo{IPAddress: "10.0.0.4"}
+	for i := 0; i < 3; i++ {
+		_, status, _ := service.PerformLogin(context.Background(), "andi", "salah", client)
+		assert.Equal(t, http.StatusUnauthorized, status)
+	}
+
+	// Percobaan berikutnya ditahan walaupun password benar
+	_, status, err := service.PerformLogin(context.Background(), "andi", "Rahasia123", client)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, status)
+}
@@ -0,0 +1,207 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMFARepo
+type MockMFARepo struct {
+	mock.Mock
+}
+
+func (m *MockMFARepo) GetByUserID(ctx context.Context, userID uuid.UUID) (*models.UserMFA, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.UserMFA), args.Error(1)
+}
+
+func (m *MockMFARepo) SavePendingSecret(ctx context.Context, userID uuid.UUID, secret string) error {
+	args := m.Called(ctx, userID, secret)
+	return args.Error(0)
+}
+
+func (m *MockMFARepo) Enable(ctx context.Context, userID uuid.UUID, hashes []string, usedStep int64) error {
+	args := m.Called(ctx, userID, hashes, usedStep)
+	return args.Error(0)
+}
+
+func (m *MockMFARepo) MarkStepUsed(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	args := m.Called(ctx, userID, step)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMFARepo) ConsumeRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	args := m.Called(ctx, userID, codeHash)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMFARepo) Delete(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// newNoMFAService: semua user dianggap belum mengaktifkan 2FA dan tidak ada role yang wajib
+func newNoMFAService(userRepo *MockUserRepo) services.MFAService {
+	mockMFA := new(MockMFARepo)
+	mockMFA.On("GetByUserID", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return services.NewMFAService(mockMFA, userRepo, "Test")
+}
+
+func TestMatchTOTPCode(t *testing.T) {
+	enrollment, err := utils.GenerateTOTPEnrollment("Test", "budi")
+	assert.NoError(t, err)
+	assert.Contains(t, enrollment.ProvisioningURI, "otpauth://totp/")
+
+	now := time.Now()
+	code, _ := totp.GenerateCode(enrollment.Secret, now)
+
+	step, ok := utils.MatchTOTPCode(enrollment.Secret, code, now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Unix()/utils.TOTPPeriod, step)
+
+	// Masih diterima satu periode kemudian (toleransi jam), tetapi tidak setelah itu
+	_, ok = utils.MatchTOTPCode(enrollment.Secret, code, now.Add(utils.TOTPPeriod*time.Second))
+	assert.True(t, ok)
+	_, ok = utils.MatchTOTPCode(enrollment.Secret, code, now.Add(3*utils.TOTPPeriod*time.Second))
+	assert.False(t, ok)
+}
+
+func TestMFAVerifyCode(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	enrollment, _ := utils.GenerateTOTPEnrollment("Test", "budi")
+	recoveryCode := "ABCDE-12345"
+
+	mockMFA := new(MockMFARepo)
+	mockMFA.On("GetByUserID", mock.Anything, userID).Return(&models.UserMFA{UserID: userID, Secret: enrollment.Secret, Enabled: true}, nil)
+	service := services.NewMFAService(mockMFA, new(MockUserRepo), "Test")
+
+	t.Run("Valid TOTP", func(t *testing.T) {
+		code, _ := totp.GenerateCode(enrollment.Secret, time.Now())
+		mockMFA.On("MarkStepUsed", mock.Anything, userID, mock.Anything).Return(true, nil).Once()
+		assert.NoError(t, service.VerifyCode(ctx, userID, code))
+	})
+
+	t.Run("Replayed TOTP Rejected", func(t *testing.T) {
+		code, _ := totp.GenerateCode(enrollment.Secret, time.Now())
+		mockMFA.On("MarkStepUsed", mock.Anything, userID, mock.Anything).Return(false, nil).Once()
+		assert.Error(t, service.VerifyCode(ctx, userID, code))
+	})
+
+	t.Run("Recovery Code Consumed Once", func(t *testing.T) {
+		hash := utils.HashToken(utils.NormalizeRecoveryCode(recoveryCode))
+		mockMFA.On("ConsumeRecoveryCode", mock.Anything, userID, hash).Return(true, nil).Once()
+		mockMFA.On("ConsumeRecoveryCode", mock.Anything, userID, hash).Return(false, nil).Once()
+
+		assert.NoError(t, service.VerifyCode(ctx, userID, "abcde-12345"))
+		assert.Error(t, service.VerifyCode(ctx, userID, recoveryCode))
+	})
+}
+
+func TestMFADisable(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	enrollment, _ := utils.GenerateTOTPEnrollment("Test", "sso-user")
+
+	mockMFA := new(MockMFARepo)
+	mockMFA.On("GetByUserID", mock.Anything, userID).Return(&models.UserMFA{UserID: userID, Secret: enrollment.Secret, Enabled: true}, nil)
+	service := services.NewMFAService(mockMFA, new(MockUserRepo), "Test")
+
+	t.Run("Missing Code", func(t *testing.T) {
+		status, err := service.Disable(ctx, userID, nil, &models.MFADisableRequest{})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("Account Without Local Password Disables With TOTP", func(t *testing.T) {
+		code, _ := totp.GenerateCode(enrollment.Secret, time.Now())
+		mockMFA.On("MarkStepUsed", mock.Anything, userID, mock.Anything).Return(true, nil).Once()
+		mockMFA.On("Delete", mock.Anything, userID).Return(nil).Once()
+
+		status, err := service.Disable(ctx, userID, nil, &models.MFADisableRequest{Code: code})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		mockMFA.AssertExpectations(t)
+	})
+}
+
+func TestPerformLoginWithMFA(t *testing.T) {
+	ctx := context.Background()
+	hash, _ := utils.HashPassword("Secret123")
+	user := &models.User{ID: uuid.New(), Username: "dosen", PasswordHash: hash, IsActive: true, Role: "Dosen Wali"}
+	enrollment, _ := utils.GenerateTOTPEnrollment("Test", user.Username)
+
+	mockRepo := new(MockUserRepo)
+	mockRepo.On("FindUserByUsernameOrEmail", mock.Anything, "dosen").Return(user, nil)
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+
+	mockMFA := new(MockMFARepo)
+	mockMFA.On("GetByUserID", mock.Anything, user.ID).Return(&models.UserMFA{UserID: user.ID, Secret: enrollment.Secret, Enabled: true}, nil)
+	mfaService := services.NewMFAService(mockMFA, mockRepo, "Test")
+	service := services.NewAuthService(mockRepo, new(MockResetRepo), new(MockProfileRepo), newTestLoginGuard(), mfaService, services.NewLocalAuthenticator())
+
+	resp, status, err := service.PerformLogin(ctx, "dosen", "Secret123", models.ClientInfo{})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.True(t, resp.Data.MFARequired)
+	assert.Empty(t, resp.Data.Token)
+	assert.NotEmpty(t, resp.Data.ChallengeToken)
+
+	t.Run("Wrong Code", func(t *testing.T) {
+		mockMFA.On("ConsumeRecoveryCode", mock.Anything, user.ID, mock.Anything).Return(false, nil).Once()
+		_, status, err := service.CompleteMFALogin(ctx, &models.MFAChallengeRequest{ChallengeToken: resp.Data.ChallengeToken, Code: "000000x"}, models.ClientInfo{})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("Valid Code Issues Tokens", func(t *testing.T) {
+		code, _ := totp.GenerateCode(enrollment.Secret, time.Now())
+		mockMFA.On("MarkStepUsed", mock.Anything, user.ID, mock.Anything).Return(true, nil).Once()
+		loginResp, status, err := service.CompleteMFALogin(ctx, &models.MFAChallengeRequest{ChallengeToken: resp.Data.ChallengeToken, Code: code}, models.ClientInfo{})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.NotEmpty(t, loginResp.Data.Token)
+	})
+
+	t.Run("Access Token Is Not A Challenge Token", func(t *testing.T) {
+		token, _, _ := utils.GenerateAuthTokens(user.ID.String(), user.Role, nil, user.SessionVersion)
+		_, status, err := service.CompleteMFALogin(ctx, &models.MFAChallengeRequest{ChallengeToken: token, Code: "123456"}, models.ClientInfo{})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+}
+
+func TestPerformLoginRequiresEnrollment(t *testing.T) {
+	hash, _ := utils.HashPassword("Secret123")
+	user := &models.User{ID: uuid.New(), Username: "admin", PasswordHash: hash, IsActive: true, Role: "Admin",
+		Permissions: []string{models.PermissionMFARequired}}
+
+	mockRepo := new(MockUserRepo)
+	mockRepo.On("FindUserByUsernameOrEmail", mock.Anything, "admin").Return(user, nil)
+
+	mockMFA := new(MockMFARepo)
+	mockMFA.On("GetByUserID", mock.Anything, user.ID).Return(nil, nil)
+	mfaService := services.NewMFAService(mockMFA, mockRepo, "Test")
+	service := services.NewAuthService(mockRepo, new(MockResetRepo), new(MockProfileRepo), newTestLoginGuard(), mfaService, services.NewLocalAuthenticator())
+
+	resp, _, err := service.PerformLogin(context.Background(), "admin", "Secret123", models.ClientInfo{})
+	assert.NoError(t, err)
+	assert.True(t, resp.Data.MFAEnrollmentRequired)
+	assert.Empty(t, resp.Data.Token)
+
+	status, err := mfaService.Disable(context.Background(), user.ID, user.Permissions, &models.MFADisableRequest{Code: "123456"})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, status)
+}
@@ -0,0 +1,377 @@
+package tests
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProfileRepo
+type MockProfileRepo struct {
+	mock.Mock
+}
+
+func (m *MockProfileRepo) UpsertStudent(ctx context.Context, s *models.Student) (*models.Student, error) {
+	args := m.Called(ctx, s)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.Student), args.Error(1)
+}
+
+func (m *MockProfileRepo) UpsertLecturer(ctx context.Context, l *models.Lecturer) (*models.Lecturer, error) { return l, nil }
+func (m *MockProfileRepo) GetLecturerByUserID(ctx context.Context, id uuid.UUID) (*models.Lecturer, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.Lecturer), args.Error(1)
+}
+
+func (m *MockProfileRepo) GetStudentProfileByUserID(ctx context.Context, id uuid.UUID) (*models.StudentProfileDetail, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.StudentProfileDetail), args.Error(1)
+}
+
+func (m *MockProfileRepo) GetLecturerProfileByUserID(ctx context.Context, id uuid.UUID) (*models.LecturerProfileDetail, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.LecturerProfileDetail), args.Error(1)
+}
+
+func (m *MockProfileRepo) ListAdviseesByLecturerUserID(ctx context.Context, id uuid.UUID) ([]models.AdviseeSummary, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]models.AdviseeSummary), args.Error(1)
+}
+
+func (m *MockProfileRepo) UpsertFacultyStaff(ctx context.Context, st *models.FacultyStaff) (*models.FacultyStaff, error) {
+	args := m.Called(ctx, st)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.FacultyStaff), args.Error(1)
+}
+
+// mockOIDCProvider adalah identity provider lokal (discovery, JWKS, token endpoint)
+type mockOIDCProvider struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	claims map[string]interface{} // klaim tambahan untuk ID token berikutnya
+	nonce  string
+}
+
+func newMockOIDCProvider(t *testing.T) *mockOIDCProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	p := &mockOIDCProvider{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                p.server.URL,
+			"authorization_endpoint":                p.server.URL + "/authorize",
+			"token_endpoint":                        p.server.URL + "/token",
+			"jwks_uri":                              p.server.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &key.PublicKey, KeyID: "test", Algorithm: "RS256", Use: "sig"},
+		}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("code") != "valid-code" || r.Form.Get("code_verifier") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "idp-access-token",
+			"token_type":   "Bearer",
+			"expires_in":   300,
+			"id_token":     p.signIDToken(t),
+		})
+	})
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+func (p *mockOIDCProvider) signIDToken(t *testing.T) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: p.key}, (&jose.SignerOptions{}).WithHeader("kid", "test"))
+	assert.NoError(t, err)
+
+	now := time.Now()
+	claims := map[string]interface{}{
+		"iss":   p.server.URL,
+		"sub":   "idp-user-1",
+		"aud":   "prestasi-api",
+		"iat":   now.Unix(),
+		"exp":   now.Add(5 * time.Minute).Unix(),
+		"nonce": p.nonce,
+	}
+	for k, v := range p.claims {
+		claims[k] = v
+	}
+	raw, err := jwt.Signed(signer).Claims(claims).Serialize()
+	assert.NoError(t, err)
+	return raw
+}
+
+func testOIDCConfig(issuer string) services.OIDCConfig {
+	return services.OIDCConfig{
+		IssuerURL:       issuer,
+		ClientID:        "prestasi-api",
+		ClientSecret:    "secret",
+		RedirectURL:     "http://localhost:3000/api/v1/auth/oidc/callback",
+		Scopes:          []string{"openid", "email", "profile"},
+		EmailClaim:      "email",
+		NameClaim:       "name",
+		StudentIDClaim:  "nim",
+		LecturerIDClaim: "nip",
+		StudentRoleName: "Mahasiswa",
+		MFAAMRValues:    []string{"mfa", "otp"},
+		MFAACRValues:    []string{"urn:kampus:loa:2"},
+	}
+}
+
+func TestOIDCLogin(t *testing.T) {
+	ctx := context.Background()
+	provider := newMockOIDCProvider(t)
+	student := &models.User{ID: uuid.New(), Username: "2110511001", Email: "budi@kampus.ac.id", IsActive: true, Role: "Mahasiswa"}
+	lecturer := &models.User{ID: uuid.New(), Username: "dosen", Email: "dosen@kampus.ac.id", IsActive: true, Role: "Dosen Wali"}
+
+	mockRepo := new(MockUserRepo)
+	mockRepo.On("FindUserByStudentNumber", mock.Anything, "2110511001").Return(student, nil)
+	mockRepo.On("FindUserByStudentNumber", mock.Anything, mock.Anything).Return(nil, errors.New("user not found"))
+	mockRepo.On("FindUserByEmail", mock.Anything, "dosen@kampus.ac.id").Return(lecturer, nil)
+	mockRepo.On("FindUserByEmail", mock.Anything, mock.Anything).Return(nil, errors.New("user not found"))
+
+	service := services.NewOIDCService(testOIDCConfig(provider.server.URL), mockRepo, new(MockRoleRepo), new(MockProfileRepo), newNoMFAService(mockRepo), newTestLoginGuard())
+
+	authReq, status, err := service.BeginLogin(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	authURL, _ := url.Parse(authReq.AuthURL)
+	assert.Equal(t, authReq.State, authURL.Query().Get("state"))
+	assert.Equal(t, authReq.Nonce, authURL.Query().Get("nonce"))
+	assert.Equal(t, "S256", authURL.Query().Get("code_challenge_method"))
+	provider.nonce = authReq.Nonce
+
+	t.Run("State Mismatch", func(t *testing.T) {
+		_, status, err := service.CompleteLogin(ctx, authReq, "valid-code", "forged-state", models.ClientInfo{})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("Numeric NIM Claim Maps To Existing Student", func(t *testing.T) {
+		provider.claims = map[string]interface{}{"nim": 2110511001, "email": "budi@kampus.ac.id"}
+		resp, status, err := service.CompleteLogin(ctx, authReq, "valid-code", authReq.State, models.ClientInfo{})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.NotEmpty(t, resp.Data.Token)
+		assert.Equal(t, student.ID.String(), resp.Data.User.ID)
+	})
+
+	t.Run("Nonce Mismatch", func(t *testing.T) {
+		provider.nonce = "other-nonce"
+		defer func() { provider.nonce = authReq.Nonce }()
+		_, status, err := service.CompleteLogin(ctx, authReq, "valid-code", authReq.State, models.ClientInfo{})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("Unverified Email Is Not Matched", func(t *testing.T) {
+		provider.claims = map[string]interface{}{"email": "budi@kampus.ac.id", "email_verified": false}
+		_, status, err := service.CompleteLogin(ctx, authReq, "valid-code", authReq.State, models.ClientInfo{})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, status)
+		mockRepo.AssertNotCalled(t, "FindUserByEmail", mock.Anything, "budi@kampus.ac.id")
+	})
+
+	t.Run("Missing Email Verified Claim Is Not Matched", func(t *testing.T) {
+		provider.claims = map[string]interface{}{"email": "budi@kampus.ac.id"}
+		_, status, err := service.CompleteLogin(ctx, authReq, "valid-code", authReq.State, models.ClientInfo{})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, status)
+		mockRepo.AssertNotCalled(t, "FindUserByEmail", mock.Anything, "budi@kampus.ac.id")
+		mockRepo.AssertNotCalled(t, "FindUserByUsernameOrEmail", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Verified Email Matches Email Column Only", func(t *testing.T) {
+		provider.claims = map[string]interface{}{"email": "Dosen@Kampus.ac.id", "email_verified": true}
+
+		resp, status, err := service.CompleteLogin(ctx, authReq, "valid-code", authReq.State, models.ClientInfo{})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, lecturer.ID.String(), resp.Data.User.ID)
+	})
+}
+
+func TestOIDCLoginGuardAndInactive(t *testing.T) {
+	ctx := context.Background()
+	provider := newMockOIDCProvider(t)
+	client := models.ClientInfo{IPAddress: "10.0.0.9", UserAgent: "test"}
+	student := &models.User{ID: uuid.New(), Username: "2110511001", IsActive: true, Role: "Mahasiswa"}
+	inactive := &models.User{ID: uuid.New(), Username: "2110511002", IsActive: false, Role: "Mahasiswa"}
+
+	mockRepo := new(MockUserRepo)
+	mockRepo.On("FindUserByStudentNumber", mock.Anything, "2110511001").Return(student, nil)
+	mockRepo.On("FindUserByStudentNumber", mock.Anything, "2110511002").Return(inactive, nil)
+
+	guard := newTestLoginGuard()
+	service := services.NewOIDCService(testOIDCConfig(provider.server.URL), mockRepo, new(MockRoleRepo), new(MockProfileRepo), newNoMFAService(mockRepo), guard)
+	authReq, _, err := service.BeginLogin(ctx)
+	assert.NoError(t, err)
+	provider.nonce = authReq.Nonce
+
+	t.Run("Inactive Account Rejected", func(t *testing.T) {
+		provider.claims = map[string]interface{}{"nim": "2110511002"}
+		resp, status, err := service.CompleteLogin(ctx, authReq, "valid-code", authReq.State, client)
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+
+	t.Run("Locked Account Rejected", func(t *testing.T) {
+		for i := 0; i < testLoginGuardConfig().LockoutThreshold; i++ {
+			guard.RecordFailure(ctx, student.Username, student, client, "invalid_password")
+		}
+		provider.claims = map[string]interface{}{"nim": "2110511001"}
+		resp, status, err := service.CompleteLogin(ctx, authReq, "valid-code", authReq.State, client)
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Equal(t, http.StatusLocked, status)
+	})
+}
+
+func TestOIDCAutoProvisionStudent(t *testing.T) {
+	ctx := context.Background()
+	provider := newMockOIDCProvider(t)
+	roleID := uuid.New()
+
+	mockRepo := new(MockUserRepo)
+	mockRepo.On("FindUserByStudentNumber", mock.Anything, "2110511099").Return(nil, errors.New("user not found"))
+	mockRepo.On("FindUserByEmail", mock.Anything, "siti@kampus.ac.id").Return(nil, errors.New("user not found"))
+	mockRepo.On("CreateUser", mock.Anything, mock.MatchedBy(func(u *models.User) bool {
+		return u.Username == "2110511099" && u.Email == "siti@kampus.ac.id" && u.RoleID == roleID && u.FullName == "Siti Aminah"
+	})).Return(&models.User{ID: uuid.New(), Username: "2110511099", IsActive: true, Role: "Mahasiswa"}, nil)
+
+	mockRole := new(MockRoleRepo)
+	mockRole.On("GetRoleByName", mock.Anything, "Mahasiswa").Return(&models.Role{ID: roleID, Name: "Mahasiswa"}, nil)
+	mockProfile := new(MockProfileRepo)
+	mockProfile.On("UpsertStudent", mock.Anything, mock.MatchedBy(func(s *models.Student) bool {
+		return s.StudentID == "2110511099"
+	})).Return(&models.Student{}, nil)
+
+	cfg := testOIDCConfig(provider.server.URL)
+	service := services.NewOIDCService(cfg, mockRepo, mockRole, mockProfile, newNoMFAService(mockRepo), newTestLoginGuard())
+	authReq, _, err := service.BeginLogin(ctx)
+	assert.NoError(t, err)
+	provider.nonce = authReq.Nonce
+	provider.claims = map[string]interface{}{"nim": "2110511099", "email": "Siti@Kampus.ac.id", "name": "Siti Aminah"}
+
+	t.Run("Disabled By Default", func(t *testing.T) {
+		_, status, err := service.CompleteLogin(ctx, authReq, "valid-code", authReq.State, models.ClientInfo{})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+
+	t.Run("Provisions Mahasiswa", func(t *testing.T) {
+		cfg.AutoProvisionStudents = true
+		service := services.NewOIDCService(cfg, mockRepo, mockRole, mockProfile, newNoMFAService(mockRepo), newTestLoginGuard())
+		authReq, _, _ := service.BeginLogin(ctx)
+		provider.nonce = authReq.Nonce
+
+		resp, status, err := service.CompleteLogin(ctx, authReq, "valid-code", authReq.State, models.ClientInfo{})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "Mahasiswa", resp.Data.User.Role)
+		mockProfile.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Code", func(t *testing.T) {
+		_, status, err := service.CompleteLogin(ctx, authReq, "bad-code", authReq.State, models.ClientInfo{})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+}
+
+func TestOIDCLoginRequiresMFA(t *testing.T) {
+	ctx := context.Background()
+	provider := newMockOIDCProvider(t)
+	admin := &models.User{ID: uuid.New(), Username: "198001012005011001", IsActive: true, Role: "Admin"}
+
+	mockRepo := new(MockUserRepo)
+	mockRepo.On("FindUserByLecturerNumber", mock.Anything, "198001012005011001").Return(admin, nil)
+	mockMFA := new(MockMFARepo)
+	mockMFA.On("GetByUserID", mock.Anything, admin.ID).Return(&models.UserMFA{UserID: admin.ID, Enabled: true}, nil)
+
+	service := services.NewOIDCService(testOIDCConfig(provider.server.URL), mockRepo, new(MockRoleRepo), new(MockProfileRepo),
+		services.NewMFAService(mockMFA, mockRepo, "Test"), newTestLoginGuard())
+	authReq, _, err := service.BeginLogin(ctx)
+	assert.NoError(t, err)
+	provider.nonce = authReq.Nonce
+
+	t.Run("Enrolled User Gets Challenge", func(t *testing.T) {
+		provider.claims = map[string]interface{}{"nip": "198001012005011001", "amr": []string{"pwd"}}
+		resp, status, err := service.CompleteLogin(ctx, authReq, "valid-code", authReq.State, models.ClientInfo{})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.True(t, resp.Data.MFARequired)
+		assert.Empty(t, resp.Data.Token)
+		assert.NotEmpty(t, resp.Data.ChallengeToken)
+	})
+
+	t.Run("Required Role Must Enroll", func(t *testing.T) {
+		admin := &models.User{ID: admin.ID, Username: admin.Username, IsActive: true, Role: "Admin",
+			Permissions: []string{models.PermissionMFARequired}}
+		mockRepo := new(MockUserRepo)
+		mockRepo.On("FindUserByLecturerNumber", mock.Anything, "198001012005011001").Return(admin, nil)
+		mockMFA := new(MockMFARepo)
+		mockMFA.On("GetByUserID", mock.Anything, admin.ID).Return(nil, nil)
+		service := services.NewOIDCService(testOIDCConfig(provider.server.URL), mockRepo, new(MockRoleRepo), new(MockProfileRepo),
+			services.NewMFAService(mockMFA, mockRepo, "Test"), newTestLoginGuard())
+		authReq, _, _ := service.BeginLogin(ctx)
+		provider.nonce = authReq.Nonce
+		provider.claims = map[string]interface{}{"nip": "198001012005011001"}
+
+		resp, status, err := service.CompleteLogin(ctx, authReq, "valid-code", authReq.State, models.ClientInfo{})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.True(t, resp.Data.MFAEnrollmentRequired)
+		assert.Empty(t, resp.Data.Token)
+	})
+
+	t.Run("MFA By IdP Skips Challenge", func(t *testing.T) {
+		for _, claims := range []map[string]interface{}{
+			{"nip": "198001012005011001", "amr": []string{"pwd", "otp"}},
+			{"nip": "198001012005011001", "acr": "urn:kampus:loa:2"},
+		} {
+			authReq, _, _ := service.BeginLogin(ctx)
+			provider.nonce = authReq.Nonce
+			provider.claims = claims
+
+			resp, status, err := service.CompleteLogin(ctx, authReq, "valid-code", authReq.State, models.ClientInfo{})
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, status)
+			assert.False(t, resp.Data.MFARequired)
+			assert.NotEmpty(t, resp.Data.Token)
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- MOCK REPOSITORY PASSWORD RESET ---
+type MockResetRepo struct {
+	mock.Mock
+}
+
+func (m *MockResetRepo) CreateToken(ctx context.Context, t *models.PasswordResetToken) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *MockResetRepo) FindUnusedTokenByHash(ctx context.Context, hash string) (*models.PasswordResetToken, error) {
+	args := m.Called(ctx, hash)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.PasswordResetToken), args.Error(1)
+}
+
+func (m *MockResetRepo) MarkTokenUsed(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockResetRepo) InvalidateUserTokens(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestChangePassword(t *testing.T) {
+	mockRepo := new(MockUserRepo)
+	service := services.NewAuthService(mockRepo, new(MockResetRepo), new(MockProfileRepo), newTestLoginGuard(), newNoMFAService(mockRepo), services.NewLocalAuthenticator())
+
+	userID := uuid.New()
+	hash, _ := utils.HashPassword("Rahasia123")
+	mockRepo.On("GetUserByID", mock.Anything, userID).Return(&models.User{ID: userID, PasswordHash: hash, IsActive: true}, nil)
+
+	t.Run("Wrong Current Password", func(t *testing.T) {
+		status, err := service.ChangePassword(context.Background(), userID, &models.ChangePasswordRequest{CurrentPassword: "salah", NewPassword: "PasswordBaru1"})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("Weak New Password", func(t *testing.T) {
+		status, err := service.ChangePassword(context.Background(), userID, &models.ChangePasswordRequest{CurrentPassword: "Rahasia123", NewPassword: "lemah"})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.On("UpdatePassword", mock.Anything, userID, mock.AnythingOfType("string")).Return(nil).Once()
+
+		status, err := service.ChangePassword(context.Background(), userID, &models.ChangePasswordRequest{CurrentPassword: "Rahasia123", NewPassword: "PasswordBaru1"})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		mockRepo.AssertCalled(t, "UpdatePassword", mock.Anything, userID, mock.AnythingOfType("string"))
+	})
+}
+
+func TestResetPasswordWithToken(t *testing.T) {
+	mockRepo := new(MockUserRepo)
+	mockReset := new(MockResetRepo)
+	service := services.NewAuthService(mockRepo, mockReset, new(MockProfileRepo), newTestLoginGuard(), newNoMFAService(mockRepo), services.NewLocalAuthenticator())
+
+	t.Run("Expired Token", func(t *testing.T) {
+		mockReset.On("FindUnusedTokenByHash", mock.Anything, utils.HashToken("expired")).Return(&models.PasswordResetToken{
+			ID: uuid.New(), UserID: uuid.New(), ExpiresAt: time.Now().Add(-time.Minute),
+		}, nil)
+
+		status, err := service.ResetPassword(context.Background(), &models.ResetPasswordRequest{Token: "expired", NewPassword: "PasswordBaru1"})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("Token Already Used", func(t *testing.T) {
+		mockReset.On("FindUnusedTokenByHash", mock.Anything, utils.HashToken("used")).Return(nil, errors.New("reset token not found"))
+
+		status, err := service.ResetPassword(context.Background(), &models.ResetPasswordRequest{Token: "used", NewPassword: "PasswordBaru1"})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		tokenID := uuid.New()
+		userID := uuid.New()
+		mockReset.On("FindUnusedTokenByHash", mock.Anything, utils.HashToken("valid")).Return(&models.PasswordResetToken{
+			ID: tokenID, UserID: userID, ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		mockReset.On("MarkTokenUsed", mock.Anything, tokenID).Return(nil)
+		mockReset.On("InvalidateUserTokens", mock.Anything, userID).Return(nil)
+		mockRepo.On("UpdatePassword", mock.Anything, userID, mock.AnythingOfType("string")).Return(nil)
+
+		status, err := service.ResetPassword(context.Background(), &models.ResetPasswordRequest{Token: "valid", NewPassword: "PasswordBaru1"})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		mockReset.AssertCalled(t, "MarkTokenUsed", mock.Anything, tokenID)
+	})
+}
+
+func TestValidateSessionRevoked(t *testing.T) {
+	mockRepo := new(MockUserRepo)
+	service := services.NewAuthService(mockRepo, new(MockResetRepo), new(MockProfileRepo), newTestLoginGuard(), newNoMFAService(mockRepo), services.NewLocalAuthenticator())
+
+	userID := uuid.New()
+	now := time.Now()
+	mockRepo.On("GetUserByID", mock.Anything, userID).Return(&models.User{ID: userID, IsActive: true, SessionVersion: 2}, nil)
+
+	oldToken := &utils.JWTCustomClaims{UserID: userID, SessionVersion: 1, RegisteredClaims: jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(now)}}
+	assert.Error(t, service.ValidateSession(context.Background(), oldToken))
+
+	// Token yang terbit sesaat setelah ganti password (detik yang sama) tetap berlaku
+	newToken := &utils.JWTCustomClaims{UserID: userID, SessionVersion: 2, RegisteredClaims: jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(now.Add(-time.Hour))}}
+	assert.NoError(t, service.ValidateSession(context.Background(), newToken))
+}
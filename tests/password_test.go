@@ -21,4 +21,13 @@ func TestPasswordHashing(t *testing.T) {
 	// Test Wrong Password
 	noMatch := utils.CheckPasswordHash("salah", hash)
 	assert.False(t, noMatch)
-}
\ No newline at end of file
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	assert.NoError(t, utils.ValidatePasswordPolicy("Rahasia123"))
+
+	assert.Error(t, utils.ValidatePasswordPolicy("Rhs12"))       // terlalu pendek
+	assert.Error(t, utils.ValidatePasswordPolicy("rahasia123"))  // tanpa huruf besar
+	assert.Error(t, utils.ValidatePasswordPolicy("RAHASIA123"))  // tanpa huruf kecil
+	assert.Error(t, utils.ValidatePasswordPolicy("RahasiaAja"))  // tanpa angka
+}
@@ -0,0 +1,186 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/repositories"
+	"prestasi-mahasiswa-api/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockProfileChangeRepo struct {
+	mock.Mock
+}
+
+func (m *MockProfileChangeRepo) Create(ctx context.Context, req *models.ProfileChangeRequest) error {
+	return m.Called(ctx, req).Error(0)
+}
+
+func (m *MockProfileChangeRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.ProfileChangeRequest, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.ProfileChangeRequest), args.Error(1)
+}
+
+func (m *MockProfileChangeRepo) List(ctx context.Context, filter models.ProfileChangeFilter) ([]models.ProfileChangeRequest, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]models.ProfileChangeRequest), args.Error(1)
+}
+
+func (m *MockProfileChangeRepo) HasPending(ctx context.Context, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockProfileChangeRepo) Reject(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID, note string) error {
+	return m.Called(ctx, id, reviewerID, note).Error(0)
+}
+
+func (m *MockProfileChangeRepo) ApproveStudentChange(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID, note string, student *models.Student) error {
+	return m.Called(ctx, id, reviewerID, note, student).Error(0)
+}
+
+func (m *MockProfileChangeRepo) ApproveLecturerChange(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID, note string, lecturer *models.Lecturer) error {
+	return m.Called(ctx, id, reviewerID, note, lecturer).Error(0)
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestUpdateOwnProfile(t *testing.T) {
+	userID := uuid.New()
+	student := &models.StudentProfileDetail{Student: models.Student{UserID: userID, StudentID: "2110511001", ProgramStudy: "Informatika", AcademicYear: "2021"}}
+
+	t.Run("Name Applied Directly And NIM Submitted For Approval", func(t *testing.T) {
+		mockChange, mockUser, mockProfile := new(MockProfileChangeRepo), new(MockUserRepo), new(MockProfileRepo)
+		service := services.NewProfileChangeService(mockChange, mockUser, mockProfile, new(MockAcademicUnitRepo), nil)
+
+		mockUser.On("GetUserByID", mock.Anything, userID).Return(&models.User{ID: userID, FullName: "Budi S"}, nil)
+		mockProfile.On("GetStudentProfileByUserID", mock.Anything, userID).Return(student, nil)
+		mockProfile.On("GetLecturerProfileByUserID", mock.Anything, userID).Return(nil, nil)
+		mockUser.On("FindDuplicateFields", mock.Anything, models.UserUniqueFields{StudentID: "2110511099"}, userID).Return([]string{}, nil)
+		mockChange.On("HasPending", mock.Anything, userID).Return(false, nil)
+		mockUser.On("UpdateUser", mock.Anything, userID, mock.MatchedBy(func(r *models.UpdateUserRequest) bool {
+			return *r.FullName == "Budi S" && r.Email == nil
+		}), (*uuid.UUID)(nil)).Return(&models.User{ID: userID}, nil)
+		mockChange.On("Create", mock.Anything, mock.MatchedBy(func(c *models.ProfileChangeRequest) bool {
+			return c.ProfileType == models.ProfileTypeStudent &&
+				assert.ObjectsAreEqual(map[string]string{models.ProfileFieldStudentID: "2110511099"}, c.Changes) &&
+				c.Previous[models.ProfileFieldStudentID] == "2110511001"
+		})).Return(nil)
+
+		// Angkatan tidak berubah sehingga tidak ikut diajukan
+		res, status, err := service.UpdateOwnProfile(context.Background(), userID, &models.SelfProfileUpdateRequest{
+			FullName: strPtr(" Budi S "), StudentID: strPtr("2110511099"), AcademicYear: strPtr("2021"), Reason: "salah input",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.NotNil(t, res.ChangeRequest)
+		assert.Equal(t, "Budi S", res.Profile.FullName)
+	})
+
+	t.Run("Existing Pending Request Blocks New Submission", func(t *testing.T) {
+		mockChange, mockUser, mockProfile := new(MockProfileChangeRepo), new(MockUserRepo), new(MockProfileRepo)
+		service := services.NewProfileChangeService(mockChange, mockUser, mockProfile, new(MockAcademicUnitRepo), nil)
+
+		mockUser.On("GetUserByID", mock.Anything, userID).Return(&models.User{ID: userID}, nil)
+		mockProfile.On("GetStudentProfileByUserID", mock.Anything, userID).Return(student, nil)
+		mockUser.On("FindDuplicateFields", mock.Anything, mock.Anything, userID).Return([]string{}, nil)
+		mockChange.On("HasPending", mock.Anything, userID).Return(true, nil)
+
+		_, status, err := service.UpdateOwnProfile(context.Background(), userID, &models.SelfProfileUpdateRequest{
+			FullName: strPtr("Budi"), StudentID: strPtr("2110511099"),
+		})
+		assert.ErrorIs(t, err, repositories.ErrPendingChangeExists)
+		assert.Equal(t, http.StatusConflict, status)
+		mockUser.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Lecturer Fields Require Lecturer Profile", func(t *testing.T) {
+		mockChange, mockUser, mockProfile := new(MockProfileChangeRepo), new(MockUserRepo), new(MockProfileRepo)
+		service := services.NewProfileChangeService(mockChange, mockUser, mockProfile, new(MockAcademicUnitRepo), nil)
+
+		mockUser.On("GetUserByID", mock.Anything, userID).Return(&models.User{ID: userID}, nil)
+		mockProfile.On("GetLecturerProfileByUserID", mock.Anything, userID).Return(nil, nil)
+
+		_, status, err := service.UpdateOwnProfile(context.Background(), userID, &models.SelfProfileUpdateRequest{LecturerID: strPtr("1980")})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, status)
+		mockChange.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestReviewProfileChange(t *testing.T) {
+	adminID, userID, requestID := uuid.New(), uuid.New(), uuid.New()
+	programID := uuid.New()
+	pending := func() *models.ProfileChangeRequest {
+		return &models.ProfileChangeRequest{
+			ID: requestID, UserID: userID, ProfileType: models.ProfileTypeStudent, Status: models.ProfileChangePending,
+			Changes: map[string]string{models.ProfileFieldStudentID: "2110511099", models.ProfileFieldStudyProgramID: programID.String()},
+		}
+	}
+
+	t.Run("Approve Applies Merged Student Profile", func(t *testing.T) {
+		mockChange, mockUser, mockProfile, mockUnit := new(MockProfileChangeRepo), new(MockUserRepo), new(MockProfileRepo), new(MockAcademicUnitRepo)
+		service := services.NewProfileChangeService(mockChange, mockUser, mockProfile, mockUnit, nil)
+
+		approved := pending()
+		approved.Status = models.ProfileChangeApproved
+		mockChange.On("GetByID", mock.Anything, requestID).Return(pending(), nil).Once()
+		mockChange.On("GetByID", mock.Anything, requestID).Return(approved, nil).Once()
+		mockProfile.On("GetStudentProfileByUserID", mock.Anything, userID).Return(&models.StudentProfileDetail{
+			Student: models.Student{UserID: userID, StudentID: "2110511001", ProgramStudy: "Sistem Informasi", AcademicYear: "2021"},
+		}, nil)
+		mockUser.On("FindDuplicateFields", mock.Anything, models.UserUniqueFields{StudentID: "2110511099"}, userID).Return([]string{}, nil)
+		mockUnit.On("GetStudyProgramByID", mock.Anything, programID).Return(&models.StudyProgram{ID: programID, Name: "Informatika"}, nil)
+		mockChange.On("ApproveStudentChange", mock.Anything, requestID, adminID, "ok", mock.MatchedBy(func(s *models.Student) bool {
+			return s.StudentID == "2110511099" && s.ProgramStudy == "Informatika" && *s.StudyProgramID == programID && s.AcademicYear == "2021"
+		})).Return(nil)
+
+		res, status, err := service.Approve(context.Background(), adminID, requestID, &models.ReviewProfileChangeRequest{Note: " ok "})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, models.ProfileChangeApproved, res.Status)
+	})
+
+	t.Run("Approve Conflicts When NIM Taken Meanwhile", func(t *testing.T) {
+		mockChange, mockUser, mockProfile := new(MockProfileChangeRepo), new(MockUserRepo), new(MockProfileRepo)
+		service := services.NewProfileChangeService(mockChange, mockUser, mockProfile, new(MockAcademicUnitRepo), nil)
+
+		mockChange.On("GetByID", mock.Anything, requestID).Return(pending(), nil)
+		mockProfile.On("GetStudentProfileByUserID", mock.Anything, userID).Return(&models.StudentProfileDetail{Student: models.Student{UserID: userID}}, nil)
+		mockUser.On("FindDuplicateFields", mock.Anything, mock.Anything, userID).Return([]string{"studentId"}, nil)
+
+		_, status, err := service.Approve(context.Background(), adminID, requestID, &models.ReviewProfileChangeRequest{})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusConflict, status)
+		mockChange.AssertNotCalled(t, "ApproveStudentChange", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Reject Already Reviewed Request", func(t *testing.T) {
+		mockChange := new(MockProfileChangeRepo)
+		service := services.NewProfileChangeService(mockChange, new(MockUserRepo), new(MockProfileRepo), new(MockAcademicUnitRepo), nil)
+
+		reviewed := pending()
+		reviewed.Status = models.ProfileChangeRejected
+		mockChange.On("GetByID", mock.Anything, requestID).Return(reviewed, nil)
+
+		_, status, err := service.Reject(context.Background(), adminID, requestID, &models.ReviewProfileChangeRequest{Note: "data tidak valid"})
+		assert.ErrorIs(t, err, repositories.ErrChangeRequestNotPending)
+		assert.Equal(t, http.StatusConflict, status)
+	})
+
+	t.Run("Reject Requires Note", func(t *testing.T) {
+		service := services.NewProfileChangeService(new(MockProfileChangeRepo), new(MockUserRepo), new(MockProfileRepo), new(MockAcademicUnitRepo), nil)
+
+		_, status, err := service.Reject(context.Background(), adminID, requestID, &models.ReviewProfileChangeRequest{Note: " "})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+}
@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListAdvisees(t *testing.T) {
+	lecturerUserID := uuid.New()
+
+	t.Run("Lecturer Sees Own Advisees", func(t *testing.T) {
+		mockProfile := new(MockProfileRepo)
+		service := services.NewProfileService(mockProfile)
+		claims := &utils.JWTCustomClaims{UserID: lecturerUserID, Role: "Dosen Wali"}
+
+		mockProfile.On("GetLecturerProfileByUserID", mock.Anything, lecturerUserID).Return(&models.LecturerProfileDetail{AdviseeCount: 1}, nil)
+		mockProfile.On("ListAdviseesByLecturerUserID", mock.Anything, lecturerUserID).Return([]models.AdviseeSummary{{StudentID: "2110511001"}}, nil)
+
+		advisees, status, err := service.ListAdvisees(context.Background(), claims, lecturerUserID)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Len(t, advisees, 1)
+	})
+
+	t.Run("Other Lecturer Forbidden", func(t *testing.T) {
+		mockProfile := new(MockProfileRepo)
+		service := services.NewProfileService(mockProfile)
+		claims := &utils.JWTCustomClaims{UserID: uuid.New(), Role: "Dosen Wali"}
+
+		_, status, err := service.ListAdvisees(context.Background(), claims, lecturerUserID)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, status)
+		mockProfile.AssertNotCalled(t, "ListAdviseesByLecturerUserID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Admin Gets 404 For Non Lecturer", func(t *testing.T) {
+		mockProfile := new(MockProfileRepo)
+		service := services.NewProfileService(mockProfile)
+		claims := &utils.JWTCustomClaims{UserID: uuid.New(), Role: "Admin", Permissions: []string{services.PermissionUserManage}}
+
+		mockProfile.On("GetLecturerProfileByUserID", mock.Anything, lecturerUserID).Return(nil, nil)
+
+		_, status, err := service.ListAdvisees(context.Background(), claims, lecturerUserID)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+}
+
+func TestGetStudentProfileNotFound(t *testing.T) {
+	mockProfile := new(MockProfileRepo)
+	service := services.NewProfileService(mockProfile)
+	userID := uuid.New()
+
+	mockProfile.On("GetStudentProfileByUserID", mock.Anything, userID).Return(nil, nil)
+
+	_, status, err := service.GetStudentProfile(context.Background(), userID)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestAuthProfileIncludesAdvisor(t *testing.T) {
+	mockRepo := new(MockUserRepo)
+	mockProfile := new(MockProfileRepo)
+	service := services.NewAuthService(mockRepo, new(MockResetRepo), mockProfile, newTestLoginGuard(), newNoMFAService(mockRepo), services.NewLocalAuthenticator())
+	userID, advisorUserID := uuid.New(), uuid.New()
+
+	mockRepo.On("GetUserByID", mock.Anything, userID).Return(&models.User{ID: userID, Username: "budi", Role: "Mahasiswa"}, nil)
+	mockProfile.On("GetStudentProfileByUserID", mock.Anything, userID).Return(&models.StudentProfileDetail{
+		Student: models.Student{UserID: userID, StudentID: "2110511001"},
+		Advisor: &models.AdvisorSummary{UserID: advisorUserID, FullName: "Dr. Sari"},
+	}, nil)
+	mockProfile.On("GetLecturerProfileByUserID", mock.Anything, userID).Return(nil, nil)
+
+	profile, status, err := service.GetProfile(context.Background(), userID)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "2110511001", profile.Student.StudentID)
+	assert.Equal(t, "Dr. Sari", profile.Student.Advisor.FullName)
+	assert.Nil(t, profile.Lecturer)
+}
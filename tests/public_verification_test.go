@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"prestasi-mahasiswa-api/middleware"
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRevokeVerification(t *testing.T) {
+	refID := uuid.New()
+	adminID := uuid.New()
+	claims := &utils.JWTCustomClaims{UserID: adminID, Role: "Admin", Permissions: []string{models.PermissionAchievementVerifyAll}}
+
+	t.Run("Verified Achievement Revoked", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		service := services.NewAchievementService(mockRepo, new(MockUserRepoForService), services.NewAccessPolicy(new(MockUserRepoForService)), nil)
+		mockRepo.On("GetReferenceByID", mock.Anything, refID).Return(&models.AchievementReference{ID: refID, Status: "verified"}, nil)
+		mockRepo.On("RevokeVerification", mock.Anything, refID, adminID, "sertifikat palsu").
+			Return(&models.AchievementReference{ID: refID, Status: "rejected"}, nil)
+
+		ref, status, err := service.RevokeVerification(context.Background(), claims, refID, " sertifikat palsu ")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "rejected", ref.Status)
+	})
+
+	t.Run("Only Verified Achievements", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		service := services.NewAchievementService(mockRepo, new(MockUserRepoForService), services.NewAccessPolicy(new(MockUserRepoForService)), nil)
+		mockRepo.On("GetReferenceByID", mock.Anything, refID).Return(&models.AchievementReference{ID: refID, Status: "submitted"}, nil)
+
+		_, status, err := service.RevokeVerification(context.Background(), claims, refID, "salah input")
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusConflict, status)
+		mockRepo.AssertNotCalled(t, "RevokeVerification", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Reason Required", func(t *testing.T) {
+		service := services.NewAchievementService(new(MockAchieveRepo), new(MockUserRepoForService), services.NewAccessPolicy(new(MockUserRepoForService)), nil)
+
+		_, status, err := service.RevokeVerification(context.Background(), claims, refID, "  ")
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
+func TestVerifyPublicCode(t *testing.T) {
+	verifiedAt := time.Date(2025, time.March, 12, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Valid Code", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		service := services.NewAchievementService(mockRepo, new(MockUserRepoForService), nil, nil)
+		mockRepo.On("GetPublicVerification", mock.Anything, "a1b2c3").Return(&models.AchievementVerification{
+			Valid: true, Status: models.VerificationValid, StudentName: "Budi", Title: "Olimpiade", AchievementType: "competition",
+			VerifiedAt: &verifiedAt, VerifierRole: "Dosen Wali",
+		}, nil)
+
+		res, status, err := service.VerifyPublicCode(context.Background(), "a1b2c3")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.True(t, res.Valid)
+		assert.Equal(t, "Dosen Wali", res.VerifierRole)
+	})
+
+	t.Run("Unknown Or Hard Deleted Code", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		service := services.NewAchievementService(mockRepo, new(MockUserRepoForService), nil, nil)
+		mockRepo.On("GetPublicVerification", mock.Anything, "hilang").Return(nil, nil)
+
+		res, status, err := service.VerifyPublicCode(context.Background(), "hilang")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.False(t, res.Valid)
+		assert.Equal(t, models.VerificationNotFound, res.Status)
+	})
+
+	t.Run("Oversized Code Skips Lookup", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		service := services.NewAchievementService(mockRepo, new(MockUserRepoForService), nil, nil)
+
+		res, _, err := service.VerifyPublicCode(context.Background(), strings.Repeat("a", 65))
+		assert.NoError(t, err)
+		assert.Equal(t, models.VerificationNotFound, res.Status)
+		mockRepo.AssertNotCalled(t, "GetPublicVerification", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPublicRateLimit(t *testing.T) {
+	app := fiber.New()
+	app.Get("/public/ping", middleware.RateLimit(2, time.Minute), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	for i := 0; i < 2; i++ {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/public/ping", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/public/ping", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+}
@@ -0,0 +1,245 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSemesterOf(t *testing.T) {
+	cases := []struct {
+		date     time.Time
+		expected string
+	}{
+		{time.Date(2024, time.August, 1, 0, 0, 0, 0, time.UTC), "2024/2025 Ganjil"},
+		{time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC), "2024/2025 Ganjil"},
+		{time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), "2024/2025 Genap"},
+		{time.Date(2025, time.July, 31, 0, 0, 0, 0, time.UTC), "2024/2025 Genap"},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, utils.SemesterOf(tc.date).Label())
+	}
+
+	odd, even := utils.SemesterOf(cases[0].date), utils.SemesterOf(cases[2].date)
+	assert.True(t, odd.Before(even))
+	assert.False(t, even.Before(odd))
+	assert.True(t, even.Before(utils.SemesterOf(time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC))))
+}
+
+func TestStudentReport(t *testing.T) {
+	studentID := uuid.New()
+	day := func(y int, m time.Month) time.Time { return time.Date(y, m, 10, 0, 0, 0, 0, time.UTC) }
+
+	t.Run("Student Sees Own Report", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		mockProfile := new(MockProfileRepo)
+		service := services.NewReportService(mockRepo, mockProfile, services.NewAccessPolicy(new(MockUserRepoForService)), nil)
+		claims := &utils.JWTCustomClaims{UserID: studentID, Role: "Mahasiswa", Permissions: []string{models.PermissionAchievementReadOwn}}
+
+		mockProfile.On("GetStudentProfileByUserID", mock.Anything, studentID).Return(&models.StudentProfileDetail{
+			Student:  models.Student{UserID: studentID, StudentID: "2110511001", ProgramStudy: "Informatika", AcademicYear: "2021"},
+			FullName: "Budi",
+		}, nil)
+		mockRepo.On("ListAchievementRecords", mock.Anything, models.AchievementScope{StudentIDs: []uuid.UUID{studentID}}).Return([]models.AchievementRecord{
+			{Title: "Hackathon", AchievementType: "competition", Status: "verified", Points: 50, CreatedAt: day(2024, time.October)},
+			{Title: "Seminar", AchievementType: "academic", Status: "submitted", Points: 10, CreatedAt: day(2025, time.January)},
+			{Title: "Olimpiade", AchievementType: "competition", Status: "verified", Points: 80, CreatedAt: day(2025, time.March)},
+		}, nil)
+
+		report, status, err := service.GetStudentReport(context.Background(), claims, studentID)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "2110511001", report.StudentID)
+		assert.Equal(t, 130, report.TotalPoints)
+		assert.Equal(t, 3, report.TotalAchievements)
+		assert.Equal(t, map[string]int{"verified": 2, "submitted": 1}, report.ByStatus)
+		assert.Equal(t, map[string]int{"competition": 2, "academic": 1}, report.ByType)
+		assert.Equal(t, []models.SemesterStats{
+			{Semester: "2024/2025 Ganjil", AcademicYear: "2024/2025", Term: "Ganjil", TotalAchievements: 2, VerifiedAchievements: 1, VerifiedPoints: 50},
+			{Semester: "2024/2025 Genap", AcademicYear: "2024/2025", Term: "Genap", TotalAchievements: 1, VerifiedAchievements: 1, VerifiedPoints: 80},
+		}, report.Timeline)
+		assert.Len(t, report.TopAchievements, 2)
+		assert.Equal(t, "Olimpiade", report.TopAchievements[0].Title)
+	})
+
+	t.Run("Timeline Uses Submission And Verification Dates", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		mockProfile := new(MockProfileRepo)
+		service := services.NewReportService(mockRepo, mockProfile, services.NewAccessPolicy(new(MockUserRepoForService)), nil)
+		claims := &utils.JWTCustomClaims{UserID: studentID, Role: "Mahasiswa", Permissions: []string{models.PermissionAchievementReadOwn}}
+		at := func(y int, m time.Month) *time.Time { d := day(y, m); return &d }
+
+		mockProfile.On("GetStudentProfileByUserID", mock.Anything, studentID).Return(&models.StudentProfileDetail{
+			Student: models.Student{UserID: studentID, StudentID: "2110511001"},
+		}, nil)
+		mockRepo.On("ListAchievementRecords", mock.Anything, models.AchievementScope{StudentIDs: []uuid.UUID{studentID}}).Return([]models.AchievementRecord{
+			// Diajukan di semester ganjil, diverifikasi di semester genap
+			{Title: "Hackathon", Status: "verified", Points: 50, CreatedAt: day(2024, time.November), SubmittedAt: at(2025, time.January), VerifiedAt: at(2025, time.March)},
+			// Draft dibuat di semester ganjil, baru diajukan di semester genap
+			{Title: "Seminar", Status: "submitted", Points: 10, CreatedAt: day(2025, time.January), SubmittedAt: at(2025, time.April)},
+			{Title: "Lomba", Status: "draft", Points: 5, CreatedAt: day(2024, time.December)},
+		}, nil)
+
+		report, status, err := service.GetStudentReport(context.Background(), claims, studentID)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, []models.SemesterStats{
+			{Semester: "2024/2025 Ganjil", AcademicYear: "2024/2025", Term: "Ganjil", TotalAchievements: 2},
+			{Semester: "2024/2025 Genap", AcademicYear: "2024/2025", Term: "Genap", TotalAchievements: 1, VerifiedAchievements: 1, VerifiedPoints: 50},
+		}, report.Timeline)
+	})
+
+	t.Run("Other Student Forbidden", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		mockProfile := new(MockProfileRepo)
+		service := services.NewReportService(mockRepo, mockProfile, services.NewAccessPolicy(new(MockUserRepoForService)), nil)
+		claims := &utils.JWTCustomClaims{UserID: uuid.New(), Role: "Mahasiswa", Permissions: []string{models.PermissionAchievementReadOwn}}
+
+		_, status, err := service.GetStudentReport(context.Background(), claims, studentID)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, status)
+		mockRepo.AssertNotCalled(t, "ListAchievementRecords", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Admin Gets 404 Without Student Profile", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		mockProfile := new(MockProfileRepo)
+		service := services.NewReportService(mockRepo, mockProfile, services.NewAccessPolicy(new(MockUserRepoForService)), nil)
+		claims := &utils.JWTCustomClaims{UserID: uuid.New(), Role: "Admin", Permissions: []string{models.PermissionAchievementReadAll}}
+
+		mockProfile.On("GetStudentProfileByUserID", mock.Anything, studentID).Return(nil, nil)
+
+		_, status, err := service.GetStudentReport(context.Background(), claims, studentID)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+}
+
+func TestTrends(t *testing.T) {
+	adminClaims := &utils.JWTCustomClaims{UserID: uuid.New(), Role: "Admin", Permissions: []string{models.PermissionAchievementReadAll}}
+	month := func(m time.Month) time.Time { return time.Date(2025, m, 1, 0, 0, 0, 0, time.UTC) }
+
+	t.Run("Monthly With Gap Filling And Growth", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		service := services.NewReportService(mockRepo, new(MockProfileRepo), services.NewAccessPolicy(new(MockUserRepoForService)), nil)
+		expectedQuery := models.TrendQuery{Interval: models.TrendIntervalMonth, GroupBy: models.TrendGroupType, DateField: models.TrendDateSubmitted}
+
+		mockRepo.On("ListTrendRows", mock.Anything, models.AchievementScope{All: true}, expectedQuery).Return([]models.TrendRow{
+			{PeriodStart: month(time.January), Status: "verified", AchievementType: "competition", Count: 1, Points: 40},
+			{PeriodStart: month(time.January), Status: "submitted", AchievementType: "academic", Count: 1, Points: 10},
+			{PeriodStart: month(time.March), Status: "verified", AchievementType: "competition", Count: 1, Points: 30},
+			{PeriodStart: month(time.April), Status: "verified", AchievementType: "competition", Count: 1, Points: 60},
+		}, nil)
+
+		report, status, err := service.GetTrends(context.Background(), adminClaims, models.TrendQuery{GroupBy: models.TrendGroupType})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Len(t, report.Periods, 4)
+
+		jan, feb, mar, apr := report.Periods[0], report.Periods[1], report.Periods[2], report.Periods[3]
+		assert.Equal(t, "2025-01", jan.Period)
+		assert.Equal(t, 2, jan.Count)
+		assert.Equal(t, 40, jan.Points) // poin hanya dari prestasi terverifikasi
+		assert.Nil(t, jan.CountGrowth)
+		assert.Equal(t, models.TrendValue{Count: 1, Points: 40}, jan.Series["competition"])
+
+		assert.Equal(t, "2025-02", feb.Period)
+		assert.Equal(t, -100.0, *feb.CountGrowth)
+		assert.Nil(t, mar.CountGrowth) // periode sebelumnya kosong
+		assert.Equal(t, 100.0, *apr.PointsGrowth)
+	})
+
+	t.Run("Semester Labels", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		service := services.NewReportService(mockRepo, new(MockProfileRepo), services.NewAccessPolicy(new(MockUserRepoForService)), nil)
+
+		mockRepo.On("ListTrendRows", mock.Anything, mock.Anything, mock.Anything).Return([]models.TrendRow{
+			{PeriodStart: time.Date(2024, time.August, 1, 0, 0, 0, 0, time.UTC), Status: "verified", Count: 1, Points: 10},
+			{PeriodStart: time.Date(2025, time.August, 1, 0, 0, 0, 0, time.UTC), Status: "verified", Count: 1, Points: 30},
+		}, nil)
+
+		report, _, err := service.GetTrends(context.Background(), adminClaims, models.TrendQuery{Interval: models.TrendIntervalSemester})
+		assert.NoError(t, err)
+		labels := []string{}
+		for _, p := range report.Periods {
+			labels = append(labels, p.Period)
+		}
+		assert.Equal(t, []string{"2024/2025 Ganjil", "2024/2025 Genap", "2025/2026 Ganjil"}, labels)
+	})
+
+	t.Run("Invalid Interval Rejected", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		service := services.NewReportService(mockRepo, new(MockProfileRepo), services.NewAccessPolicy(new(MockUserRepoForService)), nil)
+
+		_, status, err := service.GetTrends(context.Background(), adminClaims, models.TrendQuery{Interval: "week"})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, status)
+		mockRepo.AssertNotCalled(t, "ListTrendRows", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestLeaderboard(t *testing.T) {
+	lecturerID, budi, sari, andi, dewi := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	entries := func() []models.LeaderboardEntry {
+		return []models.LeaderboardEntry{
+			{UserID: &budi, StudentID: "2110511001", FullName: "Budi Santoso", TotalPoints: 80, VerifiedAchievements: 2},
+			{UserID: &sari, StudentID: "2110511002", FullName: "Sari", TotalPoints: 120, VerifiedAchievements: 3},
+			{UserID: &andi, StudentID: "2110511003", FullName: "Andi", TotalPoints: 80, VerifiedAchievements: 4},
+			{UserID: &dewi, StudentID: "2110511004", FullName: "Dewi", TotalPoints: 50, VerifiedAchievements: 1},
+		}
+	}
+
+	t.Run("Ranks With Ties And Anonymizes Outside Scope", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		mockUser := new(MockUserRepoForService)
+		service := services.NewReportService(mockRepo, new(MockProfileRepo), services.NewAccessPolicy(mockUser), nil)
+		claims := &utils.JWTCustomClaims{UserID: lecturerID, Role: "Dosen Wali", Permissions: []string{models.PermissionAchievementReadAdvisees}}
+
+		mockUser.On("GetAdviseeStudentUserIDsByAdvisorUserID", mock.Anything, lecturerID).Return([]uuid.UUID{budi}, nil)
+		mockRepo.On("ListLeaderboardEntries", mock.Anything, mock.Anything).Return(entries(), nil)
+
+		res, status, err := service.GetLeaderboard(context.Background(), claims, models.LeaderboardQuery{})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+
+		ranks := []int{}
+		for _, e := range res.Items {
+			ranks = append(ranks, e.Rank)
+		}
+		assert.Equal(t, []int{1, 2, 2, 4}, ranks)
+		assert.Equal(t, "A***", res.Items[1].FullName) // Andi: lebih banyak prestasi, tampil lebih dulu
+		assert.Equal(t, "2110****03", res.Items[1].StudentID)
+		assert.Nil(t, res.Items[1].UserID)
+		assert.Equal(t, "Budi Santoso", res.Items[2].FullName) // mahasiswa bimbingan tetap terlihat
+		assert.False(t, res.Items[2].Anonymized)
+	})
+
+	t.Run("Pagination And Forced Anonymization Keeps Self Visible", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		service := services.NewReportService(mockRepo, new(MockProfileRepo), services.NewAccessPolicy(new(MockUserRepoForService)), nil)
+		claims := &utils.JWTCustomClaims{UserID: andi, Role: "Mahasiswa", Permissions: []string{models.PermissionAchievementReadOwn}}
+
+		mockRepo.On("ListLeaderboardEntries", mock.Anything, mock.MatchedBy(func(q models.LeaderboardQuery) bool {
+			return q.AchievementType == "competition" && q.Limit == 2
+		})).Return(entries(), nil)
+
+		res, _, err := service.GetLeaderboard(context.Background(), claims, models.LeaderboardQuery{
+			AchievementType: " competition ", Anonymize: true, Page: 1, Limit: 2,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, models.Pagination{Page: 1, Limit: 2, Total: 4, TotalPages: 2}, res.Pagination)
+		assert.Len(t, res.Items, 2)
+		assert.True(t, res.Items[0].Anonymized)
+		assert.True(t, res.Items[1].IsCurrentUser)
+		assert.Equal(t, "Andi", res.Items[1].FullName)
+	})
+}
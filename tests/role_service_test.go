@@ -0,0 +1,210 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRoleRepo
+type MockRoleRepo struct {
+	mock.Mock
+}
+
+func (m *MockRoleRepo) GetRoleByName(ctx context.Context, name string) (*models.Role, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.Role), args.Error(1)
+}
+
+func (m *MockRoleRepo) GetRoleByID(ctx context.Context, id uuid.UUID) (*models.Role, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.Role), args.Error(1)
+}
+
+func (m *MockRoleRepo) GetAllRoles(ctx context.Context) ([]models.Role, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]models.Role), args.Error(1)
+}
+
+func (m *MockRoleRepo) CreateRole(ctx context.Context, role *models.Role) error {
+	return m.Called(ctx, role).Error(0)
+}
+
+func (m *MockRoleRepo) UpdateRole(ctx context.Context, role *models.Role) error {
+	return m.Called(ctx, role).Error(0)
+}
+
+func (m *MockRoleRepo) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *MockRoleRepo) CountUsersWithRole(ctx context.Context, id uuid.UUID) (int, error) {
+	args := m.Called(ctx, id)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRoleRepo) SetRolePermissions(ctx context.Context, id uuid.UUID, permissionIDs []uuid.UUID) error {
+	return m.Called(ctx, id, permissionIDs).Error(0)
+}
+
+func (m *MockRoleRepo) AddRolePermission(ctx context.Context, id uuid.UUID, permissionID uuid.UUID) error {
+	return m.Called(ctx, id, permissionID).Error(0)
+}
+
+func (m *MockRoleRepo) RemoveRolePermission(ctx context.Context, id uuid.UUID, permissionID uuid.UUID) error {
+	return m.Called(ctx, id, permissionID).Error(0)
+}
+
+// MockPermissionRepo
+type MockPermissionRepo struct {
+	mock.Mock
+}
+
+func (m *MockPermissionRepo) ListPermissions(ctx context.Context) ([]models.Permission, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.Permission), args.Error(1)
+}
+
+func (m *MockPermissionRepo) GetPermissionByID(ctx context.Context, id uuid.UUID) (*models.Permission, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.Permission), args.Error(1)
+}
+
+func (m *MockPermissionRepo) GetPermissionsByNames(ctx context.Context, names []string) ([]models.Permission, error) {
+	args := m.Called(ctx, names)
+	return args.Get(0).([]models.Permission), args.Error(1)
+}
+
+func (m *MockPermissionRepo) CreatePermission(ctx context.Context, p *models.Permission) error {
+	return m.Called(ctx, p).Error(0)
+}
+
+func (m *MockPermissionRepo) UpdatePermission(ctx context.Context, p *models.Permission) error {
+	return m.Called(ctx, p).Error(0)
+}
+
+func (m *MockPermissionRepo) DeletePermission(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func TestDeleteRole(t *testing.T) {
+	ctx := context.Background()
+	mockRole := new(MockRoleRepo)
+	service := services.NewRoleService(mockRole, new(MockPermissionRepo))
+
+	builtIn := &models.Role{ID: uuid.New(), Name: "Mahasiswa"}
+	inUse := &models.Role{ID: uuid.New(), Name: "Kaprodi"}
+	unused := &models.Role{ID: uuid.New(), Name: "Tamu"}
+	mockRole.On("GetRoleByID", mock.Anything, builtIn.ID).Return(builtIn, nil)
+	mockRole.On("GetRoleByID", mock.Anything, inUse.ID).Return(inUse, nil)
+	mockRole.On("GetRoleByID", mock.Anything, unused.ID).Return(unused, nil)
+	mockRole.On("GetRoleByID", mock.Anything, mock.Anything).Return(nil, nil)
+
+	t.Run("Not Found", func(t *testing.T) {
+		status, err := service.DeleteRole(ctx, uuid.New())
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+
+	t.Run("Built-in Role", func(t *testing.T) {
+		status, err := service.DeleteRole(ctx, builtIn.ID)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+
+	t.Run("Role In Use", func(t *testing.T) {
+		mockRole.On("CountUsersWithRole", mock.Anything, inUse.ID).Return(3, nil)
+		status, err := service.DeleteRole(ctx, inUse.ID)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusConflict, status)
+		mockRole.AssertNotCalled(t, "DeleteRole", mock.Anything, inUse.ID)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockRole.On("CountUsersWithRole", mock.Anything, unused.ID).Return(0, nil)
+		mockRole.On("DeleteRole", mock.Anything, unused.ID).Return(nil)
+		status, err := service.DeleteRole(ctx, unused.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+	})
+}
+
+func TestSetRolePermissions(t *testing.T) {
+	ctx := context.Background()
+	mockRole := new(MockRoleRepo)
+	mockPerm := new(MockPermissionRepo)
+	service := services.NewRoleService(mockRole, mockPerm)
+
+	admin := &models.Role{ID: uuid.New(), Name: "Admin"}
+	lecturer := &models.Role{ID: uuid.New(), Name: "Dosen Wali"}
+	mockRole.On("GetRoleByID", mock.Anything, admin.ID).Return(admin, nil)
+	mockRole.On("GetRoleByID", mock.Anything, lecturer.ID).Return(lecturer, nil)
+
+	verify := models.Permission{ID: uuid.New(), Name: "achievement:verify"}
+
+	t.Run("Unknown Permission", func(t *testing.T) {
+		mockPerm.On("GetPermissionsByNames", mock.Anything, []string{"achievement:verify", "nope:x"}).Return([]models.Permission{verify}, nil).Once()
+		_, status, err := service.SetRolePermissions(ctx, lecturer.ID, &models.RolePermissionsRequest{Permissions: []string{"achievement:verify", "nope:x"}})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "nope:x")
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("Admin Cannot Lose Management Permissions", func(t *testing.T) {
+		mockPerm.On("GetPermissionsByNames", mock.Anything, []string{"achievement:verify"}).Return([]models.Permission{verify}, nil).Once()
+		_, status, err := service.SetRolePermissions(ctx, admin.ID, &models.RolePermissionsRequest{Permissions: []string{"achievement:verify"}})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+
+	t.Run("Success Deduplicates Names", func(t *testing.T) {
+		mockPerm.On("GetPermissionsByNames", mock.Anything, []string{"achievement:verify"}).Return([]models.Permission{verify}, nil).Once()
+		mockRole.On("SetRolePermissions", mock.Anything, lecturer.ID, []uuid.UUID{verify.ID}).Return(nil).Once()
+		_, status, err := service.SetRolePermissions(ctx, lecturer.ID, &models.RolePermissionsRequest{Permissions: []string{"achievement:verify", " achievement:verify"}})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+	})
+}
+
+func TestCreatePermissionValidation(t *testing.T) {
+	mockPerm := new(MockPermissionRepo)
+	service := services.NewRoleService(new(MockRoleRepo), mockPerm)
+
+	_, status, err := service.CreatePermission(context.Background(), &models.PermissionRequest{Name: "Achievement Verify"})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	mockPerm.On("GetPermissionsByNames", mock.Anything, []string{"report:export"}).Return([]models.Permission{}, nil)
+	mockPerm.On("CreatePermission", mock.Anything, mock.Anything).Return(nil)
+	permission, status, err := service.CreatePermission(context.Background(), &models.PermissionRequest{Name: "report:export"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "report", permission.Resource)
+	assert.Equal(t, "export", permission.Action)
+}
+
+func TestValidateSessionRefreshesPermissions(t *testing.T) {
+	mockRepo := new(MockUserRepo)
+	userID := uuid.New()
+	mockRepo.On("GetUserByID", mock.Anything, userID).Return(&models.User{
+		ID: userID, IsActive: true, Role: "Dosen Wali", Permissions: []string{"achievement:read"},
+	}, nil)
+	service := services.NewAuthService(mockRepo, new(MockResetRepo), new(MockProfileRepo), newTestLoginGuard(), newNoMFAService(mockRepo), services.NewLocalAuthenticator())
+
+	// Token lama masih membawa permission yang sudah dicabut
+	claims := &utils.JWTCustomClaims{UserID: userID, Role: "Dosen Wali", Permissions: []string{"achievement:read", "achievement:verify"}}
+	assert.NoError(t, service.ValidateSession(context.Background(), claims))
+	assert.Equal(t, []string{"achievement:read"}, claims.Permissions)
+}
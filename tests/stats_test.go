@@ -0,0 +1,396 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/repositories"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock Stats Repository ---
+type MockStatsRepo struct{ mock.Mock }
+
+func (m *MockStatsRepo) QueryStats(ctx context.Context, scope models.AchievementScope, filter models.StatsFilter) ([]models.StatsAggregateRow, error) {
+	args := m.Called(ctx, scope, filter)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]models.StatsAggregateRow), args.Error(1)
+}
+func (m *MockStatsRepo) RefreshStudent(ctx context.Context, studentID uuid.UUID) error {
+	return m.Called(ctx, studentID).Error(0)
+}
+func (m *MockStatsRepo) RebuildAll(ctx context.Context) error { return m.Called(ctx).Error(0) }
+func (m *MockStatsRepo) ListAggregates(ctx context.Context, scope models.AchievementScope) ([]models.StatsAggregateRow, error) {
+	args := m.Called(ctx, scope)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]models.StatsAggregateRow), args.Error(1)
+}
+
+func newTestStatsAggregator(repo *MockStatsRepo) services.StatsAggregator {
+	return services.NewStatsAggregator(repo, repositories.NewInMemoryStatsCache(), services.StatsConfig{CacheTTL: time.Minute})
+}
+
+// --- Mock Stats Maintainer ---
+type MockStatsMaintainer struct{ mock.Mock }
+
+func (m *MockStatsMaintainer) StudentChanged(ctx context.Context, studentID uuid.UUID) {
+	m.Called(ctx, studentID)
+}
+
+func TestStatsCacheBackends(t *testing.T) {
+	mr := miniredis.RunT(t)
+	backends := map[string]func() repositories.StatsCache{
+		"Memory": repositories.NewInMemoryStatsCache,
+		"Redis": func() repositories.StatsCache {
+			mr.FlushAll()
+			return repositories.NewRedisStatsCache(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "test:stats")
+		},
+	}
+
+	for name, newCache := range backends {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			cache := newCache()
+			calls := 0
+			compute := func() ([]byte, error) { calls++; return []byte("v" + string(rune('0'+calls))), nil }
+
+			value, err := cache.GetOrCompute(ctx, "dashboard:all", time.Minute, compute)
+			assert.NoError(t, err)
+			assert.Equal(t, "v1", string(value))
+
+			value, _ = cache.GetOrCompute(ctx, "dashboard:all", time.Minute, compute)
+			assert.Equal(t, "v1", string(value))
+			assert.Equal(t, 1, calls)
+
+			assert.NoError(t, cache.Invalidate(ctx))
+			value, _ = cache.GetOrCompute(ctx, "dashboard:all", time.Minute, compute)
+			assert.Equal(t, "v2", string(value))
+
+			// Hasil yang dihitung sebelum invalidasi tidak boleh tersimpan
+			assert.NoError(t, cache.Invalidate(ctx))
+			value, _ = cache.GetOrCompute(ctx, "dashboard:all", time.Minute, func() ([]byte, error) {
+				_ = cache.Invalidate(ctx)
+				return []byte("stale"), nil
+			})
+			assert.Equal(t, "stale", string(value))
+			value, _ = cache.GetOrCompute(ctx, "dashboard:all", time.Minute, compute)
+			assert.Equal(t, "v3", string(value))
+
+			_, err = cache.GetOrCompute(ctx, "dashboard:other", time.Minute, func() ([]byte, error) { return nil, errors.New("db down") })
+			assert.EqualError(t, err, "db down")
+		})
+	}
+
+	t.Run("Redis TTL", func(t *testing.T) {
+		mr.FlushAll()
+		cache := repositories.NewRedisStatsCache(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "test:stats")
+		calls := 0
+		compute := func() ([]byte, error) { calls++; return []byte("x"), nil }
+
+		_, _ = cache.GetOrCompute(context.Background(), "k", time.Minute, compute)
+		mr.FastForward(2 * time.Minute)
+		_, _ = cache.GetOrCompute(context.Background(), "k", time.Minute, compute)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("Redis Unavailable Still Computes", func(t *testing.T) {
+		down := miniredis.RunT(t)
+		cache := repositories.NewRedisStatsCache(redis.NewClient(&redis.Options{Addr: down.Addr(), MaxRetries: -1}), "test:stats")
+		down.Close()
+
+		value, err := cache.GetOrCompute(context.Background(), "k", time.Minute, func() ([]byte, error) { return []byte("live"), nil })
+		assert.Error(t, err)
+		assert.Equal(t, "live", string(value))
+	})
+}
+
+func TestStatsAggregator(t *testing.T) {
+	studentA, studentB := uuid.New(), uuid.New()
+	rows := []models.StatsAggregateRow{
+		{StudentID: studentA, ProgramStudy: "Informatika", Status: "verified", AchievementType: "competition", Total: 2, Points: 130},
+		{StudentID: studentA, ProgramStudy: "Informatika", Status: "draft", AchievementType: "academic", Total: 1},
+		{StudentID: studentB, ProgramStudy: "Sistem Informasi", Status: "verified", AchievementType: "competition", Total: 1, Points: 50},
+		{StudentID: studentB, ProgramStudy: "Sistem Informasi", Status: "submitted", AchievementType: "", Total: 1},
+	}
+	config := services.StatsConfig{CacheTTL: time.Minute}
+
+	t.Run("Rolls Up And Caches", func(t *testing.T) {
+		mockRepo := new(MockStatsRepo)
+		aggregator := services.NewStatsAggregator(mockRepo, repositories.NewInMemoryStatsCache(), config)
+		mockRepo.On("ListAggregates", mock.Anything, models.AchievementScope{All: true}).Return(rows, nil).Once()
+
+		stats, err := aggregator.DashboardStats(context.Background(), models.AchievementScope{All: true})
+		assert.NoError(t, err)
+		assert.Equal(t, 5, stats.TotalAchievements)
+		assert.Equal(t, map[string]int{"verified": 3, "draft": 1, "submitted": 1}, stats.ByStatus)
+		assert.Equal(t, map[string]int{"competition": 3, "academic": 1, models.StatsUnknownType: 1}, stats.ByType)
+		assert.Equal(t, map[string]int{"Informatika": 3, "Sistem Informasi": 2}, stats.ByProgramStudy)
+		assert.False(t, stats.AsOf.IsZero())
+
+		cached, err := aggregator.DashboardStats(context.Background(), models.AchievementScope{All: true})
+		assert.NoError(t, err)
+		assert.True(t, stats.AsOf.Equal(cached.AsOf))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Scopes Cached Separately", func(t *testing.T) {
+		mockRepo := new(MockStatsRepo)
+		aggregator := services.NewStatsAggregator(mockRepo, repositories.NewInMemoryStatsCache(), config)
+		mockRepo.On("ListAggregates", mock.Anything, models.AchievementScope{All: true}).Return(rows, nil).Once()
+		mockRepo.On("ListAggregates", mock.Anything, models.AchievementScope{StudentIDs: []uuid.UUID{studentA}}).Return(rows[:2], nil).Once()
+
+		all, _ := aggregator.DashboardStats(context.Background(), models.AchievementScope{All: true})
+		own, _ := aggregator.DashboardStats(context.Background(), models.AchievementScope{StudentIDs: []uuid.UUID{studentA}})
+		assert.Equal(t, 5, all.TotalAchievements)
+		assert.Equal(t, 3, own.TotalAchievements)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Student Change Refreshes And Invalidates", func(t *testing.T) {
+		mockRepo := new(MockStatsRepo)
+		aggregator := services.NewStatsAggregator(mockRepo, repositories.NewInMemoryStatsCache(), config)
+		mockRepo.On("ListAggregates", mock.Anything, models.AchievementScope{All: true}).Return(rows, nil).Twice()
+		mockRepo.On("RefreshStudent", mock.Anything, studentA).Return(errors.New("mongo timeout"))
+
+		_, _ = aggregator.DashboardStats(context.Background(), models.AchievementScope{All: true})
+		// Refresh gagal tetap membuang cache; drift dibereskan rebuild berkala
+		aggregator.StudentChanged(context.Background(), studentA)
+		_, _ = aggregator.DashboardStats(context.Background(), models.AchievementScope{All: true})
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Rebuild Error", func(t *testing.T) {
+		mockRepo := new(MockStatsRepo)
+		aggregator := services.NewStatsAggregator(mockRepo, repositories.NewInMemoryStatsCache(), config)
+		mockRepo.On("RebuildAll", mock.Anything).Return(errors.New("lock timeout"))
+
+		assert.Error(t, aggregator.Rebuild(context.Background()))
+	})
+}
+
+func TestDashboardStatsFilters(t *testing.T) {
+	programID := uuid.New()
+	claims := &utils.JWTCustomClaims{UserID: uuid.New(), Role: "Admin", Permissions: []string{models.PermissionAchievementReadAll}}
+	newService := func(mockStats *MockStatsRepo) services.ReportService {
+		return services.NewReportService(new(MockAchieveRepo), new(MockProfileRepo), services.NewAccessPolicy(new(MockUserRepoForService)), newTestStatsAggregator(mockStats))
+	}
+
+	t.Run("Unfiltered Uses Materialized Aggregates", func(t *testing.T) {
+		mockStats := new(MockStatsRepo)
+		mockStats.On("ListAggregates", mock.Anything, models.AchievementScope{All: true}).Return([]models.StatsAggregateRow{
+			{StudentID: uuid.New(), Status: "verified", AchievementType: "competition", Total: 4},
+		}, nil)
+
+		stats, status, err := newService(mockStats).GetDashboardStats(context.Background(), claims, models.StatsFilter{})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, 4, stats.TotalAchievements)
+		mockStats.AssertNotCalled(t, "QueryStats", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Filtered Queries Source", func(t *testing.T) {
+		mockStats := new(MockStatsRepo)
+		from := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+		filter := models.StatsFilter{Statuses: []string{"verified"}, From: &from, StudyProgramID: &programID}
+		mockStats.On("QueryStats", mock.Anything, models.AchievementScope{All: true}, filter).Return([]models.StatsAggregateRow{
+			{StudentID: uuid.New(), ProgramStudy: "Informatika", Status: "verified", AchievementType: "competition", Total: 2},
+		}, nil)
+
+		stats, status, err := newService(mockStats).GetDashboardStats(context.Background(), claims, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, map[string]int{"verified": 2}, stats.ByStatus)
+		assert.Equal(t, map[string]int{"Informatika": 2}, stats.ByProgramStudy)
+		mockStats.AssertNotCalled(t, "ListAggregates", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid Filters", func(t *testing.T) {
+		from := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+		for _, filter := range []models.StatsFilter{
+			{Statuses: []string{"archived"}},
+			{From: &from, To: &to},
+		} {
+			mockStats := new(MockStatsRepo)
+			_, status, err := newService(mockStats).GetDashboardStats(context.Background(), claims, filter)
+			assert.Error(t, err)
+			assert.Equal(t, http.StatusBadRequest, status)
+			mockStats.AssertNotCalled(t, "QueryStats", mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
+}
+
+// Rekap per status, per jenis, dan per program studi harus selalu berjumlah sama dengan total,
+// dan jalur materialized maupun query langsung harus menghasilkan angka yang sama untuk data yang sama.
+func TestStatsBreakdownsCrossCheck(t *testing.T) {
+	studentA, studentB, studentC := uuid.New(), uuid.New(), uuid.New()
+	rows := []models.StatsAggregateRow{
+		{StudentID: studentA, ProgramStudy: "Informatika", Status: "draft", AchievementType: "competition", Total: 2},
+		{StudentID: studentA, ProgramStudy: "Informatika", Status: "verified", AchievementType: "competition", Total: 3, Points: 150},
+		{StudentID: studentA, ProgramStudy: "Informatika", Status: "verified", AchievementType: "publication", Total: 1, Points: 40},
+		// Dokumen MongoDB hilang: tetap masuk rekap status, jenis "unknown"
+		{StudentID: studentB, ProgramStudy: "Sistem Informasi", Status: "submitted", AchievementType: "", Total: 1},
+		{StudentID: studentB, ProgramStudy: "Sistem Informasi", Status: "rejected", AchievementType: "organization", Total: 2},
+		{StudentID: studentC, ProgramStudy: "Teknik Elektro", Status: "verified", AchievementType: "academic", Total: 5, Points: 90},
+	}
+	sum := func(counts map[string]int) int {
+		total := 0
+		for _, n := range counts {
+			total += n
+		}
+		return total
+	}
+	check := func(t *testing.T, stats *models.DashboardStats) {
+		assert.Equal(t, 14, stats.TotalAchievements)
+		assert.Equal(t, stats.TotalAchievements, sum(stats.ByStatus))
+		assert.Equal(t, stats.TotalAchievements, sum(stats.ByType))
+		assert.Equal(t, stats.TotalAchievements, sum(stats.ByProgramStudy))
+	}
+
+	mockStats := new(MockStatsRepo)
+	aggregator := newTestStatsAggregator(mockStats)
+	scope := models.AchievementScope{All: true}
+	mockStats.On("ListAggregates", mock.Anything, scope).Return(rows, nil)
+	mockStats.On("QueryStats", mock.Anything, scope, models.StatsFilter{}).Return(rows, nil)
+
+	materialized, err := aggregator.DashboardStats(context.Background(), scope)
+	assert.NoError(t, err)
+	check(t, materialized)
+
+	live, err := aggregator.QueryStats(context.Background(), scope, models.StatsFilter{})
+	assert.NoError(t, err)
+	check(t, live)
+
+	assert.Equal(t, materialized.ByStatus, live.ByStatus)
+	assert.Equal(t, materialized.ByType, live.ByType)
+	assert.Equal(t, materialized.ByProgramStudy, live.ByProgramStudy)
+	assert.Equal(t, 1, live.ByType[models.StatsUnknownType])
+}
+
+func TestWorkflowTransitionUpdatesStats(t *testing.T) {
+	refID := uuid.New()
+	studentID := uuid.New()
+	adminID := uuid.New()
+	claims := &utils.JWTCustomClaims{UserID: adminID, Role: "Admin", Permissions: []string{models.PermissionAchievementVerifyAll}}
+
+	t.Run("Verify", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		mockStats := new(MockStatsMaintainer)
+		service := services.NewAchievementService(mockRepo, new(MockUserRepoForService), nil, mockStats)
+		mockRepo.On("GetReferenceByID", mock.Anything, refID).Return(&models.AchievementReference{ID: refID, StudentID: studentID, Status: "submitted"}, nil)
+		mockRepo.On("UpdateReferenceStatus", mock.Anything, refID, "submitted", "verified", "", adminID).
+			Return(&models.AchievementReference{ID: refID, Status: "verified"}, nil)
+		mockStats.On("StudentChanged", mock.Anything, studentID).Return()
+
+		_, status, err := service.VerifyAchievement(context.Background(), claims, refID)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		mockStats.AssertExpectations(t)
+	})
+
+	t.Run("Failed Transition Leaves Stats", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		mockStats := new(MockStatsMaintainer)
+		service := services.NewAchievementService(mockRepo, new(MockUserRepoForService), nil, mockStats)
+		mockRepo.On("GetReferenceByID", mock.Anything, refID).Return(&models.AchievementReference{ID: refID, StudentID: studentID, Status: "draft"}, nil)
+
+		_, status, err := service.VerifyAchievement(context.Background(), claims, refID)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusConflict, status)
+		mockStats.AssertNotCalled(t, "StudentChanged", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Hard Delete Of Trashed Achievement", func(t *testing.T) {
+		mockRepo := new(MockAchieveRepo)
+		mockStats := new(MockStatsMaintainer)
+		service := services.NewAchievementService(mockRepo, new(MockUserRepoForService), nil, mockStats)
+		mockRepo.On("GetReferenceByID", mock.Anything, refID).Return(nil, errors.New("achievement reference not found"))
+		mockRepo.On("HardDeleteAchievement", mock.Anything, refID).Return([]models.AttachmentFile{}, nil)
+
+		status, err := service.HardDelete(context.Background(), refID)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		mockStats.AssertNotCalled(t, "StudentChanged", mock.Anything, mock.Anything)
+	})
+}
+
+func TestScopeChangesInvalidateStats(t *testing.T) {
+	all := models.AchievementScope{All: true}
+	rows := []models.StatsAggregateRow{{StudentID: uuid.New(), ProgramStudy: "Informatika", Status: "verified", AchievementType: "competition", Total: 1}}
+
+	t.Run("Advisor Reassignment", func(t *testing.T) {
+		mockStats := new(MockStatsRepo)
+		aggregator := newTestStatsAggregator(mockStats)
+		mockAdvisor := new(MockAdvisorRepo)
+		mockProfile := new(MockProfileRepo)
+		service := services.NewAdvisorService(mockAdvisor, mockProfile, new(MockAcademicUnitRepo), aggregator)
+		adminID, studentID, advisorUserID := uuid.New(), uuid.New(), uuid.New()
+		lecturer := &models.Lecturer{ID: uuid.New(), UserID: advisorUserID}
+
+		mockStats.On("ListAggregates", mock.Anything, all).Return(rows, nil).Twice()
+		mockProfile.On("GetLecturerByUserID", mock.Anything, advisorUserID).Return(lecturer, nil)
+		mockAdvisor.On("AssignAdvisor", mock.Anything, []uuid.UUID{studentID}, lecturer.ID, adminID, "").
+			Return(&models.AdvisorAssignmentResult{Matched: 1, Assigned: 1, NotFound: []uuid.UUID{}}, nil)
+
+		_, _ = aggregator.DashboardStats(context.Background(), all)
+		_, status, err := service.AssignAdvisor(context.Background(), adminID, studentID, &models.AssignAdvisorRequest{AdvisorUserID: advisorUserID.String()})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		_, _ = aggregator.DashboardStats(context.Background(), all)
+		mockStats.AssertExpectations(t)
+	})
+
+	t.Run("Study Program Update", func(t *testing.T) {
+		mockStats := new(MockStatsRepo)
+		aggregator := newTestStatsAggregator(mockStats)
+		mockUnit := new(MockAcademicUnitRepo)
+		service := services.NewAcademicUnitService(mockUnit, new(MockProfileRepo), aggregator)
+		programID, departmentID := uuid.New(), uuid.New()
+
+		mockStats.On("ListAggregates", mock.Anything, all).Return(rows, nil).Twice()
+		mockUnit.On("GetStudyProgramByID", mock.Anything, programID).Return(&models.StudyProgram{ID: programID, Name: "Informatika"}, nil)
+		mockUnit.On("GetStudyProgramByName", mock.Anything, "Teknik Informatika").Return(nil, nil)
+		mockUnit.On("GetDepartmentByID", mock.Anything, departmentID).Return(&models.Department{ID: departmentID, Name: "Teknik"}, nil)
+		mockUnit.On("UpdateStudyProgram", mock.Anything, mock.AnythingOfType("*models.StudyProgram")).Return(nil)
+
+		_, _ = aggregator.DashboardStats(context.Background(), all)
+		_, status, err := service.UpdateStudyProgram(context.Background(), programID, &models.StudyProgramRequest{
+			Name: "Teknik Informatika", DepartmentID: departmentID.String(),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		_, _ = aggregator.DashboardStats(context.Background(), all)
+		mockStats.AssertExpectations(t)
+	})
+
+	t.Run("User Import", func(t *testing.T) {
+		mockStats := new(MockStatsRepo)
+		aggregator := newTestStatsAggregator(mockStats)
+		f := newUserImportFixture(nil, nil)
+		service := f.newService(aggregator)
+		csv := importCSVHeader + "budi,budi@kampus.ac.id,Budi Santoso,Mahasiswa,2110511001,Informatika,2021/2022,\n"
+
+		mockStats.On("ListAggregates", mock.Anything, all).Return(rows, nil).Twice()
+		f.importRepo.On("ImportUsers", mock.Anything, mock.Anything, true).Return([]error{nil}, nil)
+		f.resetRepo.On("CreateToken", mock.Anything, mock.AnythingOfType("*models.PasswordResetToken")).Return(nil)
+
+		_, _ = aggregator.DashboardStats(context.Background(), all)
+		_, status, err := service.ImportUsers(context.Background(), uuid.New(), "mahasiswa.csv", strings.NewReader(csv), models.UserImportOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, status)
+		_, _ = aggregator.DashboardStats(context.Background(), all)
+		mockStats.AssertExpectations(t)
+	})
+}
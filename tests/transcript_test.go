@@ -0,0 +1,125 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTranscriptRepo struct {
+	mock.Mock
+}
+
+func (m *MockTranscriptRepo) Create(ctx context.Context, transcript *models.StudentTranscript) error {
+	return m.Called(ctx, transcript).Error(0)
+}
+
+func (m *MockTranscriptRepo) GetVerificationByCode(ctx context.Context, code string) (*models.TranscriptVerification, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.TranscriptVerification), args.Error(1)
+}
+
+func TestGenerateTranscript(t *testing.T) {
+	studentID := uuid.New()
+	config := services.TranscriptConfig{VerifyURL: "https://prestasi.example.ac.id/verify/"}
+
+	t.Run("Records Verified Achievements Only", func(t *testing.T) {
+		mockRepo, mockProfile, mockTranscript := new(MockAchieveRepo), new(MockProfileRepo), new(MockTranscriptRepo)
+		service := services.NewTranscriptService(mockRepo, mockProfile, mockTranscript, services.NewAccessPolicy(new(MockUserRepoForService)), config)
+		claims := &utils.JWTCustomClaims{UserID: studentID, Role: "Mahasiswa", Permissions: []string{models.PermissionAchievementReadOwn}}
+		verifiedAt := time.Date(2025, time.March, 12, 0, 0, 0, 0, time.UTC)
+
+		mockProfile.On("GetStudentProfileByUserID", mock.Anything, studentID).Return(&models.StudentProfileDetail{
+			Student:  models.Student{UserID: studentID, StudentID: "2110511001", ProgramStudy: "Informatika", AcademicYear: "2021"},
+			FullName: "Budi",
+		}, nil)
+		mockRepo.On("ListAchievementRecords", mock.Anything, models.AchievementScope{StudentIDs: []uuid.UUID{studentID}}).Return([]models.AchievementRecord{
+			{Title: "Olimpiade", AchievementType: "competition", Status: "verified", Points: 80, VerifiedAt: &verifiedAt, VerifierName: "Dr. Andi"},
+			{Title: "Jurnal", AchievementType: "publication", Status: "verified", Points: 40, VerifiedAt: &verifiedAt, VerifierName: "Dr. Andi"},
+			{Title: "Seminar", AchievementType: "academic", Status: "submitted", Points: 10},
+		}, nil)
+		var recorded *models.StudentTranscript
+		mockTranscript.On("Create", mock.Anything, mock.MatchedBy(func(tr *models.StudentTranscript) bool {
+			recorded = tr
+			return tr.StudentUserID == studentID && *tr.IssuedBy == studentID && tr.TotalAchievements == 2 && tr.TotalPoints == 120 && len(tr.Code) >= 20
+		})).Return(nil)
+
+		job, status, err := service.GenerateTranscript(context.Background(), claims, studentID)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "skpi-2110511001.pdf", job.FileName)
+		assert.Equal(t, "application/pdf", job.ContentType)
+
+		var buf bytes.Buffer
+		assert.NoError(t, job.Write(context.Background(), &buf))
+		assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF")))
+		assert.NotNil(t, recorded)
+	})
+
+	t.Run("Codes Are Unique Per Issuance", func(t *testing.T) {
+		mockRepo, mockProfile, mockTranscript := new(MockAchieveRepo), new(MockProfileRepo), new(MockTranscriptRepo)
+		service := services.NewTranscriptService(mockRepo, mockProfile, mockTranscript, services.NewAccessPolicy(new(MockUserRepoForService)), config)
+		claims := &utils.JWTCustomClaims{UserID: uuid.New(), Role: "Admin", Permissions: []string{models.PermissionAchievementReadAll}}
+
+		mockProfile.On("GetStudentProfileByUserID", mock.Anything, studentID).Return(&models.StudentProfileDetail{Student: models.Student{UserID: studentID}}, nil)
+		mockRepo.On("ListAchievementRecords", mock.Anything, mock.Anything).Return([]models.AchievementRecord{}, nil)
+		codes := map[string]bool{}
+		mockTranscript.On("Create", mock.Anything, mock.MatchedBy(func(tr *models.StudentTranscript) bool {
+			codes[tr.Code] = true
+			return true
+		})).Return(nil)
+
+		for i := 0; i < 3; i++ {
+			_, _, err := service.GenerateTranscript(context.Background(), claims, studentID)
+			assert.NoError(t, err)
+		}
+		assert.Len(t, codes, 3)
+	})
+
+	t.Run("Other Student Forbidden", func(t *testing.T) {
+		mockTranscript := new(MockTranscriptRepo)
+		service := services.NewTranscriptService(new(MockAchieveRepo), new(MockProfileRepo), mockTranscript, services.NewAccessPolicy(new(MockUserRepoForService)), config)
+		claims := &utils.JWTCustomClaims{UserID: uuid.New(), Role: "Mahasiswa", Permissions: []string{models.PermissionAchievementReadOwn}}
+
+		_, status, err := service.GenerateTranscript(context.Background(), claims, studentID)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, status)
+		mockTranscript.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestVerifyTranscript(t *testing.T) {
+	t.Run("Known Code Valid", func(t *testing.T) {
+		mockTranscript := new(MockTranscriptRepo)
+		service := services.NewTranscriptService(new(MockAchieveRepo), new(MockProfileRepo), mockTranscript, nil, services.TranscriptConfig{})
+		mockTranscript.On("GetVerificationByCode", mock.Anything, "abc123").Return(&models.TranscriptVerification{Valid: true, Code: "abc123", StudentName: "Budi"}, nil)
+
+		res, status, err := service.VerifyTranscript(context.Background(), " abc123 ")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.True(t, res.Valid)
+	})
+
+	t.Run("Unknown Code Not Valid", func(t *testing.T) {
+		mockTranscript := new(MockTranscriptRepo)
+		service := services.NewTranscriptService(new(MockAchieveRepo), new(MockProfileRepo), mockTranscript, nil, services.TranscriptConfig{})
+		mockTranscript.On("GetVerificationByCode", mock.Anything, "zzz").Return(nil, nil)
+
+		res, status, err := service.VerifyTranscript(context.Background(), "zzz")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.False(t, res.Valid)
+		assert.Empty(t, res.StudentName)
+	})
+}
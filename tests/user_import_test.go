@@ -0,0 +1,283 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/repositories"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/xuri/excelize/v2"
+)
+
+// MockUserImportRepo
+type MockUserImportRepo struct {
+	mock.Mock
+}
+
+func (m *MockUserImportRepo) FindExisting(ctx context.Context, usernames, emails, nims, nips []string) (*models.UserImportExisting, error) {
+	args := m.Called(ctx, usernames, emails, nims, nips)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*models.UserImportExisting), args.Error(1)
+}
+
+func (m *MockUserImportRepo) FindLecturerIDsByNIP(ctx context.Context, nips []string) (map[string]uuid.UUID, error) {
+	args := m.Called(ctx, nips)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(map[string]uuid.UUID), args.Error(1)
+}
+
+func (m *MockUserImportRepo) ImportUsers(ctx context.Context, records []models.UserImportRecord, atomic bool) ([]error, error) {
+	args := m.Called(ctx, records, atomic)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]error), args.Error(1)
+}
+
+const importCSVHeader = "username,email,full_name,role,nim_nip,program_study,academic_year,advisor_nip\n"
+
+type userImportFixture struct {
+	importRepo *MockUserImportRepo
+	roleRepo   *MockRoleRepo
+	unitRepo   *MockAcademicUnitRepo
+	resetRepo  *MockResetRepo
+	service    services.UserImportService
+	programID  uuid.UUID
+}
+
+func newUserImportFixture(existing *models.UserImportExisting, advisors map[string]uuid.UUID) *userImportFixture {
+	f := &userImportFixture{
+		importRepo: new(MockUserImportRepo),
+		roleRepo:   new(MockRoleRepo),
+		unitRepo:   new(MockAcademicUnitRepo),
+		resetRepo:  new(MockResetRepo),
+		programID:  uuid.New(),
+	}
+	if existing == nil {
+		existing = &models.UserImportExisting{Usernames: map[string]bool{}, Emails: map[string]bool{}, NIMs: map[string]bool{}, NIPs: map[string]bool{}}
+	}
+	if advisors == nil {
+		advisors = map[string]uuid.UUID{}
+	}
+	f.importRepo.On("FindExisting", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(existing, nil)
+	f.importRepo.On("FindLecturerIDsByNIP", mock.Anything, mock.Anything).Return(advisors, nil)
+	f.roleRepo.On("GetRoleByName", mock.Anything, "Mahasiswa").Return(&models.Role{ID: uuid.New(), Name: "Mahasiswa", Permissions: []string{models.PermissionAchievementReadOwn}}, nil).Maybe()
+	f.roleRepo.On("GetRoleByName", mock.Anything, "Dosen Wali").Return(&models.Role{ID: uuid.New(), Name: "Dosen Wali", Permissions: []string{models.PermissionAchievementReadAdvisees}}, nil).Maybe()
+	f.roleRepo.On("GetRoleByName", mock.Anything, "Peserta Didik").Return(&models.Role{ID: uuid.New(), Name: "Peserta Didik", Permissions: []string{models.PermissionAchievementReadOwn}}, nil).Maybe()
+	f.roleRepo.On("GetRoleByName", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	f.unitRepo.On("GetStudyProgramByName", mock.Anything, "Informatika").Return(&models.StudyProgram{ID: f.programID, Name: "Informatika"}, nil).Maybe()
+	f.unitRepo.On("GetStudyProgramByName", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+
+	f.service = f.newService(nil)
+	return f
+}
+
+func (f *userImportFixture) newService(stats services.StatsInvalidator) services.UserImportService {
+	cfg := services.UserImportConfig{MaxRows: 100, InviteURL: "https://prestasi.kampus.ac.id/reset-password", InviteTTL: 0}
+	return services.NewUserImportService(f.importRepo, f.roleRepo, f.unitRepo, f.resetRepo, cfg, stats)
+}
+
+func findImportRow(report *models.UserImportReport, row int) models.UserImportRowResult {
+	for _, r := range report.Rows {
+		if r.Row == row {
+			return r
+		}
+	}
+	return models.UserImportRowResult{}
+}
+
+func TestUserImportDryRun(t *testing.T) {
+	existing := &models.UserImportExisting{
+		Usernames: map[string]bool{"lama": true}, Emails: map[string]bool{}, NIMs: map[string]bool{}, NIPs: map[string]bool{},
+	}
+	f := newUserImportFixture(existing, nil)
+	csv := importCSVHeader +
+		"budi,budi@kampus.ac.id,Budi Santoso,Mahasiswa,2110511001,Informatika,2021/2022,\n" +
+		"BUDI,budi2@kampus.ac.id,Budi Lain,Mahasiswa,2110511002,Informatika,2021/2022,\n" +
+		"lama,lama@kampus.ac.id,User Lama,Mahasiswa,2110511003,Informatika,2021/2022,\n" +
+		"siti,bukan-email,Siti,Superuser,,,,\n" +
+		"\n" +
+		"andi,andi@kampus.ac.id,Andi,Mahasiswa,2110511004,Kedokteran,,\n"
+
+	report, status, err := f.service.ImportUsers(context.Background(), uuid.New(), "mahasiswa.csv", strings.NewReader(csv), models.UserImportOptions{DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 5, report.TotalRows)
+	assert.Equal(t, 1, report.ValidRows)
+	assert.Equal(t, 4, report.FailedRows)
+
+	assert.Equal(t, models.UserImportStatusValid, findImportRow(report, 2).Status)
+	assert.Contains(t, findImportRow(report, 3).Errors, "username BUDI duplicates row 2")
+	assert.Contains(t, findImportRow(report, 4).Errors, "username lama already exists")
+	assert.Contains(t, findImportRow(report, 5).Errors, "email bukan-email is not valid")
+	assert.Contains(t, findImportRow(report, 5).Errors, "role Superuser does not exist")
+	assert.Contains(t, findImportRow(report, 7).Errors, "program_study Kedokteran does not exist")
+	assert.Contains(t, findImportRow(report, 7).Errors, "academic_year is required for role Mahasiswa")
+	f.importRepo.AssertNotCalled(t, "ImportUsers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserImportTransactionalRejectsInvalidFile(t *testing.T) {
+	f := newUserImportFixture(nil, nil)
+	csv := importCSVHeader +
+		"budi,budi@kampus.ac.id,Budi Santoso,Mahasiswa,2110511001,Informatika,2021/2022,\n" +
+		"siti,siti@kampus.ac.id,Siti,Mahasiswa,2110511002,Informatika,2021/2022,99999\n"
+
+	report, status, err := f.service.ImportUsers(context.Background(), uuid.New(), "mahasiswa.csv", strings.NewReader(csv), models.UserImportOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, models.UserImportStatusSkipped, findImportRow(report, 2).Status)
+	assert.Contains(t, findImportRow(report, 3).Errors, "advisor with NIP 99999 not found")
+	f.importRepo.AssertNotCalled(t, "ImportUsers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserImportPartialWithInvites(t *testing.T) {
+	f := newUserImportFixture(nil, nil)
+	csv := importCSVHeader +
+		"budi,budi@kampus.ac.id,Budi Santoso,Mahasiswa,2110511001,Informatika,2021/2022,198001\n" +
+		"pak.andi,andi@kampus.ac.id,Andi Wijaya,Dosen Wali,198001,,,\n" +
+		"x,,,,,,,\n"
+
+	// Dosen di file yang sama disimpan lebih dulu dan menjadi dosen wali mahasiswa
+	f.importRepo.On("ImportUsers", mock.Anything, mock.MatchedBy(func(records []models.UserImportRecord) bool {
+		return len(records) == 2 && records[0].Lecturer != nil && records[1].Student != nil &&
+			*records[1].Student.AdvisorID == records[0].Lecturer.ID &&
+			*records[1].Student.StudyProgramID == f.programID &&
+			!utils.CheckPasswordHash("", records[0].User.PasswordHash)
+	}), false).Return([]error{nil, nil}, nil)
+	f.resetRepo.On("CreateToken", mock.Anything, mock.AnythingOfType("*models.PasswordResetToken")).Return(nil).Twice()
+
+	report, status, err := f.service.ImportUsers(context.Background(), uuid.New(), "batch.csv", strings.NewReader(csv), models.UserImportOptions{Mode: models.UserImportModePartial})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, 2, report.ImportedRows)
+	assert.Equal(t, 1, report.FailedRows)
+
+	budi := findImportRow(report, 2)
+	assert.Equal(t, models.UserImportStatusImported, budi.Status)
+	assert.True(t, strings.HasPrefix(budi.InviteLink, "https://prestasi.kampus.ac.id/reset-password?token="))
+	assert.Empty(t, budi.InitialPassword)
+	f.resetRepo.AssertExpectations(t)
+}
+
+func TestUserImportNormalisesEmail(t *testing.T) {
+	existing := &models.UserImportExisting{
+		Usernames: map[string]bool{}, Emails: map[string]bool{"lama@kampus.ac.id": true}, NIMs: map[string]bool{}, NIPs: map[string]bool{},
+	}
+	f := newUserImportFixture(existing, nil)
+	csv := importCSVHeader +
+		"budi,\" Budi@Kampus.AC.ID \",Budi Santoso,Mahasiswa,2110511001,Informatika,2021/2022,\n" +
+		"sari,budi@kampus.ac.id,Sari,Mahasiswa,2110511002,Informatika,2021/2022,\n" +
+		"lama,LAMA@kampus.ac.id,User Lama,Mahasiswa,2110511003,Informatika,2021/2022,\n"
+
+	var savedEmail string
+	f.importRepo.On("ImportUsers", mock.Anything, mock.Anything, false).Run(func(args mock.Arguments) {
+		savedEmail = args.Get(1).([]models.UserImportRecord)[0].User.Email
+	}).Return([]error{nil}, nil)
+	f.resetRepo.On("CreateToken", mock.Anything, mock.AnythingOfType("*models.PasswordResetToken")).Return(nil)
+
+	report, status, err := f.service.ImportUsers(context.Background(), uuid.New(), "mahasiswa.csv", strings.NewReader(csv), models.UserImportOptions{Mode: models.UserImportModePartial})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "budi@kampus.ac.id", savedEmail)
+	assert.Contains(t, findImportRow(report, 3).Errors, "email budi@kampus.ac.id duplicates row 2")
+	assert.Contains(t, findImportRow(report, 4).Errors, "email LAMA@kampus.ac.id already exists")
+}
+
+func TestUserImportTransactionalRollback(t *testing.T) {
+	f := newUserImportFixture(nil, nil)
+	csv := importCSVHeader +
+		"budi,budi@kampus.ac.id,Budi Santoso,Mahasiswa,2110511001,Informatika,2021/2022,\n" +
+		"siti,siti@kampus.ac.id,Siti,Mahasiswa,2110511002,Informatika,2021/2022,\n"
+
+	f.importRepo.On("ImportUsers", mock.Anything, mock.Anything, true).
+		Return([]error{nil, assert.AnError}, repositories.ErrImportRolledBack)
+
+	report, status, err := f.service.ImportUsers(context.Background(), uuid.New(), "mahasiswa.csv", strings.NewReader(csv),
+		models.UserImportOptions{Credential: models.UserImportCredentialPassword})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, 0, report.ImportedRows)
+
+	budi := findImportRow(report, 2)
+	assert.Equal(t, models.UserImportStatusSkipped, budi.Status)
+	assert.Empty(t, budi.InitialPassword, "password must not leak for rows that were rolled back")
+	assert.Equal(t, models.UserImportStatusFailed, findImportRow(report, 3).Status)
+}
+
+func TestUserImportXLSXWithGeneratedPasswords(t *testing.T) {
+	f := newUserImportFixture(nil, nil)
+
+	xlsx := excelize.NewFile()
+	sheet := xlsx.GetSheetName(0)
+	assert.NoError(t, xlsx.SetSheetRow(sheet, "A1", &[]string{"Username", "Email", "Full Name", "Role", "NIM", "Prodi", "Angkatan"}))
+	assert.NoError(t, xlsx.SetSheetRow(sheet, "A2", &[]interface{}{"budi", "budi@kampus.ac.id", "Budi Santoso", "Mahasiswa", "2110511001", "Informatika", "2021/2022"}))
+	var buf bytes.Buffer
+	assert.NoError(t, xlsx.Write(&buf))
+
+	var savedHash string
+	f.importRepo.On("ImportUsers", mock.Anything, mock.Anything, true).Run(func(args mock.Arguments) {
+		savedHash = args.Get(1).([]models.UserImportRecord)[0].User.PasswordHash
+	}).Return([]error{nil}, nil)
+
+	report, status, err := f.service.ImportUsers(context.Background(), uuid.New(), "mahasiswa.xlsx", &buf,
+		models.UserImportOptions{Credential: models.UserImportCredentialPassword})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, status)
+
+	budi := findImportRow(report, 2)
+	assert.NoError(t, utils.ValidatePasswordPolicy(budi.InitialPassword))
+	assert.True(t, utils.CheckPasswordHash(budi.InitialPassword, savedHash))
+	assert.Empty(t, budi.InviteLink)
+}
+
+func TestUserImportMissingColumns(t *testing.T) {
+	f := newUserImportFixture(nil, nil)
+
+	_, status, err := f.service.ImportUsers(context.Background(), uuid.New(), "x.csv", strings.NewReader("username,email\nbudi,budi@kampus.ac.id\n"), models.UserImportOptions{})
+	assert.EqualError(t, err, "missing required column(s): full_name, role")
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	_, status, err = f.service.ImportUsers(context.Background(), uuid.New(), "x.txt", strings.NewReader(""), models.UserImportOptions{})
+	assert.ErrorIs(t, err, utils.ErrUnsupportedSpreadsheet)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestUserImportProfileFromRolePermissions(t *testing.T) {
+	f := newUserImportFixture(nil, nil)
+	csv := importCSVHeader +
+		"rina,rina@kampus.ac.id,Rina,Peserta Didik,2110511005,Informatika,2023/2024,\n"
+
+	f.importRepo.On("ImportUsers", mock.Anything, mock.MatchedBy(func(records []models.UserImportRecord) bool {
+		return len(records) == 1 && records[0].Student != nil && records[0].Lecturer == nil &&
+			records[0].Student.StudentID == "2110511005"
+	}), true).Return([]error{nil}, nil)
+
+	_, status, err := f.service.ImportUsers(context.Background(), uuid.New(), "mahasiswa.csv", strings.NewReader(csv),
+		models.UserImportOptions{Credential: models.UserImportCredentialPassword})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, status)
+	f.importRepo.AssertExpectations(t)
+}
+
+func TestUserImportLowercasesUsername(t *testing.T) {
+	f := newUserImportFixture(nil, nil)
+	csv := importCSVHeader +
+		"Budi.S,budi@kampus.ac.id,Budi Santoso,Mahasiswa,2110511001,Informatika,2021/2022,\n"
+
+	f.importRepo.On("ImportUsers", mock.Anything, mock.MatchedBy(func(records []models.UserImportRecord) bool {
+		return len(records) == 1 && records[0].User.Username == "budi.s"
+	}), true).Return([]error{nil}, nil)
+
+	report, status, err := f.service.ImportUsers(context.Background(), uuid.New(), "mahasiswa.csv", strings.NewReader(csv),
+		models.UserImportOptions{Credential: models.UserImportCredentialPassword})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "budi.s", findImportRow(report, 2).Username)
+}
@@ -0,0 +1,152 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"prestasi-mahasiswa-api/models"
+	"prestasi-mahasiswa-api/repositories"
+	"prestasi-mahasiswa-api/services"
+	"prestasi-mahasiswa-api/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newUserServiceForTest(userRepo *MockUserRepo, roleRepo *MockRoleRepo) services.UserService {
+	return services.NewUserService(userRepo, roleRepo, new(MockProfileRepo), new(MockResetRepo), newTestLoginGuard(), new(MockAcademicUnitRepo), nil)
+}
+
+func TestCreateUserValidation(t *testing.T) {
+	mockUser := new(MockUserRepo)
+	service := newUserServiceForTest(mockUser, new(MockRoleRepo))
+
+	_, status, err := service.CreateUser(context.Background(), &models.CreateUserRequest{
+		Username: "budi", Email: "budi@", Password: "lemah", FullName: "Budi", RoleName: "Mahasiswa",
+	})
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+
+	var reqErr *utils.RequestError
+	assert.True(t, errors.As(err, &reqErr))
+	fields := []string{}
+	for _, f := range reqErr.Fields {
+		fields = append(fields, f.Field)
+	}
+	assert.ElementsMatch(t, []string{"email", "password"}, fields)
+	mockUser.AssertNotCalled(t, "FindDuplicateFields", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateUserDuplicate(t *testing.T) {
+	req := func() *models.CreateUserRequest {
+		return &models.CreateUserRequest{
+			Username: " Budi ", Email: "Budi@Kampus.ac.id", Password: "Rahasia123", FullName: "Budi", RoleName: "Mahasiswa",
+		}
+	}
+
+	t.Run("Conflicting Field Reported", func(t *testing.T) {
+		mockUser := new(MockUserRepo)
+		service := newUserServiceForTest(mockUser, new(MockRoleRepo))
+		mockUser.On("FindDuplicateFields", mock.Anything, models.UserUniqueFields{Username: "budi", Email: "Budi@Kampus.ac.id"}, uuid.Nil).
+			Return([]string{"email"}, nil)
+
+		_, status, err := service.CreateUser(context.Background(), req())
+		assert.Equal(t, http.StatusConflict, status)
+
+		var reqErr *utils.RequestError
+		assert.True(t, errors.As(err, &reqErr))
+		assert.Equal(t, []utils.FieldError{{Field: "email", Message: "email already exists"}}, reqErr.Fields)
+		mockUser.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unique Violation From Database Mapped To 409", func(t *testing.T) {
+		mockUser := new(MockUserRepo)
+		mockRole := new(MockRoleRepo)
+		service := newUserServiceForTest(mockUser, mockRole)
+		mockUser.On("FindDuplicateFields", mock.Anything, mock.Anything, uuid.Nil).Return([]string{}, nil)
+		mockRole.On("GetRoleByName", mock.Anything, "Mahasiswa").Return(&models.Role{ID: uuid.New(), Name: "Mahasiswa"}, nil)
+		mockUser.On("CreateUser", mock.Anything, mock.Anything).Return(nil, &repositories.DuplicateFieldError{Field: "username"})
+
+		_, status, err := service.CreateUser(context.Background(), req())
+		assert.Equal(t, http.StatusConflict, status)
+		assert.EqualError(t, err, "username already exists")
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockUser := new(MockUserRepo)
+		mockRole := new(MockRoleRepo)
+		service := newUserServiceForTest(mockUser, mockRole)
+		mockUser.On("FindDuplicateFields", mock.Anything, mock.Anything, uuid.Nil).Return([]string{}, nil)
+		mockRole.On("GetRoleByName", mock.Anything, "Mahasiswa").Return(&models.Role{ID: uuid.New(), Name: "Mahasiswa"}, nil)
+		mockUser.On("CreateUser", mock.Anything, mock.MatchedBy(func(u *models.User) bool { return u.Username == "budi" })).
+			Return(&models.User{Username: "budi"}, nil)
+
+		_, status, err := service.CreateUser(context.Background(), req())
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, status)
+	})
+}
+
+func TestUpdateUserEmailDuplicate(t *testing.T) {
+	mockUser := new(MockUserRepo)
+	service := newUserServiceForTest(mockUser, new(MockRoleRepo))
+	userID := uuid.New()
+	email := "dosen@kampus.ac.id"
+
+	mockUser.On("GetUserByID", mock.Anything, userID).Return(&models.User{ID: userID}, nil)
+	mockUser.On("FindDuplicateFields", mock.Anything, models.UserUniqueFields{Email: email}, userID).Return([]string{"email"}, nil)
+
+	_, status, err := service.UpdateUser(context.Background(), userID, &models.UpdateUserRequest{Email: &email})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusConflict, status)
+	mockUser.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSetLecturerProfileDuplicateNIP(t *testing.T) {
+	mockUser := new(MockUserRepo)
+	mockProfile := new(MockProfileRepo)
+	mockUnit := new(MockAcademicUnitRepo)
+	service := services.NewUserService(mockUser, new(MockRoleRepo), mockProfile, new(MockResetRepo), newTestLoginGuard(), mockUnit, nil)
+	userID, departmentID := uuid.New(), uuid.New()
+
+	mockUser.On("GetUserByID", mock.Anything, userID).Return(&models.User{ID: userID}, nil)
+	mockUnit.On("GetDepartmentByID", mock.Anything, departmentID).Return(&models.Department{ID: departmentID, Name: "Teknik"}, nil)
+	mockUser.On("FindDuplicateFields", mock.Anything, models.UserUniqueFields{LecturerID: "198001012005011001"}, userID).Return([]string{"lecturerId"}, nil)
+
+	_, status, err := service.SetLecturerProfile(context.Background(), userID, &models.LecturerProfileRequest{
+		LecturerID: "198001012005011001", DepartmentID: departmentID.String(),
+	})
+	assert.Equal(t, http.StatusConflict, status)
+	assert.EqualError(t, err, "lecturer ID (NIP) already registered")
+	mockProfile.AssertNotCalled(t, "UpsertLecturer", mock.Anything, mock.Anything)
+}
+
+func TestListUsers(t *testing.T) {
+	t.Run("Normalizes Paging And Embeds Profiles", func(t *testing.T) {
+		mockUser := new(MockUserRepo)
+		service := newUserServiceForTest(mockUser, new(MockRoleRepo))
+		userID := uuid.New()
+
+		mockUser.On("ListUsers", mock.Anything, mock.MatchedBy(func(q models.UserListQuery) bool {
+			return q.Page == 1 && q.Limit == models.MaxPageLimit && q.Search == "2110" && q.SortBy == models.UserSortUsername
+		})).Return([]models.UserListItem{{ID: userID, Username: "budi", Student: &models.Student{UserID: userID, StudentID: "2110511001"}}}, 241, nil)
+
+		res, status, err := service.ListUsers(context.Background(), models.UserListQuery{Page: 0, Limit: 500, Search: " 2110 "})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, models.Pagination{Page: 1, Limit: 100, Total: 241, TotalPages: 3}, res.Pagination)
+		assert.Equal(t, "2110511001", res.Items[0].Student.StudentID)
+	})
+
+	t.Run("Unknown Sort Field Rejected", func(t *testing.T) {
+		mockUser := new(MockUserRepo)
+		service := newUserServiceForTest(mockUser, new(MockRoleRepo))
+
+		_, status, err := service.ListUsers(context.Background(), models.UserListQuery{SortBy: "password_hash"})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, status)
+		mockUser.AssertNotCalled(t, "ListUsers", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// GetEnv mengambil environment variable, atau fallback jika tidak di-set
+func GetEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// GetEnvInt mengambil environment variable bertipe integer
+func GetEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// GetEnvDuration mengambil environment variable bertipe durasi (contoh: "30m", "24h")
+func GetEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// GetEnvPositiveDuration seperti GetEnvDuration, tetapi nilai nol/negatif diganti fallback
+// (dipakai untuk interval job; time.NewTicker panic jika interval <= 0)
+func GetEnvPositiveDuration(key string, fallback time.Duration) time.Duration {
+	if value := GetEnvDuration(key, fallback); value > 0 {
+		return value
+	}
+	return fallback
+}
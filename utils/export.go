@@ -0,0 +1,465 @@
+package utils
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	ExportCSV  = "csv"
+	ExportXLSX = "xlsx"
+	ExportPDF  = "pdf"
+)
+
+// ErrUnsupportedExportFormat dikembalikan jika format ekspor bukan csv, xlsx, atau pdf
+var ErrUnsupportedExportFormat = errors.New("unsupported export format, use csv, xlsx or pdf")
+
+// ExportColumn adalah satu kolom tabel ekspor. Width adalah bobot relatif lebar kolom (dipakai PDF & XLSX).
+type ExportColumn struct {
+	Header string
+	Width  float64
+}
+
+// ReportWriter menulis dokumen laporan berisi judul dan satu atau lebih tabel secara berurutan,
+// sehingga baris dapat dialirkan langsung dari database tanpa menampung seluruh data.
+type ReportWriter interface {
+	Title(title string, meta ...string) error
+	Table(caption string, columns []ExportColumn) error
+	Row(values ...interface{}) error
+	Close() error
+}
+
+// SheetWriter diimplementasikan writer yang mendukung pemisahan bagian dokumen:
+// worksheet baru di XLSX, halaman baru di PDF. CSV tidak mendukungnya.
+type SheetWriter interface {
+	Sheet(name string) error
+}
+
+// ExportContentType mengembalikan MIME type untuk format ekspor
+func ExportContentType(format string) string {
+	switch format {
+	case ExportCSV:
+		return "text/csv; charset=utf-8"
+	case ExportXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case ExportPDF:
+		return "application/pdf"
+	}
+	return "application/octet-stream"
+}
+
+// ValidateExportFormat memastikan format didukung (default csv jika kosong)
+func ValidateExportFormat(format string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	switch format {
+	case "":
+		return ExportCSV, nil
+	case ExportCSV, ExportXLSX, ExportPDF:
+		return format, nil
+	}
+	return "", ErrUnsupportedExportFormat
+}
+
+// NewReportWriter membuat writer sesuai format. Output ditulis ke w paling lambat saat Close.
+func NewReportWriter(format string, w io.Writer) (ReportWriter, error) {
+	switch format {
+	case ExportCSV:
+		return newCSVReportWriter(w)
+	case ExportXLSX:
+		return newXLSXReportWriter(w)
+	case ExportPDF:
+		return newPDFReportWriter(w), nil
+	}
+	return nil, ErrUnsupportedExportFormat
+}
+
+func formatExportValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		return v.Format("02-01-2006")
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format("02-01-2006")
+	}
+	return fmt.Sprint(value)
+}
+
+// --- CSV: hanya tabel; judul diabaikan agar file tetap mudah diolah ---
+
+type csvReportWriter struct {
+	w      *csv.Writer
+	tables int
+}
+
+func newCSVReportWriter(w io.Writer) (*csvReportWriter, error) {
+	// BOM agar Excel membaca UTF-8 dengan benar
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return nil, err
+	}
+	return &csvReportWriter{w: csv.NewWriter(w)}, nil
+}
+
+func (c *csvReportWriter) Title(string, ...string) error { return nil }
+
+func (c *csvReportWriter) Table(caption string, columns []ExportColumn) error {
+	if c.tables > 0 {
+		if err := c.w.Write([]string{}); err != nil {
+			return err
+		}
+	}
+	c.tables++
+	if caption != "" {
+		if err := c.w.Write([]string{caption}); err != nil {
+			return err
+		}
+	}
+	headers := make([]string, len(columns))
+	for i, col := range columns {
+		headers[i] = col.Header
+	}
+	return c.w.Write(headers)
+}
+
+func (c *csvReportWriter) Row(values ...interface{}) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		record[i] = formatExportValue(v)
+	}
+	if err := c.w.Write(record); err != nil {
+		return err
+	}
+	c.w.Flush() // dialirkan per baris
+	return c.w.Error()
+}
+
+func (c *csvReportWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// --- XLSX: StreamWriter excelize (baris disimpan ke file sementara, bukan memori) ---
+
+const xlsxSheetName = "Laporan"
+
+type xlsxReportWriter struct {
+	out         io.Writer
+	file        *excelize.File
+	stream      *excelize.StreamWriter // dibuat saat worksheet pertama dipakai
+	sheets      map[string]bool
+	row         int
+	titleStyle  int
+	headerStyle int
+	widthsSet   bool
+	pending     [][]interface{} // baris judul ditahan sampai lebar kolom diatur di tabel pertama
+}
+
+func newXLSXReportWriter(w io.Writer) (*xlsxReportWriter, error) {
+	f := excelize.NewFile()
+	titleStyle, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true, Size: 14}})
+	if err != nil {
+		return nil, err
+	}
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font:   &excelize.Font{Bold: true},
+		Fill:   excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"D9E1F2"}},
+		Border: []excelize.Border{{Type: "bottom", Color: "000000", Style: 1}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &xlsxReportWriter{out: w, file: f, sheets: map[string]bool{}, row: 1, titleStyle: titleStyle, headerStyle: headerStyle}, nil
+}
+
+// Sheet menutup worksheet aktif lalu memulai worksheet baru dengan nama yang sudah disesuaikan aturan Excel
+func (x *xlsxReportWriter) Sheet(name string) error {
+	name = uniqueSheetName(name, x.sheets)
+	if x.stream == nil {
+		if err := x.file.SetSheetName("Sheet1", name); err != nil {
+			return err
+		}
+	} else {
+		if err := x.flushPending(); err != nil {
+			return err
+		}
+		if err := x.stream.Flush(); err != nil {
+			return err
+		}
+		if _, err := x.file.NewSheet(name); err != nil {
+			return err
+		}
+	}
+
+	stream, err := x.file.NewStreamWriter(name)
+	if err != nil {
+		return err
+	}
+	x.sheets[strings.ToLower(name)] = true
+	x.stream, x.row, x.widthsSet = stream, 1, false
+	return nil
+}
+
+func (x *xlsxReportWriter) ensureSheet() error {
+	if x.stream != nil {
+		return nil
+	}
+	return x.Sheet(xlsxSheetName)
+}
+
+// uniqueSheetName: maksimal 31 karakter, tanpa karakter terlarang, dan tidak bentrok dengan worksheet lain
+func uniqueSheetName(name string, used map[string]bool) string {
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return ' '
+		}
+		return r
+	}, name))
+	if name == "" {
+		name = xlsxSheetName
+	}
+	base := []rune(name)
+	if len(base) > 31 {
+		base = base[:31]
+	}
+	candidate := string(base)
+	for i := 2; used[strings.ToLower(candidate)]; i++ {
+		suffix := fmt.Sprintf(" (%d)", i)
+		trimmed := base
+		if len(trimmed)+len(suffix) > 31 {
+			trimmed = trimmed[:31-len(suffix)]
+		}
+		candidate = string(trimmed) + suffix
+	}
+	return candidate
+}
+
+func (x *xlsxReportWriter) writeRow(values []interface{}) error {
+	if err := x.ensureSheet(); err != nil {
+		return err
+	}
+	cell, _ := excelize.CoordinatesToCellName(1, x.row)
+	x.row++
+	return x.stream.SetRow(cell, values)
+}
+
+func (x *xlsxReportWriter) Title(title string, meta ...string) error {
+	x.pending = append(x.pending, []interface{}{excelize.Cell{StyleID: x.titleStyle, Value: title}})
+	for _, line := range meta {
+		x.pending = append(x.pending, []interface{}{line})
+	}
+	x.pending = append(x.pending, nil) // baris kosong pemisah
+	return nil
+}
+
+func (x *xlsxReportWriter) flushPending() error {
+	for _, values := range x.pending {
+		if values == nil {
+			x.row++
+			continue
+		}
+		if err := x.writeRow(values); err != nil {
+			return err
+		}
+	}
+	x.pending = nil
+	return nil
+}
+
+func (x *xlsxReportWriter) Table(caption string, columns []ExportColumn) error {
+	if err := x.ensureSheet(); err != nil {
+		return err
+	}
+	// Lebar kolom StreamWriter hanya boleh diatur sebelum baris pertama; dipakai kolom tabel pertama
+	if !x.widthsSet {
+		for i, col := range columns {
+			width := col.Width * 4
+			if width < 10 {
+				width = 10
+			}
+			if err := x.stream.SetColWidth(i+1, i+1, width); err != nil {
+				return err
+			}
+		}
+		x.widthsSet = true
+	}
+	if err := x.flushPending(); err != nil {
+		return err
+	}
+
+	if caption != "" {
+		if err := x.writeRow([]interface{}{excelize.Cell{StyleID: x.titleStyle, Value: caption}}); err != nil {
+			return err
+		}
+	}
+	headers := make([]interface{}, len(columns))
+	for i, col := range columns {
+		headers[i] = excelize.Cell{StyleID: x.headerStyle, Value: col.Header}
+	}
+	return x.writeRow(headers)
+}
+
+func (x *xlsxReportWriter) Row(values ...interface{}) error {
+	cells := make([]interface{}, len(values))
+	for i, v := range values {
+		switch v.(type) {
+		case int, int64, float64:
+			cells[i] = v
+		default:
+			cells[i] = formatExportValue(v)
+		}
+	}
+	return x.writeRow(cells)
+}
+
+func (x *xlsxReportWriter) Close() error {
+	defer x.file.Close()
+	if err := x.ensureSheet(); err != nil {
+		return err
+	}
+	if err := x.flushPending(); err != nil {
+		return err
+	}
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	_, err := x.file.WriteTo(x.out)
+	return err
+}
+
+// --- PDF: template A4 landscape dengan kop institusi dan nomor halaman ---
+
+const (
+	pdfMargin    = 12.0
+	pdfRowHeight = 6.5
+)
+
+type pdfReportWriter struct {
+	out       io.Writer
+	pdf       *fpdf.Fpdf
+	translate func(string) string
+	columns   []ExportColumn
+	widths    []float64
+	started   bool // halaman aktif sudah berisi konten
+}
+
+func newPDFReportWriter(w io.Writer) *pdfReportWriter {
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin+4)
+	pdf.AliasNbPages("{nb}")
+
+	p := &pdfReportWriter{out: w, pdf: pdf, translate: pdf.UnicodeTranslatorFromDescriptor("")}
+	institution := GetEnv("REPORT_INSTITUTION_NAME", "Sistem Pelaporan Prestasi Mahasiswa")
+	printedAt := time.Now().Format("02-01-2006 15:04")
+
+	pdf.SetHeaderFunc(func() {
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.SetTextColor(90, 90, 90)
+		pdf.CellFormat(0, 5, p.translate(institution), "B", 1, "L", false, 0, "")
+		pdf.Ln(3)
+		pdf.SetTextColor(0, 0, 0)
+		// Header tabel diulang di setiap halaman baru
+		if p.columns != nil && pdf.PageNo() > 1 {
+			p.tableHeader()
+		}
+	})
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargin)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.SetTextColor(90, 90, 90)
+		pdf.CellFormat(0, 5, p.translate("Dicetak: "+printedAt), "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 5, fmt.Sprintf("Halaman %d/{nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+	pdf.AddPage()
+	return p
+}
+
+// Sheet memulai bagian baru di halaman baru (halaman pertama dipakai jika masih kosong)
+func (p *pdfReportWriter) Sheet(string) error {
+	p.columns = nil
+	if p.started {
+		p.pdf.AddPage()
+		p.started = false
+	}
+	return p.pdf.Error()
+}
+
+func (p *pdfReportWriter) Title(title string, meta ...string) error {
+	p.started = true
+	p.pdf.SetFont("Helvetica", "B", 14)
+	p.pdf.CellFormat(0, 8, p.translate(title), "", 1, "L", false, 0, "")
+	p.pdf.SetFont("Helvetica", "", 10)
+	for _, line := range meta {
+		p.pdf.CellFormat(0, 5.5, p.translate(line), "", 1, "L", false, 0, "")
+	}
+	p.pdf.Ln(3)
+	return p.pdf.Error()
+}
+
+func (p *pdfReportWriter) Table(caption string, columns []ExportColumn) error {
+	p.started = true
+	if p.columns != nil {
+		p.pdf.Ln(4)
+	}
+	if caption != "" {
+		p.pdf.SetFont("Helvetica", "B", 11)
+		p.pdf.CellFormat(0, 7, p.translate(caption), "", 1, "L", false, 0, "")
+	}
+
+	pageWidth, _ := p.pdf.GetPageSize()
+	usable := pageWidth - 2*pdfMargin
+	total := 0.0
+	for _, col := range columns {
+		total += col.Width
+	}
+	p.columns = columns
+	p.widths = make([]float64, len(columns))
+	for i, col := range columns {
+		p.widths[i] = usable * col.Width / total
+	}
+	p.tableHeader()
+	return p.pdf.Error()
+}
+
+func (p *pdfReportWriter) tableHeader() {
+	p.pdf.SetFont("Helvetica", "B", 9)
+	p.pdf.SetFillColor(217, 225, 242)
+	for i, col := range p.columns {
+		p.pdf.CellFormat(p.widths[i], pdfRowHeight, p.translate(col.Header), "1", 0, "C", true, 0, "")
+	}
+	p.pdf.Ln(-1)
+	p.pdf.SetFont("Helvetica", "", 9)
+}
+
+func (p *pdfReportWriter) Row(values ...interface{}) error {
+	for i, v := range values {
+		if i >= len(p.widths) {
+			break
+		}
+		align := "L"
+		switch v.(type) {
+		case int, int64, float64:
+			align = "R"
+		}
+		text := fitText(p.pdf, p.translate(formatExportValue(v)), p.widths[i]-2)
+		p.pdf.CellFormat(p.widths[i], pdfRowHeight, text, "1", 0, align, false, 0, "")
+	}
+	p.pdf.Ln(-1)
+	return p.pdf.Error()
+}
+
+func (p *pdfReportWriter) Close() error {
+	return p.pdf.Output(p.out)
+}
@@ -14,21 +14,24 @@ type JWTCustomClaims struct {
 	UserID      uuid.UUID `json:"userId"`
 	Role        string    `json:"role"`
 	Permissions []string  `json:"permissions"`
+	// SessionVersion harus sama dengan users.session_version; berubah saat sesi dicabut
+	SessionVersion int `json:"sv"`
 	jwt.RegisteredClaims
 }
 
 var jwtSecret = []byte(os.Getenv("JWT_SECRET"))
 
-func GenerateAuthTokens(userID string, role string, permissions []string) (string, string, error) {
+func GenerateAuthTokens(userID string, role string, permissions []string, sessionVersion int) (string, string, error) {
 	id, err := uuid.Parse(userID)
 	if err != nil {
 		return "", "", fmt.Errorf("invalid user ID: %w", err)
 	}
 
 	claims := &JWTCustomClaims{
-		UserID:      id,
-		Role:        role,
-		Permissions: permissions,
+		UserID:         id,
+		Role:           role,
+		Permissions:    permissions,
+		SessionVersion: sessionVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 24)), // 24 jam
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -61,4 +64,50 @@ func ValidateToken(tokenString string) (*JWTCustomClaims, error) {
 	}
 
 	return nil, fmt.Errorf("invalid token claims")
-}
\ No newline at end of file
+}
+
+const (
+	MFAChallengeVerify = "mfa_verify" // user sudah enroll, tinggal memasukkan kode
+	MFAChallengeEnroll = "mfa_enroll" // role wajib 2FA tetapi user belum enroll
+)
+
+// MFAChallengeClaims adalah token sementara antara tahap password dan tahap kode 2FA
+type MFAChallengeClaims struct {
+	UserID  uuid.UUID `json:"userId"`
+	Purpose string    `json:"purpose"`
+	jwt.RegisteredClaims
+}
+
+// Challenge token ditandatangani dengan kunci turunan sehingga tidak bisa dipakai sebagai access token
+func mfaChallengeSecret() []byte {
+	return append(append([]byte{}, jwtSecret...), []byte(":mfa-challenge")...)
+}
+
+func GenerateMFAChallengeToken(userID uuid.UUID, purpose string, ttl time.Duration) (string, error) {
+	claims := &MFAChallengeClaims{
+		UserID:  userID,
+		Purpose: purpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(mfaChallengeSecret())
+}
+
+func ValidateMFAChallengeToken(tokenString string) (*MFAChallengeClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &MFAChallengeClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return mfaChallengeSecret(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if claims, ok := token.Claims.(*MFAChallengeClaims); ok && token.Valid {
+		return claims, nil
+	}
+	return nil, fmt.Errorf("invalid challenge token claims")
+}
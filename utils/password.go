@@ -1,6 +1,14 @@
 package utils
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"unicode"
+
+	"golang.org/x/crypto/bcrypt"
+)
 
 // HashPassword mengenkripsi password menggunakan bcrypt
 func HashPassword(password string) (string, error) {
@@ -12,4 +20,74 @@ func HashPassword(password string) (string, error) {
 func CheckPasswordHash(password, hash string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
-}
\ No newline at end of file
+}
+
+const (
+	PasswordMinLength = 8
+	PasswordMaxLength = 72 // batas input bcrypt
+)
+
+// ValidatePasswordPolicy memastikan password memenuhi kebijakan minimum:
+// 8-72 karakter serta mengandung huruf besar, huruf kecil, dan angka.
+func ValidatePasswordPolicy(password string) error {
+	if len(password) < PasswordMinLength {
+		return fmt.Errorf("password must be at least %d characters", PasswordMinLength)
+	}
+	if len(password) > PasswordMaxLength {
+		return fmt.Errorf("password must be at most %d characters", PasswordMaxLength)
+	}
+
+	var hasUpper, hasLower, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasUpper || !hasLower || !hasDigit {
+		return errors.New("password must contain uppercase letters, lowercase letters and digits")
+	}
+	return nil
+}
+
+const (
+	initialPasswordLength = 12
+	passwordUpper         = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	passwordLower         = "abcdefghijkmnopqrstuvwxyz"
+	passwordDigits        = "23456789"
+)
+
+// GenerateInitialPassword membuat password awal acak yang lolos ValidatePasswordPolicy.
+// Karakter yang mirip (0/O, 1/l/I) dihindari agar mudah disalin user.
+func GenerateInitialPassword() (string, error) {
+	all := passwordUpper + passwordLower + passwordDigits
+	sets := []string{passwordUpper, passwordLower, passwordDigits}
+
+	password := make([]byte, initialPasswordLength)
+	for i := range password {
+		charset := all
+		if i < len(sets) {
+			charset = sets[i] // jamin minimal satu karakter dari tiap kelompok
+		}
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+		if err != nil {
+			return "", err
+		}
+		password[i] = charset[n.Int64()]
+	}
+
+	// Acak posisi agar kelompok wajib tidak selalu di depan
+	for i := len(password) - 1; i > 0; i-- {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		j := n.Int64()
+		password[i], password[j] = password[j], password[i]
+	}
+	return string(password), nil
+}
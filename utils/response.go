@@ -1,12 +1,33 @@
 package utils
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+)
 
 // Response data structure
 type JSONResponse struct {
-	Status  string      `json:"status"`
-	Message string      `json:"message,omitempty"`
-	Data    interface{} `json:"data,omitempty"`
+	Status  string       `json:"status"`
+	Message string       `json:"message,omitempty"`
+	Data    interface{}  `json:"data,omitempty"`
+	Errors  []FieldError `json:"errors,omitempty"` // Detail per field (409/422)
+}
+
+// FieldError menjelaskan kesalahan pada satu field input
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// RequestError adalah error yang membawa detail field (mis. duplikat atau validasi gagal)
+type RequestError struct {
+	Message string
+	Fields  []FieldError
+}
+
+func (e *RequestError) Error() string {
+	return e.Message
 }
 
 // SuccessResponse mengirim respon sukses (200, 201, dll)
@@ -24,4 +45,17 @@ func ErrorResponse(c *fiber.Ctx, statusCode int, message string) error {
 		Status:  "error",
 		Message: message,
 	})
-}
\ No newline at end of file
+}
+
+// ErrorResponseFromError seperti ErrorResponse, tetapi menyertakan detail field jika err adalah RequestError
+func ErrorResponseFromError(c *fiber.Ctx, statusCode int, err error) error {
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		return c.Status(statusCode).JSON(JSONResponse{
+			Status:  "error",
+			Message: reqErr.Message,
+			Errors:  reqErr.Fields,
+		})
+	}
+	return ErrorResponse(c, statusCode, err.Error())
+}
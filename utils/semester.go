@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	SemesterOdd  = "Ganjil" // Agustus - Januari
+	SemesterEven = "Genap"  // Februari - Juli
+)
+
+// Semester akademik, mis. {AcademicYear: "2024/2025", Term: "Ganjil"}
+type Semester struct {
+	AcademicYear string `json:"academicYear"`
+	Term         string `json:"term"`
+}
+
+// SemesterOf menentukan semester akademik dari sebuah tanggal.
+// Tahun akademik dimulai bulan Agustus; Januari masih termasuk semester ganjil tahun sebelumnya.
+func SemesterOf(t time.Time) Semester {
+	year, month := t.Year(), t.Month()
+	switch {
+	case month >= time.August:
+		return Semester{AcademicYear: fmt.Sprintf("%d/%d", year, year+1), Term: SemesterOdd}
+	case month == time.January:
+		return Semester{AcademicYear: fmt.Sprintf("%d/%d", year-1, year), Term: SemesterOdd}
+	default:
+		return Semester{AcademicYear: fmt.Sprintf("%d/%d", year-1, year), Term: SemesterEven}
+	}
+}
+
+// Label dipakai sebagai kunci pengelompokan sekaligus teks tampilan, mis. "2024/2025 Ganjil"
+func (s Semester) Label() string {
+	return s.AcademicYear + " " + s.Term
+}
+
+// Before membandingkan urutan kronologis dua semester
+func (s Semester) Before(other Semester) bool {
+	if s.AcademicYear != other.AcademicYear {
+		return s.AcademicYear < other.AcademicYear
+	}
+	return s.Term == SemesterOdd && other.Term == SemesterEven
+}
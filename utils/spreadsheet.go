@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ErrUnsupportedSpreadsheet dikembalikan jika ekstensi file bukan .csv atau .xlsx
+var ErrUnsupportedSpreadsheet = errors.New("unsupported file type, use .csv or .xlsx")
+
+// ReadSpreadsheet membaca seluruh baris dari file CSV atau XLSX (sheet pertama).
+// Baris pertama dikembalikan apa adanya (biasanya header).
+func ReadSpreadsheet(filename string, r io.Reader) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1 // jumlah kolom per baris boleh berbeda, divalidasi oleh pemanggil
+		reader.TrimLeadingSpace = true
+
+		// encoding/csv melewati baris kosong; baris kosong diisi ulang agar indeks = nomor baris di file - 1
+		var rows [][]string
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse CSV: %w", err)
+			}
+			line, _ := reader.FieldPos(0)
+			for len(rows) < line-1 {
+				rows = append(rows, []string{})
+			}
+			rows = append(rows, record)
+		}
+		if len(rows) > 0 && len(rows[0]) > 0 {
+			rows[0][0] = strings.TrimPrefix(rows[0][0], "\ufeff") // BOM dari Excel
+		}
+		return rows, nil
+	case ".xlsx":
+		f, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse XLSX: %w", err)
+		}
+		defer f.Close()
+
+		sheets := f.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, errors.New("XLSX file has no sheets")
+		}
+		rows, err := f.GetRows(sheets[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read XLSX rows: %w", err)
+		}
+		return rows, nil
+	default:
+		return nil, ErrUnsupportedSpreadsheet
+	}
+}
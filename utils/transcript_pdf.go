@@ -0,0 +1,171 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"io"
+	"time"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/qr"
+	"github.com/go-pdf/fpdf"
+)
+
+// TranscriptDocument adalah isi SKPI (Surat Keterangan Pendamping Ijazah) yang siap dicetak
+type TranscriptDocument struct {
+	Code          string
+	VerifyURL     string // isi QR code
+	StudentName   string
+	StudentNumber string
+	ProgramStudy  string
+	AcademicYear  string
+	IssuedAt      time.Time
+	Groups        []TranscriptGroup
+	TotalPoints   int
+}
+
+// TranscriptGroup adalah kelompok prestasi per jenis
+type TranscriptGroup struct {
+	Label string
+	Items []TranscriptItem
+}
+
+type TranscriptItem struct {
+	Title      string
+	Points     int
+	VerifiedAt *time.Time
+	Verifier   string
+}
+
+const transcriptQRSize = 32.0
+
+// WriteTranscriptPDF merender SKPI (A4 portrait) lengkap dengan QR code menuju halaman verifikasi
+func WriteTranscriptPDF(w io.Writer, doc TranscriptDocument) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin+6, pdfMargin, pdfMargin+6)
+	pdf.SetAutoPageBreak(true, pdfMargin+4)
+	pdf.AliasNbPages("{nb}")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargin)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.SetTextColor(90, 90, 90)
+		pdf.CellFormat(0, 5, tr("Nomor dokumen: "+doc.Code), "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 5, fmt.Sprintf("Halaman %d/{nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	})
+	pdf.AddPage()
+
+	// Kop dokumen
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.CellFormat(0, 6, tr(GetEnv("REPORT_INSTITUTION_NAME", "Sistem Pelaporan Prestasi Mahasiswa")), "", 1, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 8, "SURAT KETERANGAN PENDAMPING IJAZAH", "B", 1, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, tr("Daftar Prestasi Mahasiswa Terverifikasi"), "", 1, "C", false, 0, "")
+	pdf.Ln(4)
+
+	identity := [][2]string{
+		{"Nama", doc.StudentName},
+		{"NIM", doc.StudentNumber},
+		{"Program Studi", doc.ProgramStudy},
+		{"Angkatan", doc.AcademicYear},
+		{"Tanggal Terbit", doc.IssuedAt.Format("02-01-2006")},
+	}
+	for _, line := range identity {
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(35, 6, tr(line[0]), "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 6, tr(": "+line[1]), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	pageWidth, _ := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
+	usable := pageWidth - left - right
+	widths := []float64{0.07 * usable, 0.45 * usable, 0.1 * usable, 0.16 * usable, 0.22 * usable}
+	headers := []string{"No", "Judul Prestasi", "Poin", "Tgl Verifikasi", "Diverifikasi Oleh"}
+
+	if len(doc.Groups) == 0 {
+		pdf.SetFont("Helvetica", "I", 10)
+		pdf.CellFormat(0, 6, tr("Belum ada prestasi terverifikasi."), "", 1, "L", false, 0, "")
+	}
+	for _, group := range doc.Groups {
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.CellFormat(0, 7, tr(group.Label), "", 1, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.SetFillColor(217, 225, 242)
+		for i, h := range headers {
+			pdf.CellFormat(widths[i], pdfRowHeight, tr(h), "1", 0, "C", true, 0, "")
+		}
+		pdf.Ln(-1)
+
+		pdf.SetFont("Helvetica", "", 9)
+		for i, item := range group.Items {
+			values := []string{fmt.Sprint(i + 1), item.Title, fmt.Sprint(item.Points), formatExportValue(item.VerifiedAt), item.Verifier}
+			for j, v := range values {
+				align := "L"
+				if j == 0 || j == 2 {
+					align = "R"
+				}
+				pdf.CellFormat(widths[j], pdfRowHeight, fitText(pdf, tr(v), widths[j]-2), "1", 0, align, false, 0, "")
+			}
+			pdf.Ln(-1)
+		}
+		pdf.Ln(3)
+	}
+
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(0, 7, fmt.Sprintf("Total poin prestasi terverifikasi: %d", doc.TotalPoints), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	// QR verifikasi; pindah halaman bila sisa ruang tidak cukup
+	_, pageHeight := pdf.GetPageSize()
+	if pdf.GetY()+transcriptQRSize+pdfMargin+8 > pageHeight {
+		pdf.AddPage()
+	}
+	qrPNG, err := encodeQRPNG(doc.VerifyURL)
+	if err != nil {
+		return err
+	}
+	pdf.RegisterImageOptionsReader("qr", fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(qrPNG))
+	y := pdf.GetY()
+	pdf.ImageOptions("qr", left, y, transcriptQRSize, transcriptQRSize, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+	pdf.SetXY(left+transcriptQRSize+4, y+4)
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.MultiCell(usable-transcriptQRSize-4, 5, tr("Keaslian dokumen ini dapat diverifikasi dengan memindai QR code atau membuka:\n"+doc.VerifyURL), "", "L", false)
+
+	if err := pdf.Error(); err != nil {
+		return err
+	}
+	return pdf.Output(w)
+}
+
+// encodeQRPNG membuat QR code (PNG) dengan koreksi error level M
+func encodeQRPNG(content string) ([]byte, error) {
+	code, err := qr.Encode(content, qr.M, qr.Auto)
+	if err != nil {
+		return nil, err
+	}
+	code, err = barcode.Scale(code, 256, 256)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, code); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// fitText memotong teks yang melebihi lebar sel dan menambahkan "..."
+func fitText(pdf *fpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	for len(text) > 0 && pdf.GetStringWidth(text+"...") > width {
+		text = text[:len(text)-1]
+	}
+	return text + "..."
+}